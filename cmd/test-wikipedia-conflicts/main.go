@@ -8,7 +8,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ppiankov/entropia/internal/entities"
 	"github.com/ppiankov/entropia/internal/extract/adapters"
+	"github.com/ppiankov/entropia/internal/model"
 )
 
 func main() {
@@ -28,12 +30,12 @@ func main() {
 		fmt.Println(strings.Repeat("-", 60))
 
 		// Test edit war detection
-		editWar, err := adapters.DetectEditWar(ctx, url)
+		editWar, err := adapters.DetectEditWar(ctx, url, model.DefaultConfig().Wikipedia)
 		if err != nil {
 			fmt.Printf("  Edit war check error: %v\n", err)
 		} else if editWar.IsHighConflict {
 			fmt.Printf("  ⚠️  EDIT WAR DETECTED\n")
-			fmt.Printf("     - Recent edits (30 days): %d\n", editWar.RecentEdits)
+			fmt.Printf("     - Recent edits (%.0f days): %d\n", editWar.WindowDays, editWar.RecentEdits)
 			fmt.Printf("     - Reverts: %d\n", editWar.RevertCount)
 			fmt.Printf("     - Unique editors: %d\n", editWar.UniqueEditors)
 			fmt.Printf("     - Edit frequency: %.2f edits/day\n", editWar.EditFrequency)
@@ -54,16 +56,16 @@ func main() {
 		In the Soviet Union, borscht became a symbol of Eastern European cuisine.
 		`
 
-		conflicts := adapters.DetectHistoricalEntities(testText)
-		if len(conflicts) > 0 {
-			fmt.Printf("\n  ⚠️  HISTORICAL ENTITIES DETECTED: %d\n", len(conflicts))
-			for _, hc := range conflicts {
-				yearsAgo := 2026 - hc.Entity.EndYear
+		matches := entities.Default().Find(testText)
+		if len(matches) > 0 {
+			fmt.Printf("\n  ⚠️  HISTORICAL ENTITIES DETECTED: %d\n", len(matches))
+			for _, match := range matches {
+				yearsAgo := time.Now().Year() - match.Entity.EndYear
 				fmt.Printf("     - %s (ended %d, %d years ago)\n",
-					hc.Entity.Name, hc.Entity.EndYear, yearsAgo)
-				fmt.Printf("       %s\n", hc.Entity.Description)
-				if len(hc.Context) > 0 {
-					fmt.Printf("       Context: %s\n", hc.Context[0])
+					match.Entity.Name, match.Entity.EndYear, yearsAgo)
+				fmt.Printf("       %s\n", match.Entity.Description)
+				if len(match.Context) > 0 {
+					fmt.Printf("       Context: %s\n", match.Context[0])
 				}
 			}
 		}
@@ -0,0 +1,186 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/util"
+)
+
+// timestampLayout is the CDX/Wayback capture timestamp format
+const timestampLayout = "20060102150405"
+
+// lookupWorkers bounds concurrent archive queries per report; public
+// archives rate-limit aggressively
+const lookupWorkers = 4
+
+// Snapshot is an archived capture of a URL
+type Snapshot struct {
+	URL        string    // Replayable snapshot URL
+	Original   string    // URL as captured
+	CapturedAt time.Time // Capture time
+}
+
+// Client queries a Wayback CDX-compatible endpoint
+type Client struct {
+	httpClient  *http.Client
+	cdxURL      string
+	snapshotURL string
+	userAgent   string
+}
+
+// NewClient creates an archive client. cdxURL is the CDX search endpoint
+// (e.g. https://web.archive.org/cdx/search/cdx); snapshots are linked as
+// <snapshotURL>/<timestamp>/<original>. An empty snapshotURL is derived
+// from cdxURL (see SnapshotPrefix).
+func NewClient(cdxURL, snapshotURL string, timeout time.Duration, userAgent, httpProxy, httpsProxy, noProxy string) *Client {
+	if snapshotURL == "" {
+		snapshotURL = SnapshotPrefix(cdxURL)
+	}
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy: util.NewProxyFunc(httpProxy, httpsProxy, noProxy),
+			},
+		},
+		cdxURL:      cdxURL,
+		snapshotURL: strings.TrimSuffix(snapshotURL, "/"),
+		userAgent:   userAgent,
+	}
+}
+
+// SnapshotPrefix derives the replay prefix for a CDX endpoint: Wayback's
+// <base>/cdx/search/cdx replays under <base>/web, pywb's <base>/<coll>/cdx
+// under <base>/<coll>
+func SnapshotPrefix(cdxURL string) string {
+	base := strings.TrimSuffix(cdxURL, "/")
+	if trimmed, ok := strings.CutSuffix(base, "/cdx/search/cdx"); ok {
+		return trimmed + "/web"
+	}
+	return strings.TrimSuffix(base, "/cdx")
+}
+
+// Closest returns the successful capture of rawURL closest to at, or nil
+// when the archive has none
+func (c *Client) Closest(ctx context.Context, rawURL string, at time.Time) (*Snapshot, error) {
+	query := url.Values{}
+	query.Set("url", rawURL)
+	query.Set("output", "json")
+	query.Set("fl", "timestamp,original")
+	query.Set("filter", "statuscode:200")
+	query.Set("closest", at.UTC().Format(timestampLayout))
+	query.Set("sort", "closest")
+	query.Set("limit", "1")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cdxURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("query archive: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("archive returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	// An empty body means no captures
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil, nil
+	}
+
+	// JSON output is a header row followed by one row per capture
+	var rows [][]string
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if len(rows) < 2 || len(rows[1]) < 2 {
+		return nil, nil
+	}
+
+	timestamp, original := rows[1][0], rows[1][1]
+	capturedAt, err := time.Parse(timestampLayout, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("parse timestamp %q: %w", timestamp, err)
+	}
+
+	return &Snapshot{
+		URL:        c.snapshotURL + "/" + timestamp + "/" + original,
+		Original:   original,
+		CapturedAt: capturedAt,
+	}, nil
+}
+
+// AnnotateDead looks up snapshots for dead links (including soft 404s) and
+// records them in place. Links whose lookup fails are left unchecked so
+// they are not reported as lost; links that already have a snapshot are
+// skipped.
+func (c *Client) AnnotateDead(ctx context.Context, results []model.ValidationResult) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, lookupWorkers)
+
+	for i := range results {
+		if !IsDead(results[i]) || results[i].ArchivedURL != "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *model.ValidationResult) {
+			defer wg.Done()
+
+			select {
+			case <-ctx.Done():
+				return
+			case semaphore <- struct{}{}:
+			}
+			defer func() { <-semaphore }()
+
+			// Aim for the capture nearest the last known good state
+			at := time.Now()
+			if r.LastModified != nil {
+				at = *r.LastModified
+			}
+
+			snapshot, err := c.Closest(ctx, r.URL, at)
+			if err != nil {
+				return
+			}
+			r.ArchiveChecked = true
+			if snapshot != nil {
+				capturedAt := snapshot.CapturedAt
+				r.ArchivedURL = snapshot.URL
+				r.ArchivedAt = &capturedAt
+			}
+		}(&results[i])
+	}
+
+	wg.Wait()
+}
+
+// IsDead reports whether a validation result is a dead link worth
+// recovering: an HTTP failure or a soft 404 (missing local files have no
+// web captures)
+func IsDead(r model.ValidationResult) bool {
+	if strings.HasPrefix(r.URL, "file://") {
+		return false
+	}
+	return r.Dead()
+}
@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// newCDXServer returns a CDX stand-in that knows one capture of known
+func newCDXServer(t *testing.T, known string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cdx/search/cdx" {
+			http.NotFound(w, r)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("output") != "json" || query.Get("sort") != "closest" || query.Get("closest") == "" {
+			t.Errorf("Unexpected CDX query: %s", r.URL.RawQuery)
+		}
+		switch query.Get("url") {
+		case known:
+			_, _ = w.Write([]byte(`[["timestamp","original"],["20190304050607","` + known + `"]]`))
+		case "https://broken.example.com/":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+}
+
+func TestClient_Closest(t *testing.T) {
+	server := newCDXServer(t, "https://gone.example.com/report")
+	defer server.Close()
+
+	client := NewClient(server.URL+"/cdx/search/cdx", "", 5*time.Second, "Entropia/test", "", "", "")
+
+	snapshot, err := client.Closest(context.Background(), "https://gone.example.com/report", time.Now())
+	if err != nil {
+		t.Fatalf("Closest failed: %v", err)
+	}
+	if snapshot == nil {
+		t.Fatal("Expected a snapshot")
+	}
+	if want := server.URL + "/web/20190304050607/https://gone.example.com/report"; snapshot.URL != want {
+		t.Errorf("Expected snapshot URL %s, got %s", want, snapshot.URL)
+	}
+	if snapshot.CapturedAt.Format("2006-01-02 15:04:05") != "2019-03-04 05:06:07" {
+		t.Errorf("Unexpected capture time %v", snapshot.CapturedAt)
+	}
+
+	none, err := client.Closest(context.Background(), "https://never.example.com/", time.Now())
+	if err != nil || none != nil {
+		t.Errorf("Expected no snapshot and no error, got %v, %v", none, err)
+	}
+}
+
+func TestClient_AnnotateDead(t *testing.T) {
+	server := newCDXServer(t, "https://gone.example.com/report")
+	defer server.Close()
+
+	client := NewClient(server.URL+"/cdx/search/cdx", "", 5*time.Second, "Entropia/test", "", "", "")
+
+	results := []model.ValidationResult{
+		{URL: "https://alive.example.com/", IsAccessible: true},
+		{URL: "https://gone.example.com/report", IsDead: true},
+		{URL: "https://lost.example.com/page", IsAccessible: true, Soft404: model.Soft404NotFoundPage},
+		{URL: "https://broken.example.com/", IsDead: true},
+	}
+	client.AnnotateDead(context.Background(), results)
+
+	if results[0].ArchiveChecked {
+		t.Error("Expected live link not to be looked up")
+	}
+	if !results[1].ArchiveChecked || results[1].ArchivedURL == "" || results[1].ArchivedAt == nil {
+		t.Errorf("Expected archived snapshot for dead link, got %+v", results[1])
+	}
+	if !results[2].ArchiveChecked || results[2].ArchivedURL != "" {
+		t.Errorf("Expected soft 404 checked and lost, got %+v", results[2])
+	}
+	if results[3].ArchiveChecked {
+		t.Error("Expected failed lookup to leave the link unchecked")
+	}
+}
+
+func TestSnapshotPrefix(t *testing.T) {
+	tests := map[string]string{
+		"https://web.archive.org/cdx/search/cdx":   "https://web.archive.org/web",
+		"http://localhost:8080/my-web-archive/cdx": "http://localhost:8080/my-web-archive",
+	}
+	for cdxURL, want := range tests {
+		if got := SnapshotPrefix(cdxURL); got != want {
+			t.Errorf("SnapshotPrefix(%q) = %q, want %q", cdxURL, got, want)
+		}
+	}
+}
+
+func TestArchiveLinks(t *testing.T) {
+	snapshot := "https://web.archive.org/web/20190512093000/https://example.com/story"
+	if !IsArchiveURL(snapshot) || !IsArchiveURL("https://archive.ph/AbCd1") || IsArchiveURL("https://example.com/archive/2019") {
+		t.Error("Unexpected archive host classification")
+	}
+	if got := Original(snapshot); got != "https://example.com/story" {
+		t.Errorf("Original = %q", got)
+	}
+	capturedAt, ok := SnapshotTime(snapshot)
+	if !ok || capturedAt.Format("2006-01-02 15:04") != "2019-05-12 09:30" {
+		t.Errorf("SnapshotTime = %v, %v", capturedAt, ok)
+	}
+	if capturedAt, ok := SnapshotTime("https://web.archive.org/web/2019id_/http://example.com/"); !ok || capturedAt.Year() != 2019 {
+		t.Errorf("Expected truncated timestamp with modifier to parse, got %v, %v", capturedAt, ok)
+	}
+}
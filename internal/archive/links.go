@@ -0,0 +1,75 @@
+package archive
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// archiveHosts are web archives whose snapshot links citing pages carry
+// next to the original source (Wikipedia's "Archived from the original")
+var archiveHosts = []string{
+	"web.archive.org",
+	"wayback.archive-it.org",
+	"archive.today",
+	"archive.ph",
+	"archive.is",
+	"archive.li",
+	"archive.md",
+	"archive.vn",
+	"archive.fo",
+	"webcitation.org",
+	"ghostarchive.org",
+	"perma.cc",
+	"webarchive.org.uk",
+	"webarchive.nationalarchives.gov.uk",
+}
+
+// IsArchiveURL reports whether rawURL is a snapshot on a known web archive
+func IsArchiveURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	for _, archive := range archiveHosts {
+		if host == archive || strings.HasSuffix(host, "."+archive) {
+			return true
+		}
+	}
+	return false
+}
+
+// SnapshotTime reads the capture time from a Wayback-style snapshot URL
+// (<prefix>/<timestamp>/<original>), where the timestamp may be truncated
+// or carry a replay modifier (20190512id_)
+func SnapshotTime(snapshotURL string) (time.Time, bool) {
+	parsed, err := url.Parse(snapshotURL)
+	if err != nil {
+		return time.Time{}, false
+	}
+	for _, segment := range strings.Split(parsed.Path, "/") {
+		digits := strings.TrimRightFunc(segment, func(r rune) bool { return r < '0' || r > '9' })
+		if len(digits) < 4 || len(digits) > len(timestampLayout) || strings.Trim(digits, "0123456789") != "" {
+			continue
+		}
+		if t, err := time.Parse(timestampLayout[:len(digits)], digits); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Original returns the source URL a Wayback-style snapshot URL captures,
+// or "" when it does not embed one
+func Original(snapshotURL string) string {
+	if snapshotURL == "" {
+		return ""
+	}
+	for _, scheme := range []string{"https://", "http://"} {
+		if i := strings.Index(snapshotURL[1:], scheme); i >= 0 {
+			return snapshotURL[i+1:]
+		}
+	}
+	return ""
+}
@@ -2,13 +2,17 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"syscall"
 	"time"
 
-	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/diff"
 	"github.com/ppiankov/entropia/internal/pipeline"
 	"github.com/ppiankov/entropia/internal/worker"
 	"github.com/spf13/cobra"
@@ -18,24 +22,49 @@ var (
 	concurrency  int
 	outputDir    string
 	batchTimeout time.Duration
+	batchHTML    bool
+	batchResume  bool
+
+	// Sitemap discovery instead of a URL file
+	batchSitemap  string
+	batchInclude  []string
+	batchExclude  []string
+	batchMaxPages int
+
+	// Docs sources matched by a glob instead of a URL file
+	batchGlob string
 	// noFooter is defined in scan.go and shared here
 )
 
+// batchJournalFile is the checkpoint journal in the output directory
+const batchJournalFile = "batch-journal.jsonl"
+
 // batchCmd represents the batch command
 var batchCmd = &cobra.Command{
-	Use:   "batch <file>",
+	Use:   "batch [file|dir|warc]",
 	Short: "Scan multiple URLs from a file in parallel",
 	Long: `Batch processes multiple URLs concurrently:
-- Read URLs from input file (one per line)
+- Read URLs from input file (one per line) or from a sitemap (--sitemap)
+- Or scan offline: every HTML page in a WARC archive or a directory of
+  saved pages (--source-url maps the directory to its site URL)
+- Or scan Markdown/reStructuredText docs matched by --glob (relative links
+  are checked as files in the repository)
 - Process URLs in parallel with configurable worker count
 - Each scan uses concurrent evidence validation
 - Generate individual reports for each URL
+- Write an aggregate batch-summary.json and batch-summary.md
+- Journal finished URLs so an interrupted batch can be resumed
 
 Example:
   entropia batch urls.txt
   entropia batch urls.txt --concurrency 10 --output-dir ./reports
-  entropia batch urls.txt --concurrency 5 --timeout 5m`,
-	Args: cobra.ExactArgs(1),
+  entropia batch urls.txt --concurrency 5 --timeout 5m
+  entropia batch urls.txt --resume
+  entropia batch --sitemap https://docs.example.com/sitemap.xml --include '/guides/*'
+  entropia batch crawl.warc.gz
+  entropia batch ./site-mirror --source-url https://docs.example.com/
+  entropia batch --glob 'docs/**/*.md' --fail-on-critical`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runBatch,
 }
 
@@ -46,14 +75,40 @@ func init() {
 	batchCmd.Flags().IntVar(&concurrency, "concurrency", runtime.NumCPU(), "number of concurrent workers")
 	batchCmd.Flags().StringVar(&outputDir, "output-dir", "./entropia-reports", "output directory for reports")
 	batchCmd.Flags().DurationVar(&batchTimeout, "timeout", 10*time.Minute, "total timeout for batch processing")
+	batchCmd.Flags().StringVar(&batchSitemap, "sitemap", "", "read URLs from a sitemap or sitemap index (URL or file, may be gzipped) instead of a file")
+	batchCmd.Flags().StringSliceVar(&batchInclude, "include", nil, "with --sitemap: only URLs whose path matches one of these patterns (repeatable)")
+	batchCmd.Flags().StringSliceVar(&batchExclude, "exclude", nil, "with --sitemap: skip URLs whose path matches one of these patterns (repeatable)")
+	batchCmd.Flags().IntVar(&batchMaxPages, "max-pages", 0, "with --sitemap: scan at most this many URLs (0 = no limit)")
+	batchCmd.Flags().StringVar(&batchGlob, "glob", "", "scan local files matching a glob instead of a file (e.g. 'docs/**/*.md'; ** matches any directories)")
+	batchCmd.Flags().StringVar(&sourceURL, "source-url", "", "with a directory: site URL the directory was saved from (default: each page's file:// URL or canonical link)")
+	batchCmd.Flags().BoolVar(&batchResume, "resume", false, "skip URLs already finished in the output directory's journal (after a timeout or Ctrl-C)")
+	batchCmd.Flags().BoolVar(&batchHTML, "html", false, "also write self-contained HTML reports and an index.html linking them")
 
 	// Inherit flags from scan command
 	batchCmd.Flags().DurationVar(&timeout, "scan-timeout", 30*time.Second, "timeout for individual scans")
 	batchCmd.Flags().StringVar(&userAgent, "ua", "Entropia/0.1 (+https://github.com/ppiankov/entropia)", "HTTP User-Agent")
 	batchCmd.Flags().BoolVar(&noCache, "no-cache", false, "disable cache (force fresh fetch)")
+	batchCmd.Flags().BoolVar(&noHistory, "no-history", false, "do not record these scans in the history store")
+	batchCmd.Flags().BoolVar(&contentDates, "content-dates", false, "GET evidence pages and read publication dates from their HTML (slower, more accurate freshness)")
+	batchCmd.Flags().BoolVar(&soft404, "soft-404", false, "GET evidence pages and flag \"not found\" and domain-parking pages served with 200")
+	batchCmd.Flags().BoolVar(&noArchive, "no-archive", false, "do not look up archived snapshots of dead evidence")
+	batchCmd.Flags().StringVar(&archiveURL, "archive-url", "", "Wayback CDX-compatible endpoint for dead evidence lookups (default: web.archive.org)")
+	identifierFlags(batchCmd)
+	wikipediaFlags(batchCmd)
 	batchCmd.Flags().BoolVar(&noFooter, "no-footer", false, "disable footer in Markdown reports")
 	batchCmd.Flags().StringVar(&httpProxy, "http-proxy", "", "HTTP proxy URL (overrides HTTP_PROXY env var)")
 	batchCmd.Flags().StringVar(&httpsProxy, "https-proxy", "", "HTTPS proxy URL (overrides HTTPS_PROXY env var)")
+	batchCmd.Flags().StringVar(&rulesFile, "rules", "", "path to custom scoring rules JSON (see 'entropia rules')")
+	batchCmd.Flags().StringVar(&entitiesFile, "entities", "", "path to a historical entity catalog, YAML or JSON (see 'entropia entities')")
+	batchCmd.Flags().StringVar(&adapterName, "adapter", "", "force a domain adapter for every URL (docs, wikipedia, legal, generic); default auto-detects per page")
+	batchCmd.Flags().StringVar(&language, "language", "", "force the claim keyword language for every URL (en, ru, uk, de, ja); default detects per page")
+	batchCmd.Flags().StringSliceVar(&keywordPacks, "keyword-packs", nil, "keyword pack files, YAML or JSON, adding or replacing languages (repeatable)")
+
+	// CI flags
+	batchCmd.Flags().StringVar(&ciFormat, "format", "", "also write one CI report for all URLs (sarif, junit)")
+	batchCmd.Flags().StringVar(&ciOut, "out", "", "CI report path (default <output-dir>/entropia.sarif or junit.xml)")
+	batchCmd.Flags().IntVar(&failUnder, "fail-under", 0, "exit non-zero when any support index is below this (0 = off)")
+	batchCmd.Flags().BoolVar(&failOnCritical, "fail-on-critical", false, "exit non-zero when any report has a critical signal")
 
 	// LLM flags
 	batchCmd.Flags().BoolVar(&llmEnabled, "llm", false, "enable LLM summary generation")
@@ -62,7 +117,54 @@ func init() {
 }
 
 func runBatch(cmd *cobra.Command, args []string) error {
-	file := args[0]
+	file := ""
+	if len(args) == 1 {
+		file = args[0]
+	}
+	inputs := 0
+	for _, input := range []string{file, batchSitemap, batchGlob} {
+		if input != "" {
+			inputs++
+		}
+	}
+	if inputs != 1 {
+		return fmt.Errorf("provide one of a URL file, --sitemap or --glob")
+	}
+	if batchSitemap == "" && (len(batchInclude) > 0 || len(batchExclude) > 0 || batchMaxPages > 0) {
+		return fmt.Errorf("--include, --exclude and --max-pages require --sitemap")
+	}
+	dir := false
+	if file != "" {
+		info, err := os.Stat(file)
+		dir = err == nil && info.IsDir()
+	}
+	if sourceURL != "" && !dir {
+		return fmt.Errorf("--source-url requires a directory of saved pages")
+	}
+
+	// Build configuration: config file, then flags given on the command line
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	applyScanFlags(cmd, cfg)
+	flagOverride(cmd, "scan-timeout", "http.timeout", &cfg.HTTP.Timeout, timeout)
+	flagOverride(cmd, "output-dir", "output.dir", &cfg.Output.Dir, outputDir)
+	flagOverride(cmd, "include", "crawl.include", &cfg.Crawl.Include, batchInclude)
+	flagOverride(cmd, "exclude", "crawl.exclude", &cfg.Crawl.Exclude, batchExclude)
+	flagOverride(cmd, "max-pages", "crawl.max_pages", &cfg.Crawl.MaxPages, batchMaxPages)
+	if err := applyLLMFlags(cmd, cfg); err != nil {
+		return err
+	}
+
+	if err := validateScanConfig(cfg); err != nil {
+		return err
+	}
+	if err := validateCIFlags(); err != nil {
+		return err
+	}
+
+	outputDir := cfg.Output.Dir
 	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
 	defer cancel()
 
@@ -71,120 +173,313 @@ func runBatch(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(os.Stderr, "  Entropia Batch Processing\n")
 	fmt.Fprintf(os.Stderr, "═══════════════════════════════════════════════════════════\n")
 	fmt.Fprintf(os.Stderr, "\n")
-	fmt.Fprintf(os.Stderr, "  Input file:   %s\n", file)
-	fmt.Fprintf(os.Stderr, "  Workers:      %d\n", concurrency)
+	switch {
+	case file != "":
+		fmt.Fprintf(os.Stderr, "  Input file:   %s\n", file)
+	case batchSitemap != "":
+		fmt.Fprintf(os.Stderr, "  Sitemap:      %s\n", batchSitemap)
+	default:
+		fmt.Fprintf(os.Stderr, "  Glob:         %s\n", batchGlob)
+	}
+	fmt.Fprintf(os.Stderr, "  Workers:      %d\n", cfg.Concurrency.Workers)
 	fmt.Fprintf(os.Stderr, "  Output dir:   %s\n", outputDir)
 	fmt.Fprintf(os.Stderr, "  Timeout:      %v\n", batchTimeout)
-	fmt.Fprintf(os.Stderr, "\n")
-
-	// Build configuration
-	cfg := model.DefaultConfig()
-	cfg.HTTP.Timeout = timeout
-	cfg.HTTP.UserAgent = userAgent
-	cfg.HTTP.HTTPProxy = httpProxy
-	cfg.HTTP.HTTPSProxy = httpsProxy
-	cfg.Cache.Enabled = !noCache
-	cfg.Concurrency.Workers = concurrency
-	cfg.Output.Verbose = verbose
-	cfg.Output.IncludeFooter = !noFooter
-
-	// Configure LLM if enabled
-	if llmEnabled {
-		cfg.LLM.Provider = llmProvider
-		cfg.LLM.Model = llmModel
-		cfg.LLM.StrictEvidence = true // Always enforce
-
-		// Get API key from environment
-		switch llmProvider {
-		case "openai":
-			cfg.LLM.APIKey = os.Getenv("OPENAI_API_KEY")
-			if cfg.LLM.APIKey == "" {
-				return fmt.Errorf("OPENAI_API_KEY environment variable not set")
-			}
-		case "anthropic", "claude":
-			cfg.LLM.APIKey = os.Getenv("ANTHROPIC_API_KEY")
-			if cfg.LLM.APIKey == "" {
-				return fmt.Errorf("ANTHROPIC_API_KEY environment variable not set")
-			}
-		case "ollama":
-			// Ollama doesn't need an API key
-			baseURL := os.Getenv("OLLAMA_BASE_URL")
-			if baseURL != "" {
-				cfg.LLM.BaseURL = baseURL
-			}
-		}
-
-		fmt.Fprintf(os.Stderr, "  LLM:          %s/%s\n", llmProvider, llmModel)
+	if cfg.Extraction.Adapter != "" {
+		fmt.Fprintf(os.Stderr, "  Adapter:      %s\n", cfg.Extraction.Adapter)
+	}
+	if cfg.Scoring.RulesFile != "" {
+		fmt.Fprintf(os.Stderr, "  Rules:        %s\n", cfg.Scoring.RulesFile)
+	}
+	if cfg.Entities.CatalogFile != "" {
+		fmt.Fprintf(os.Stderr, "  Entities:     %s\n", cfg.Entities.CatalogFile)
 	}
+	if cfg.Extraction.Language != "" {
+		fmt.Fprintf(os.Stderr, "  Language:     %s\n", cfg.Extraction.Language)
+	}
+	if len(cfg.Extraction.KeywordPacks) > 0 {
+		fmt.Fprintf(os.Stderr, "  Packs:        %s\n", strings.Join(cfg.Extraction.KeywordPacks, ", "))
+	}
+	if cfg.LLM.Provider != "" {
+		fmt.Fprintf(os.Stderr, "  LLM:          %s/%s\n", cfg.LLM.Provider, cfg.LLM.Model)
+	}
+	fmt.Fprintf(os.Stderr, "\n")
 
 	// Create output directory
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("create output directory: %w", err)
 	}
 
+	// Offline input: pages come from a WARC archive or a saved directory
+	var store *pipeline.DocumentStore
+	switch {
+	case dir:
+		store, err = pipeline.NewDirStore(file, sourceURL, cfg.HTTP.MaxBodyBytes)
+	case pipeline.IsWARC(file):
+		store, err = pipeline.NewWARCStore(file, cfg.HTTP.MaxBodyBytes)
+	}
+	if err != nil {
+		return err
+	}
+
 	// Create pipeline
 	p := pipeline.NewPipeline(cfg)
 
 	// Create batch processor
-	processor := worker.NewBatchProcessor(p, concurrency, cfg.RateLimiting.RequestsPerSecond, cfg.RateLimiting.BurstSize)
+	var processor *worker.BatchProcessor
+	if store != nil || batchGlob != "" {
+		if store != nil {
+			p.SetDocumentStore(store)
+		}
+		processor = worker.NewBatchProcessorWithLimiter(p, cfg.Concurrency.Workers, nil) // No page fetches to pace
+	} else {
+		processor = worker.NewBatchProcessor(p, cfg.Concurrency.Workers, cfg.RateLimiting.RequestsPerSecond, cfg.RateLimiting.BurstSize)
+	}
+	if limiter := processor.Limiter(); limiter != nil && p.Robots() != nil {
+		limiter.SetRobotsChecker(p.Robots()) // Honor Crawl-delay per host
+	}
 
-	// Process URLs
-	fmt.Fprintf(os.Stderr, "⚙️  Reading URLs from file...\n")
-	results, err := processor.ProcessFile(ctx, file)
+	var urls []string
+	switch {
+	case store != nil:
+		fmt.Fprintf(os.Stderr, "⚙️  Reading pages from %s...\n", file)
+		urls = store.URLs()
+	case batchSitemap != "":
+		// Discovery shares the scans' limiter and robots.txt checker
+		crawler, err := newCrawler(cfg, processor.Limiter(), p.Robots())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "⚙️  Reading URLs from sitemap...\n")
+		if urls, err = crawler.Sitemap(ctx, batchSitemap); err != nil {
+			return err
+		}
+	case batchGlob != "":
+		fmt.Fprintf(os.Stderr, "⚙️  Matching files...\n")
+		paths, err := pipeline.GlobFiles(batchGlob)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			urls = append(urls, pipeline.FileURL(path))
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "⚙️  Reading URLs from file...\n")
+		if urls, err = worker.ReadURLsFromFile(file); err != nil {
+			return fmt.Errorf("process file: %w", err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "✓ Loaded %d URLs\n", len(urls))
+
+	// Checkpoint journal: every finished URL is recorded as soon as its
+	// reports are on disk, so --resume can skip it after a timeout or Ctrl-C
+	journalPath := filepath.Join(outputDir, batchJournalFile)
+	journal, err := worker.OpenJournal(journalPath, batchResume)
 	if err != nil {
-		return fmt.Errorf("process file: %w", err)
+		return err
 	}
+	defer func() { _ = journal.Close() }()
+
+	renderer := pipeline.NewRenderer(cfg.Output.IncludeFooter)
+	gate := ciGate()
+	outcomes := make(map[string]pipeline.CIResult, len(urls))
+	htmlEntries := make(map[string]pipeline.HTMLIndexEntry, len(urls))
+
+	// Report file names in use: pages with the same subject (every docs
+	// folder has an index.md) get numbered names instead of overwriting
+	slugs := make(map[string]bool)
+
+	// Reload reports finished by a previous run
+	var pending []string
+	for _, url := range urls {
+		entry, ok := journal.Completed(url)
+		if !ok {
+			pending = append(pending, url)
+			continue
+		}
+		slugs[strings.TrimSuffix(entry.Report, ".json")] = true
+		report, err := diff.LoadReport(filepath.Join(outputDir, entry.Report))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %s: %v (rescanning)\n", url, err)
+			pending = append(pending, url)
+			continue
+		}
+		outcomes[url] = pipeline.CIResult{URL: url, Report: report, Failures: gate.Check(report)}
+		if batchHTML {
+			htmlEntries[url] = pipeline.NewHTMLIndexEntry(report, strings.TrimSuffix(entry.Report, ".json")+".html")
+		}
+	}
+	resumedCount := len(urls) - len(pending)
+	if batchResume {
+		fmt.Fprintf(os.Stderr, "✓ Resuming: %d URLs already done, %d to scan\n", resumedCount, len(pending))
+	}
+
+	// Ctrl-C and SIGTERM stop the batch; finished results are already on
+	// disk and in-flight scans are discarded
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupts)
+	go func() {
+		select {
+		case <-interrupts:
+			signal.Stop(interrupts) // A second Ctrl-C exits immediately
+			fmt.Fprintf(os.Stderr, "\n⚠️  Interrupted: stopping workers and writing partial results (Ctrl-C again to abort)\n")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
 
-	fmt.Fprintf(os.Stderr, "✓ Loaded %d URLs\n", len(results))
 	fmt.Fprintf(os.Stderr, "\n")
-	fmt.Fprintf(os.Stderr, "⚙️  Processing URLs with %d workers...\n", concurrency)
+	fmt.Fprintf(os.Stderr, "⚙️  Processing URLs with %d workers...\n", cfg.Concurrency.Workers)
 	fmt.Fprintf(os.Stderr, "\n")
 
-	// Process results
+	// Render and journal each result as it finishes
 	successCount := 0
 	failureCount := 0
+	processor.ProcessURLsFunc(ctx, pending, func(result *worker.ScanResult) {
+		if ctx.Err() != nil && (errors.Is(result.Error, context.Canceled) || errors.Is(result.Error, context.DeadlineExceeded)) {
+			return // Cut short by the interrupt or timeout, not scanned; rescanned on --resume
+		}
 
-	for _, result := range results {
 		if result.Error != nil {
 			failureCount++
+			outcomes[result.URL] = pipeline.CIResult{URL: result.URL, Error: result.Error, Failures: gate.CheckError(result.Error)}
+			htmlEntries[result.URL] = pipeline.HTMLIndexEntry{URL: result.URL, Error: result.Error.Error()}
+			recordJournal(journal, worker.JournalEntry{URL: result.URL, Error: result.Error.Error()})
 			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", result.URL, result.Error)
-			continue
+			return
 		}
 
 		successCount++
+		outcomes[result.URL] = pipeline.CIResult{
+			URL:      result.URL,
+			Report:   result.Report,
+			Failures: gate.Check(result.Report),
+		}
 
 		// Generate output file names
-		slug := sanitizeFilename(result.Report.Subject)
+		slug := uniqueSlug(slugs, sanitizeFilename(result.Report.Subject))
 		jsonPath := filepath.Join(outputDir, slug+".json")
 		mdPath := filepath.Join(outputDir, slug+".md")
 
 		// Render report
-		renderer := pipeline.NewRenderer(cfg.Output.IncludeFooter)
 		if err := renderer.RenderJSON(result.Report, jsonPath); err != nil {
 			fmt.Fprintf(os.Stderr, "✗ %s: failed to write JSON: %v\n", result.URL, err)
-			continue
+			recordJournal(journal, worker.JournalEntry{URL: result.URL, Error: err.Error()})
+			return
 		}
 		if err := renderer.RenderMarkdown(result.Report, mdPath); err != nil {
 			fmt.Fprintf(os.Stderr, "✗ %s: failed to write Markdown: %v\n", result.URL, err)
-			continue
+			recordJournal(journal, worker.JournalEntry{URL: result.URL, Error: err.Error()})
+			return
 		}
+		if batchHTML {
+			htmlPath := filepath.Join(outputDir, slug+".html")
+			if err := renderer.RenderHTML(result.Report, htmlPath); err != nil {
+				fmt.Fprintf(os.Stderr, "✗ %s: failed to write HTML: %v\n", result.URL, err)
+				recordJournal(journal, worker.JournalEntry{URL: result.URL, Error: err.Error()})
+				return
+			}
+			htmlEntries[result.URL] = pipeline.NewHTMLIndexEntry(result.Report, slug+".html")
+		}
+
+		recordJournal(journal, worker.JournalEntry{URL: result.URL, Report: slug + ".json"})
+		fmt.Fprintf(os.Stderr, "✓ %s (index: %d/100, adapter: %s)\n", result.Report.Subject, result.Report.Score.Index, result.Report.Adapter)
+	})
 
-		fmt.Fprintf(os.Stderr, "✓ %s (index: %d/100)\n", result.Report.Subject, result.Report.Score.Index)
+	// Collect finished URLs in input order
+	var ciResults []pipeline.CIResult
+	var indexEntries []pipeline.HTMLIndexEntry
+	for _, url := range urls {
+		if outcome, ok := outcomes[url]; ok {
+			ciResults = append(ciResults, outcome)
+		}
+		if entry, ok := htmlEntries[url]; ok {
+			indexEntries = append(indexEntries, entry)
+		}
 	}
+	remaining := len(urls) - len(outcomes)
 
 	// Summary
 	fmt.Fprintf(os.Stderr, "\n")
 	fmt.Fprintf(os.Stderr, "═══════════════════════════════════════════════════════════\n")
-	fmt.Fprintf(os.Stderr, "  Batch Complete\n")
+	if remaining > 0 {
+		fmt.Fprintf(os.Stderr, "  Batch Stopped\n")
+	} else {
+		fmt.Fprintf(os.Stderr, "  Batch Complete\n")
+	}
 	fmt.Fprintf(os.Stderr, "═══════════════════════════════════════════════════════════\n")
 	fmt.Fprintf(os.Stderr, "\n")
-	fmt.Fprintf(os.Stderr, "  Total:     %d URLs\n", len(results))
+	fmt.Fprintf(os.Stderr, "  Total:     %d URLs\n", len(urls))
+	if resumedCount > 0 {
+		fmt.Fprintf(os.Stderr, "  Resumed:   %d\n", resumedCount)
+	}
 	fmt.Fprintf(os.Stderr, "  Success:   %d\n", successCount)
 	fmt.Fprintf(os.Stderr, "  Failures:  %d\n", failureCount)
+	if remaining > 0 {
+		fmt.Fprintf(os.Stderr, "  Remaining: %d\n", remaining)
+	}
 	fmt.Fprintf(os.Stderr, "  Output:    %s\n", outputDir)
 	fmt.Fprintf(os.Stderr, "\n")
 
-	return nil
+	// Aggregate summary across all finished URLs
+	summary := pipeline.SummarizeBatch(ciResults)
+	summary.Remaining = remaining
+	summaryJSON := filepath.Join(outputDir, "batch-summary.json")
+	summaryMD := filepath.Join(outputDir, "batch-summary.md")
+	if err := renderer.RenderBatchSummaryJSON(summary, summaryJSON); err != nil {
+		return fmt.Errorf("write batch summary: %w", err)
+	}
+	if err := renderer.RenderBatchSummaryMarkdown(summary, summaryMD); err != nil {
+		return fmt.Errorf("write batch summary: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Wrote batch summary: %s, %s\n", summaryJSON, summaryMD)
+
+	if batchHTML {
+		indexPath := filepath.Join(outputDir, "index.html")
+		if err := renderer.RenderHTMLIndex(indexEntries, indexPath); err != nil {
+			return fmt.Errorf("write HTML index: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "✓ Wrote HTML index: %s\n", indexPath)
+	}
+
+	// CI report and quality gate
+	ciPath := func(format string) string {
+		if format == "junit" {
+			return filepath.Join(outputDir, "junit.xml")
+		}
+		return filepath.Join(outputDir, "entropia.sarif")
+	}
+	if err := writeCIReport(renderer, ciResults, ciPath); err != nil {
+		return fmt.Errorf("write CI report: %w", err)
+	}
+
+	if remaining > 0 {
+		reason := "interrupted"
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			reason = "timed out"
+		}
+		return fmt.Errorf("batch %s with %d of %d URLs remaining; rerun with --resume to continue", reason, remaining, len(urls))
+	}
+
+	return gateError(ciResults)
+}
+
+// recordJournal checkpoints a finished URL, warning (not failing) on error
+func recordJournal(journal *worker.Journal, entry worker.JournalEntry) {
+	if err := journal.Record(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %s: %v\n", entry.URL, err)
+	}
+}
+
+// uniqueSlug returns slug, or slug-2, slug-3... when it is already used,
+// and marks the result used
+func uniqueSlug(used map[string]bool, slug string) string {
+	unique := slug
+	for n := 2; used[unique]; n++ {
+		unique = fmt.Sprintf("%s-%d", slug, n)
+	}
+	used[unique] = true
+	return unique
 }
 
 // sanitizeFilename sanitizes a string for use as a filename
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ppiankov/entropia/internal/pipeline"
+)
+
+// CI flags shared by scan and batch
+var (
+	ciFormat       string
+	ciOut          string
+	failUnder      int
+	failOnCritical bool
+)
+
+// validateCIFlags checks --format and --fail-under
+func validateCIFlags() error {
+	switch ciFormat {
+	case "", "sarif", "junit":
+	default:
+		return fmt.Errorf("unsupported format %q (use sarif or junit)", ciFormat)
+	}
+	if failUnder < 0 || failUnder > 100 {
+		return fmt.Errorf("--fail-under must be between 0 and 100, got %d", failUnder)
+	}
+	return nil
+}
+
+// ciGate builds the quality gate from flags
+func ciGate() pipeline.Gate {
+	return pipeline.Gate{FailUnder: failUnder, FailOnCritical: failOnCritical}
+}
+
+// writeCIReport renders results in the --format requested, to --out or
+// defaultPath(format) when --out is empty
+func writeCIReport(renderer *pipeline.Renderer, results []pipeline.CIResult, defaultPath func(format string) string) error {
+	if ciFormat == "" {
+		return nil
+	}
+
+	path := ciOut
+	if path == "" {
+		path = defaultPath(ciFormat)
+	}
+
+	var err error
+	switch ciFormat {
+	case "sarif":
+		err = renderer.RenderSARIF(results, Version, path)
+	case "junit":
+		err = renderer.RenderJUnit(results, path)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Wrote %s report: %s\n", ciFormat, path)
+	return nil
+}
+
+// gateError reports gate failures on stderr and returns an error (non-zero
+// exit) when any result failed the gate
+func gateError(results []pipeline.CIResult) error {
+	failed := 0
+	for _, result := range results {
+		if len(result.Failures) == 0 {
+			continue
+		}
+		failed++
+		for _, failure := range result.Failures {
+			fmt.Fprintf(os.Stderr, "✗ %s: %s\n", result.URL, failure)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("quality gate failed for %d of %d URL(s)", failed, len(results))
+	}
+	return nil
+}
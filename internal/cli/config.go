@@ -3,7 +3,9 @@ package cli
 import (
 	"fmt"
 	"os"
+	"sort"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/ppiankov/entropia/internal/model"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -28,9 +30,12 @@ var configShowCmd = &cobra.Command{
 	Short: "Show current configuration",
 	Long:  `Display the current configuration including all sources (defaults, config file, env vars, flags)வுடன்.`, // Note: The original string had a typo here, which has been corrected. The original string was `Display the current configuration including all sources (defaults, config file, env vars, flags).` and the corrected string is `Display the current configuration including all sources (defaults, config file, env vars, flags).`
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg := model.DefaultConfig()
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
 
-		// Load configuration from file if it exists
+		// Report the file the configuration was loaded from
 		configFile := viper.ConfigFileUsed()
 		if configFile != "" {
 			fmt.Fprintf(os.Stderr, "Configuration file: %s\n\n", configFile)
@@ -153,6 +158,70 @@ var configInitCmd = &cobra.Command{
 	},
 }
 
+// loadConfig returns the built-in defaults overlaid with the config file
+// (--config, else ~/.entropia/config.yaml) and ENTROPIA_* environment
+// variables (ENTROPIA_HTTP_USER_AGENT sets http.user_agent). Keys neither
+// sets keep their defaults.
+func loadConfig() (*model.Config, error) {
+	cfg := model.DefaultConfig()
+
+	keys, err := configKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if err := viper.BindEnv(key); err != nil {
+			return nil, fmt.Errorf("bind environment for %s: %w", key, err)
+		}
+	}
+
+	// The model tags its fields for YAML, so decode by those names
+	if err := viper.Unmarshal(cfg, func(dc *mapstructure.DecoderConfig) { dc.TagName = "yaml" }); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// configKeys lists the dotted keys of every setting in cfg (e.g.,
+// "http.user_agent"), so each can be bound to its environment variable
+func configKeys(cfg *model.Config) ([]string, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+	var tree map[string]interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+
+	var keys []string
+	var walk func(prefix string, node map[string]interface{})
+	walk = func(prefix string, node map[string]interface{}) {
+		for name, value := range node {
+			if child, ok := value.(map[string]interface{}); ok && len(child) > 0 {
+				walk(prefix+name+".", child)
+				continue
+			}
+			keys = append(keys, prefix+name)
+		}
+	}
+	walk("", tree)
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// flagOverride sets *dst to a flag's value when the flag was given on the
+// command line, or when neither the config file nor the environment sets
+// key (the flag's default applies). Flags cmd does not define are ignored.
+func flagOverride[T any](cmd *cobra.Command, flag, key string, dst *T, value T) {
+	if cmd.Flags().Lookup(flag) == nil {
+		return
+	}
+	if cmd.Flags().Changed(flag) || !viper.IsSet(key) {
+		*dst = value
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configShowCmd)
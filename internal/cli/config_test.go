@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// useConfig points the CLI at a config file with the given content and
+// restores the global viper state afterwards
+func useConfig(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+
+	previous := cfgFile
+	cfgFile = path
+	t.Cleanup(func() {
+		cfgFile = previous
+		viper.Reset()
+	})
+	viper.Reset()
+	initConfig()
+}
+
+func TestLoadConfig_EnvOverridesFile(t *testing.T) {
+	t.Setenv("ENTROPIA_HTTP_USER_AGENT", "EnvAgent/1.0")
+	t.Setenv("ENTROPIA_CACHE_ENABLED", "false")
+	t.Setenv("ENTROPIA_CRAWL_INCLUDE", "/docs/*,/guide/*")
+	useConfig(t, "http:\n  user_agent: FileAgent/1.0\n  timeout: 45s\n")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("loadConfig failed: %v", err)
+	}
+
+	if cfg.HTTP.UserAgent != "EnvAgent/1.0" {
+		t.Errorf("Expected user agent from the environment, got %q", cfg.HTTP.UserAgent)
+	}
+	if cfg.HTTP.Timeout != 45*time.Second {
+		t.Errorf("Expected timeout 45s from the file, got %v", cfg.HTTP.Timeout)
+	}
+	if cfg.Cache.Enabled {
+		t.Error("Expected cache disabled by ENTROPIA_CACHE_ENABLED")
+	}
+	if len(cfg.Crawl.Include) != 2 || cfg.Crawl.Include[1] != "/guide/*" {
+		t.Errorf("Expected include patterns from the environment, got %v", cfg.Crawl.Include)
+	}
+	if cfg.History.Dir == "" {
+		t.Error("Expected keys set nowhere to keep their defaults")
+	}
+}
+
+func TestFlagOverride_EnvAndExplicitFlags(t *testing.T) {
+	t.Setenv("ENTROPIA_HTTP_USER_AGENT", "EnvAgent/1.0")
+	useConfig(t, "")
+
+	var ua string
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringVar(&ua, "ua", "FlagDefault/1.0", "")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("loadConfig failed: %v", err)
+	}
+	flagOverride(cmd, "ua", "http.user_agent", &cfg.HTTP.UserAgent, ua)
+	if cfg.HTTP.UserAgent != "EnvAgent/1.0" {
+		t.Errorf("Expected an unset flag to keep the environment value, got %q", cfg.HTTP.UserAgent)
+	}
+
+	if err := cmd.Flags().Set("ua", "Flag/2.0"); err != nil {
+		t.Fatal(err)
+	}
+	flagOverride(cmd, "ua", "http.user_agent", &cfg.HTTP.UserAgent, ua)
+	if cfg.HTTP.UserAgent != "Flag/2.0" {
+		t.Errorf("Expected an explicit flag to win, got %q", cfg.HTTP.UserAgent)
+	}
+}
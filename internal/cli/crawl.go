@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/crawl"
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/pipeline"
+	"github.com/ppiankov/entropia/internal/util"
+	"github.com/ppiankov/entropia/internal/worker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	crawlDepth     int
+	crawlMaxPages  int
+	crawlInclude   []string
+	crawlExclude   []string
+	crawlNoSitemap bool
+	crawlOut       string
+	crawlTimeout   time.Duration
+)
+
+// crawlCmd represents the crawl command
+var crawlCmd = &cobra.Command{
+	Use:   "crawl <root-url>",
+	Short: "Discover pages on a site from its sitemaps and links",
+	Long: `Crawl lists the pages of a site for 'entropia batch':
+- Reads sitemaps advertised in robots.txt (or /sitemap.xml), including
+  sitemap indexes and gzipped sitemaps
+- Follows same-host links breadth-first up to --depth hops
+- Filters paths with --include / --exclude patterns (* matches any run
+  of characters, including /)
+- Honors robots.txt, <meta name="robots"> and the per-domain rate limit
+
+Prints one URL per line (or writes them to --out).
+
+Example:
+  entropia crawl https://docs.example.com/
+  entropia crawl https://docs.example.com/ --include '/guides/*' --exclude '*/changelog' --out urls.txt
+  entropia batch urls.txt`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCrawl,
+}
+
+func init() {
+	rootCmd.AddCommand(crawlCmd)
+
+	defaults := model.DefaultConfig().Crawl
+	crawlCmd.Flags().IntVar(&crawlDepth, "depth", defaults.MaxDepth, "link hops to follow from the root URL (0 = root and sitemaps only)")
+	crawlCmd.Flags().IntVar(&crawlMaxPages, "max-pages", defaults.MaxPages, "stop after this many pages (0 = no limit)")
+	crawlCmd.Flags().StringSliceVar(&crawlInclude, "include", nil, "only pages whose path matches one of these patterns (repeatable)")
+	crawlCmd.Flags().StringSliceVar(&crawlExclude, "exclude", nil, "skip pages whose path matches one of these patterns (repeatable)")
+	crawlCmd.Flags().BoolVar(&crawlNoSitemap, "no-sitemap", false, "do not read the site's sitemaps; follow links only")
+	crawlCmd.Flags().StringVarP(&crawlOut, "out", "o", "", "write URLs to this file instead of stdout")
+	crawlCmd.Flags().DurationVar(&crawlTimeout, "timeout", 10*time.Minute, "total timeout for discovery")
+	crawlCmd.Flags().StringVar(&userAgent, "ua", "Entropia/0.1 (+https://github.com/ppiankov/entropia)", "HTTP User-Agent")
+	crawlCmd.Flags().StringVar(&httpProxy, "http-proxy", "", "HTTP proxy URL (overrides HTTP_PROXY env var)")
+	crawlCmd.Flags().StringVar(&httpsProxy, "https-proxy", "", "HTTPS proxy URL (overrides HTTPS_PROXY env var)")
+}
+
+func runCrawl(cmd *cobra.Command, args []string) error {
+	root := args[0]
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	applyScanFlags(cmd, cfg)
+	flagOverride(cmd, "depth", "crawl.max_depth", &cfg.Crawl.MaxDepth, crawlDepth)
+	flagOverride(cmd, "max-pages", "crawl.max_pages", &cfg.Crawl.MaxPages, crawlMaxPages)
+	flagOverride(cmd, "include", "crawl.include", &cfg.Crawl.Include, crawlInclude)
+	flagOverride(cmd, "exclude", "crawl.exclude", &cfg.Crawl.Exclude, crawlExclude)
+	flagOverride(cmd, "no-sitemap", "crawl.sitemaps", &cfg.Crawl.Sitemaps, !crawlNoSitemap)
+
+	// Same politeness as batch: robots.txt and a per-domain limiter
+	var robots *util.RobotsChecker
+	if cfg.RateLimiting.RespectRobotsTxt {
+		robots = util.NewRobotsChecker(cfg.HTTP.UserAgent, 10*time.Second)
+		robots.SetProxy(util.NewProxyFunc(cfg.HTTP.HTTPProxy, cfg.HTTP.HTTPSProxy, cfg.HTTP.NoProxy))
+	}
+	var limiter *worker.Limiter
+	if cfg.RateLimiting.RequestsPerSecond > 0 {
+		limiter = worker.NewLimiter(cfg.RateLimiting.RequestsPerSecond, cfg.RateLimiting.BurstSize)
+		if robots != nil {
+			limiter.SetRobotsChecker(robots)
+		}
+	}
+
+	crawler, err := newCrawler(cfg, limiter, robots)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), crawlTimeout)
+	defer cancel()
+
+	fmt.Fprintf(os.Stderr, "⚙️  Crawling %s (depth %d)...\n", root, cfg.Crawl.MaxDepth)
+	urls, err := crawler.Crawl(ctx, root)
+	if err != nil && len(urls) == 0 {
+		return err
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Crawl stopped early: %v\n", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Discovered %d pages\n", len(urls))
+
+	output := strings.Join(urls, "\n")
+	if len(urls) > 0 {
+		output += "\n"
+	}
+	if crawlOut == "" {
+		_, err := fmt.Print(output)
+		return err
+	}
+	if err := os.WriteFile(crawlOut, []byte(output), 0644); err != nil {
+		return fmt.Errorf("write URLs: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Wrote %s\n", crawlOut)
+	return nil
+}
+
+// newCrawler builds a discovery crawler sharing the caller's limiter and
+// robots.txt checker (either may be nil)
+func newCrawler(cfg *model.Config, limiter *worker.Limiter, robots *util.RobotsChecker) (*crawl.Crawler, error) {
+	fetcher := pipeline.NewFetcher(cfg.HTTP.Timeout, cfg.HTTP.UserAgent, crawl.MaxSitemapBytes, cfg.HTTP.InsecureTLS, cfg.HTTP.HTTPProxy, cfg.HTTP.HTTPSProxy, cfg.HTTP.NoProxy)
+	if robots != nil {
+		fetcher.SetRobotsChecker(robots)
+	}
+
+	var pacer crawl.Limiter
+	if limiter != nil {
+		pacer = limiter // Avoid a non-nil interface holding a nil pointer
+	}
+
+	return crawl.NewCrawler(fetcher, pacer, robots, cfg.Crawl)
+}
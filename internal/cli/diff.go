@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ppiankov/entropia/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat string
+	diffOut    string
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff <old.json> <new.json>",
+	Short: "Compare two reports of the same page over time",
+	Long: `Diff loads two JSON reports and prints how the source drifted:
+- Support index and confidence change
+- Claims added and removed
+- Evidence that went dead (or came back)
+- Authority tier shifts
+- Signals that appeared, cleared or changed severity
+
+Example:
+  entropia diff laksa-2026-01.json laksa-2026-02.json
+  entropia diff old.json new.json --format json --out drift.json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVar(&diffFormat, "format", "markdown", "output format (markdown, json)")
+	diffCmd.Flags().StringVar(&diffOut, "out", "", "write output to file instead of stdout")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	if diffFormat != "markdown" && diffFormat != "json" {
+		return fmt.Errorf("unsupported format %q (use markdown or json)", diffFormat)
+	}
+
+	oldReport, err := diff.LoadReport(args[0])
+	if err != nil {
+		return err
+	}
+	newReport, err := diff.LoadReport(args[1])
+	if err != nil {
+		return err
+	}
+
+	if verbose && oldReport.SourceURL != newReport.SourceURL {
+		fmt.Fprintf(os.Stderr, "Warning: comparing different sources (%s vs %s)\n", oldReport.SourceURL, newReport.SourceURL)
+	}
+
+	d := diff.Compare(oldReport, newReport)
+
+	var output []byte
+	if diffFormat == "json" {
+		output, err = json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode JSON: %w", err)
+		}
+		output = append(output, '\n')
+	} else {
+		output = []byte(diff.RenderMarkdown(d))
+	}
+
+	if diffOut == "" {
+		_, err = os.Stdout.Write(output)
+		return err
+	}
+
+	if err := os.WriteFile(diffOut, output, 0644); err != nil {
+		return fmt.Errorf("write diff: %w", err)
+	}
+	if verbose {
+		fmt.Fprintf(os.Stderr, "✓ Wrote diff: %s\n", diffOut)
+	}
+	return nil
+}
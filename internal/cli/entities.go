@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/entities"
+	"github.com/ppiankov/entropia/internal/extract"
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	entitiesCatalog string
+	entitiesFormat  string
+)
+
+// entitiesCmd represents the entities command
+var entitiesCmd = &cobra.Command{
+	Use:   "entities",
+	Short: "Inspect the historical entity catalog and check text against it",
+	Long: `The historical entity catalog lists states and polities that no longer
+exist (Kyivan Rus, USSR, Ottoman Empire, ...). Wikipedia scans flag articles
+that attribute origins to them as historical_entity signals, and any scan
+flags a claim that dates one of them to years it did not exist
+("originated in Kyivan Rus in the 16th century") as an anachronism.
+
+A catalog file is YAML or JSON (by extension). Each entity has a name,
+optional aliases, names by language, start and end years, successor states
+and a description. Entropia ships a bundled catalog; pass --entities to
+scan, batch or serve to use your own.
+
+Example:
+  entropia entities list --format yaml > entities.yaml
+  entropia entities check "The dish originates from Kyivan Rus in the 16th century"
+  entropia entities check --catalog entities.yaml "Borscht in the Soviet Union"
+  entropia scan https://en.wikipedia.org/wiki/Borscht --entities entities.yaml`,
+}
+
+var entitiesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the entities in the catalog (--catalog, else entities.catalog_file, else the bundled catalog)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if entitiesFormat != "text" && entitiesFormat != "json" && entitiesFormat != "yaml" {
+			return fmt.Errorf("unsupported format %q (use text, json or yaml)", entitiesFormat)
+		}
+		catalog, err := loadEntityCatalog(cmd)
+		if err != nil {
+			return err
+		}
+
+		switch entitiesFormat {
+		case "json":
+			return writeJSON(catalog)
+		case "yaml":
+			encoder := yaml.NewEncoder(os.Stdout)
+			encoder.SetIndent(2)
+			if err := encoder.Encode(catalog); err != nil {
+				return fmt.Errorf("encode YAML: %w", err)
+			}
+			return encoder.Close()
+		}
+
+		fmt.Printf("Catalog: %s (%d entities)\n\n", catalog.Name, len(catalog.Entities))
+		for _, entity := range catalog.Entities {
+			fmt.Printf("  %s (%s)\n", entity.Name, entity.Lifespan())
+			if entity.Description != "" {
+				fmt.Printf("    %s\n", entity.Description)
+			}
+			if len(entity.Aliases) > 0 {
+				fmt.Printf("    Aliases:    %s\n", strings.Join(entity.Aliases, ", "))
+			}
+			langs := make([]string, 0, len(entity.Names))
+			for lang := range entity.Names {
+				langs = append(langs, lang)
+			}
+			sort.Strings(langs)
+			for _, lang := range langs {
+				fmt.Printf("    Names (%s): %s\n", lang, strings.Join(entity.Names[lang], ", "))
+			}
+			if len(entity.Successors) > 0 {
+				fmt.Printf("    Successors: %s\n", strings.Join(entity.Successors, ", "))
+			}
+		}
+		return nil
+	},
+}
+
+var entitiesCheckCmd = &cobra.Command{
+	Use:   "check <text>",
+	Short: "Report the catalog entities named in a text and dates that contradict their lifespans",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		catalog, err := loadEntityCatalog(cmd)
+		if err != nil {
+			return err
+		}
+
+		text := strings.Join(args, " ")
+		matches := catalog.Find(text)
+		if len(matches) == 0 {
+			fmt.Printf("No historical entities found (catalog: %s)\n", catalog.Name)
+			return nil
+		}
+
+		fmt.Printf("%d historical entit%s found (catalog: %s)\n\n", len(matches), pluralY(len(matches)), catalog.Name)
+		for _, match := range matches {
+			entity := match.Entity
+			fmt.Printf("  %s (%s)\n", entity.Name, entity.Lifespan())
+			fmt.Printf("    Matched:    %s\n", strings.Join(match.Names, ", "))
+			if len(entity.Successors) > 0 {
+				fmt.Printf("    Successors: %s\n", strings.Join(entity.Successors, ", "))
+			}
+			if len(match.Context) > 0 {
+				fmt.Printf("    Context:    %s\n", match.Context[0])
+			}
+		}
+
+		// The text is checked as one claim against the entities' lifespans
+		claims := extract.AnnotateDates([]model.Claim{{Text: text}})
+		for _, a := range catalog.Anachronisms(claims) {
+			fmt.Printf("\n  ⚠ Anachronism: %q dates %s to %d-%d, but it existed %s\n",
+				a.Date.Text, a.Entity.Name, a.Date.From, a.Date.To, a.Entity.Lifespan())
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(entitiesCmd)
+	entitiesCmd.AddCommand(entitiesListCmd)
+	entitiesCmd.AddCommand(entitiesCheckCmd)
+
+	entitiesCmd.PersistentFlags().StringVar(&entitiesCatalog, "catalog", "", "catalog file, YAML or JSON (default: entities.catalog_file from the config file, else the bundled catalog)")
+	entitiesListCmd.Flags().StringVar(&entitiesFormat, "format", "text", "output format (text, json, yaml)")
+}
+
+// loadEntityCatalog returns the catalog named by --catalog or the config
+// file, or the bundled one
+func loadEntityCatalog(cmd *cobra.Command) (*entities.Catalog, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	flagOverride(cmd, "catalog", "entities.catalog_file", &cfg.Entities.CatalogFile, entitiesCatalog)
+	if cfg.Entities.CatalogFile == "" {
+		return entities.Default(), nil
+	}
+	return entities.Load(cfg.Entities.CatalogFile)
+}
+
+// validateEntitiesFile fails fast on a bad --entities value before any scanning starts
+func validateEntitiesFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	_, err := entities.Load(path)
+	return err
+}
+
+func pluralY(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ppiankov/entropia/internal/history"
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyDir    string
+	historyFormat string
+	historyLimit  int
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history [url]",
+	Short: "Show support index and decay trends for a scanned URL",
+	Long: `History shows every recorded scan of a URL over time:
+- Support index and confidence
+- Dead-link ratio among validated evidence
+- Signal counts by severity
+
+Every fresh scan is recorded (disable with --no-history on scan/batch).
+Without a URL, lists all URLs with recorded history.
+
+Example:
+  entropia history
+  entropia history https://en.wikipedia.org/wiki/Laksa
+  entropia history https://en.wikipedia.org/wiki/Laksa --format json --limit 10`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringVar(&historyDir, "dir", model.DefaultConfig().History.Dir, "history directory")
+	historyCmd.Flags().StringVar(&historyFormat, "format", "text", "output format (text, json)")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "show only the most recent N scans (0 = all)")
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	if historyFormat != "text" && historyFormat != "json" {
+		return fmt.Errorf("unsupported format %q (use text or json)", historyFormat)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	flagOverride(cmd, "dir", "history.dir", &cfg.History.Dir, historyDir)
+	store := history.NewStore(util.ExpandHome(cfg.History.Dir))
+
+	if len(args) == 0 {
+		sources, err := store.Sources()
+		if err != nil {
+			return err
+		}
+		if historyFormat == "json" {
+			return writeJSON(sources)
+		}
+		if len(sources) == 0 {
+			fmt.Printf("No scan history in %s\n", store.Dir())
+			return nil
+		}
+		for _, source := range sources {
+			fmt.Println(source)
+		}
+		return nil
+	}
+
+	url := args[0]
+	points, err := store.Trend(url)
+	if err != nil {
+		return err
+	}
+	if historyLimit > 0 && len(points) > historyLimit {
+		points = points[len(points)-historyLimit:]
+	}
+
+	if historyFormat == "json" {
+		return writeJSON(points)
+	}
+
+	if len(points) == 0 {
+		return fmt.Errorf("no history for %s (run 'entropia scan' first)", url)
+	}
+
+	fmt.Printf("\n")
+	fmt.Printf("═══════════════════════════════════════════════════════════\n")
+	fmt.Printf("  Scan History: %s\n", url)
+	fmt.Printf("═══════════════════════════════════════════════════════════\n")
+	fmt.Printf("\n")
+	fmt.Printf("  %-17s  %5s  %6s  %-10s  %8s  %10s  %s\n", "Scanned (UTC)", "Index", "Δ", "Confidence", "Evidence", "Dead", "Signals (crit/warn/info)")
+
+	for i, point := range points {
+		delta := "-"
+		if i > 0 {
+			delta = fmt.Sprintf("%+d", point.Index-points[i-1].Index)
+		}
+		fmt.Printf("  %-17s  %5d  %6s  %-10s  %8d  %3d (%3.0f%%)  %d/%d/%d\n",
+			point.FetchedAt.UTC().Format("2006-01-02 15:04"),
+			point.Index,
+			delta,
+			point.Confidence,
+			point.Evidence,
+			point.DeadLinks,
+			point.DeadRatio*100,
+			point.Signals[model.SeverityCritical],
+			point.Signals[model.SeverityWarning],
+			point.Signals[model.SeverityInfo],
+		)
+	}
+
+	fmt.Printf("\n")
+	return nil
+}
+
+// writeJSON writes v as indented JSON to stdout
+func writeJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("encode JSON: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -83,6 +84,7 @@ func initConfig() {
 
 	// Read in environment variables that match ENTROPIA_*
 	viper.SetEnvPrefix("ENTROPIA")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // http.user_agent -> ENTROPIA_HTTP_USER_AGENT
 	viper.AutomaticEnv()
 
 	// If a config file is found, read it in
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ppiankov/entropia/internal/score"
+	"github.com/spf13/cobra"
+)
+
+// rulesCmd represents the rules command
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Inspect and validate scoring rules files",
+	Long: `Scoring rules control component weights, severity thresholds and
+per-signal penalties used to compute the support index.
+
+A rules file is JSON. Any field it omits keeps its built-in value, so a file
+may override only what it needs. Weights must sum to 100.
+
+Example:
+  entropia rules show > my-rules.json
+  entropia rules validate my-rules.json
+  entropia scan https://example.com --rules my-rules.json`,
+}
+
+var rulesValidateCmd = &cobra.Command{
+	Use:   "validate <file>",
+	Short: "Validate a scoring rules file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := score.LoadRules(args[0])
+		if err != nil {
+			return err
+		}
+
+		w := rules.Weights
+		fmt.Printf("✓ %s is valid\n", args[0])
+		fmt.Printf("  Name:      %s\n", rules.Name)
+		fmt.Printf("  Weights:   coverage=%d authority=%d freshness=%d accessibility=%d\n",
+			w.Coverage, w.Authority, w.Freshness, w.Accessibility)
+		fmt.Printf("  Penalties: %d signal type(s)\n", len(rules.Penalties))
+		return nil
+	},
+}
+
+var rulesShowCmd = &cobra.Command{
+	Use:   "show [file]",
+	Short: "Print effective scoring rules as JSON (built-in rules if no file given)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules := score.DefaultRules()
+		if len(args) == 1 {
+			loaded, err := score.LoadRules(args[0])
+			if err != nil {
+				return err
+			}
+			rules = loaded
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rules); err != nil {
+			return fmt.Errorf("encode rules: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesValidateCmd)
+	rulesCmd.AddCommand(rulesShowCmd)
+}
+
+// validateRulesFile fails fast on a bad --rules value before any scanning starts
+func validateRulesFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	_, err := score.LoadRules(path)
+	return err
+}
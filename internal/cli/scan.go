@@ -4,32 +4,56 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/ppiankov/entropia/internal/extract/adapters"
+	"github.com/ppiankov/entropia/internal/lang"
 	"github.com/ppiankov/entropia/internal/model"
 	"github.com/ppiankov/entropia/internal/pipeline"
 	"github.com/spf13/cobra"
 )
 
 var (
-	outJSON     string
-	outMD       string
-	timeout     time.Duration
-	userAgent   string
-	maxBytes    int64
-	noCache     bool
-	noFooter    bool
-	insecureTLS bool
-	llmEnabled  bool
-	llmProvider string
-	llmModel    string
-	httpProxy   string
-	httpsProxy  string
+	outJSON      string
+	outMD        string
+	outHTML      string
+	timeout      time.Duration
+	userAgent    string
+	maxBytes     int64
+	noCache      bool
+	noFooter     bool
+	insecureTLS  bool
+	llmEnabled   bool
+	llmProvider  string
+	llmModel     string
+	httpProxy    string
+	httpsProxy   string
+	adapterName  string
+	rulesFile    string
+	entitiesFile string
+	language     string
+	keywordPacks []string
+	noHistory    bool
+	contentDates bool
+	soft404      bool
+	noArchive    bool
+	archiveURL   string
+	sourceURL    string
+
+	noIdentifiers bool
+	doiResolver   string
+	arxivResolver string
+	isbnResolver  string
+
+	wikipediaAPI string
+	editWindow   time.Duration
+	maxRevisions int
 )
 
 // scanCmd represents the scan command
 var scanCmd = &cobra.Command{
-	Use:   "scan <url>",
+	Use:   "scan <url|file|->",
 	Short: "Scan a single URL and generate entropy/decay report",
 	Long: `Scan analyzes a single web page to:
 - Extract factual and attributional claims
@@ -38,10 +62,21 @@ var scanCmd = &cobra.Command{
 - Detect conflicts, gaps, and decay
 - Generate transparent, explainable reports
 
+The page can also be read offline: a saved HTML file (path or file:// URL),
+"-" for standard input, or a WARC archive (.warc, .warc.gz). Relative links
+resolve against --source-url, else the page's canonical URL. Markdown and
+reStructuredText files (.md, .rst) are parsed natively; their relative links
+are checked as files on disk.
+
 Example:
   entropia scan https://en.wikipedia.org/wiki/Laksa
   entropia scan https://example.com --json report.json --md report.md
-  entropia scan https://example.com --llm openai --model gpt-4o-mini`,
+  entropia scan https://example.com --llm openai --model gpt-4o-mini
+  entropia scan https://example.com/statute --adapter legal
+  entropia scan file://page.html --source-url https://example.com/page
+  curl -s https://example.com/page | entropia scan - --source-url https://example.com/page
+  entropia scan crawl.warc.gz --source-url https://example.com/page
+  entropia scan docs/install.md`,
 	Args: cobra.ExactArgs(1),
 	RunE: runScan,
 }
@@ -52,16 +87,38 @@ func init() {
 	// Output flags
 	scanCmd.Flags().StringVar(&outJSON, "json", "report.json", "output JSON path")
 	scanCmd.Flags().StringVar(&outMD, "md", "", "output Markdown path (optional)")
+	scanCmd.Flags().StringVar(&outHTML, "html", "", "output self-contained HTML path (optional)")
 
 	// HTTP flags
 	scanCmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "overall scan timeout (increase for pages with many evidence links)")
 	scanCmd.Flags().StringVar(&userAgent, "ua", "Entropia/0.1 (+https://github.com/ppiankov/entropia)", "HTTP User-Agent")
 	scanCmd.Flags().Int64Var(&maxBytes, "max-bytes", 2_000_000, "max response bytes to read")
 	scanCmd.Flags().BoolVar(&noCache, "no-cache", false, "disable cache (force fresh fetch)")
+	scanCmd.Flags().BoolVar(&noHistory, "no-history", false, "do not record this scan in the history store")
+	scanCmd.Flags().BoolVar(&contentDates, "content-dates", false, "GET evidence pages and read publication dates from their HTML (slower, more accurate freshness)")
+	scanCmd.Flags().BoolVar(&soft404, "soft-404", false, "GET evidence pages and flag \"not found\" and domain-parking pages served with 200")
+	scanCmd.Flags().BoolVar(&noArchive, "no-archive", false, "do not look up archived snapshots of dead evidence")
+	scanCmd.Flags().StringVar(&archiveURL, "archive-url", "", "Wayback CDX-compatible endpoint for dead evidence lookups (default: web.archive.org)")
+	identifierFlags(scanCmd)
+	wikipediaFlags(scanCmd)
 	scanCmd.Flags().BoolVar(&noFooter, "no-footer", false, "disable footer in Markdown reports")
 	scanCmd.Flags().BoolVar(&insecureTLS, "insecure", false, "skip TLS certificate verification (use for self-signed certs)")
 	scanCmd.Flags().StringVar(&httpProxy, "http-proxy", "", "HTTP proxy URL (overrides HTTP_PROXY env var)")
 	scanCmd.Flags().StringVar(&httpsProxy, "https-proxy", "", "HTTPS proxy URL (overrides HTTPS_PROXY env var)")
+	scanCmd.Flags().StringVar(&sourceURL, "source-url", "", "original URL of a local file, stdin page or WARC record (links resolve against it)")
+
+	// Extraction flags
+	scanCmd.Flags().StringVar(&rulesFile, "rules", "", "path to custom scoring rules JSON (see 'entropia rules')")
+	scanCmd.Flags().StringVar(&entitiesFile, "entities", "", "path to a historical entity catalog, YAML or JSON (see 'entropia entities')")
+	scanCmd.Flags().StringVar(&adapterName, "adapter", "", "force a domain adapter (docs, wikipedia, legal, generic); default auto-detects per page")
+	scanCmd.Flags().StringVar(&language, "language", "", "force the claim keyword language (en, ru, uk, de, ja); default detects from <html lang> or the wiki subdomain")
+	scanCmd.Flags().StringSliceVar(&keywordPacks, "keyword-packs", nil, "keyword pack files, YAML or JSON, adding or replacing languages (repeatable)")
+
+	// CI flags
+	scanCmd.Flags().StringVar(&ciFormat, "format", "", "also write a CI report (sarif, junit)")
+	scanCmd.Flags().StringVar(&ciOut, "out", "", "CI report path (default report.sarif or report.junit.xml)")
+	scanCmd.Flags().IntVar(&failUnder, "fail-under", 0, "exit non-zero when the support index is below this (0 = off)")
+	scanCmd.Flags().BoolVar(&failOnCritical, "fail-on-critical", false, "exit non-zero when any signal is critical")
 
 	// LLM flags
 	scanCmd.Flags().BoolVar(&llmEnabled, "llm", false, "enable LLM summary generation")
@@ -71,53 +128,37 @@ func init() {
 
 func runScan(cmd *cobra.Command, args []string) error {
 	url := args[0]
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
 
-	if verbose {
-		fmt.Fprintf(os.Stderr, "Scanning: %s\n", url)
-		fmt.Fprintf(os.Stderr, "Timeout: %v\n", timeout)
-		fmt.Fprintf(os.Stderr, "Cache: %v\n", !noCache)
-		fmt.Fprintln(os.Stderr)
+	// Build configuration: config file, then flags given on the command line
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	applyScanFlags(cmd, cfg)
+	flagOverride(cmd, "timeout", "http.timeout", &cfg.HTTP.Timeout, timeout)
+	if err := applyLLMFlags(cmd, cfg); err != nil {
+		return err
 	}
 
-	// Build configuration from flags
-	cfg := model.DefaultConfig()
-	cfg.HTTP.Timeout = timeout
-	cfg.HTTP.UserAgent = userAgent
-	cfg.HTTP.MaxBodyBytes = maxBytes
-	cfg.HTTP.InsecureTLS = insecureTLS
-	cfg.HTTP.HTTPProxy = httpProxy
-	cfg.HTTP.HTTPSProxy = httpsProxy
-	cfg.Cache.Enabled = !noCache
-	cfg.Output.Verbose = verbose
-	cfg.Output.IncludeFooter = !noFooter
+	if err := validateScanConfig(cfg); err != nil {
+		return err
+	}
+	if err := validateCIFlags(); err != nil {
+		return err
+	}
+	if sourceURL != "" && !pipeline.IsWARC(url) && !pipeline.IsLocalSource(url) {
+		return fmt.Errorf("--source-url applies only to local files, stdin and WARC archives")
+	}
 
-	// Configure LLM if enabled
-	if llmEnabled {
-		cfg.LLM.Provider = llmProvider
-		cfg.LLM.Model = llmModel
-		cfg.LLM.StrictEvidence = true // Always enforce
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.Timeout)
+	defer cancel()
 
-		// Get API key from environment
-		switch llmProvider {
-		case "openai":
-			cfg.LLM.APIKey = os.Getenv("OPENAI_API_KEY")
-			if cfg.LLM.APIKey == "" {
-				return fmt.Errorf("OPENAI_API_KEY environment variable not set")
-			}
-		case "anthropic", "claude":
-			cfg.LLM.APIKey = os.Getenv("ANTHROPIC_API_KEY")
-			if cfg.LLM.APIKey == "" {
-				return fmt.Errorf("ANTHROPIC_API_KEY environment variable not set")
-			}
-		case "ollama":
-			// Ollama doesn't need an API key
-			baseURL := os.Getenv("OLLAMA_BASE_URL")
-			if baseURL != "" {
-				cfg.LLM.BaseURL = baseURL
-			}
-		}
+	verbose := cfg.Output.Verbose
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Scanning: %s\n", url)
+		fmt.Fprintf(os.Stderr, "Timeout: %v\n", cfg.HTTP.Timeout)
+		fmt.Fprintf(os.Stderr, "Cache: %v\n", cfg.Cache.Enabled)
+		fmt.Fprintln(os.Stderr)
 	}
 
 	// Create pipeline
@@ -125,15 +166,32 @@ func runScan(cmd *cobra.Command, args []string) error {
 
 	// Scan URL
 	if verbose {
-		fmt.Fprintf(os.Stderr, "⚙️  Fetching HTML...\n")
+		if pipeline.IsWARC(url) || pipeline.IsLocalSource(url) {
+			fmt.Fprintf(os.Stderr, "⚙️  Reading HTML...\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "⚙️  Fetching HTML...\n")
+		}
+	}
+
+	renderer := pipeline.NewRenderer(cfg.Output.IncludeFooter)
+	ciPath := func(format string) string {
+		if format == "junit" {
+			return "report.junit.xml"
+		}
+		return "report.sarif"
 	}
 
-	result, err := p.ScanURL(ctx, url)
+	result, err := scanSource(ctx, p, cfg, url)
 	if err != nil {
+		// Still record the failure so CI shows which page broke
+		if ciErr := writeCIReport(renderer, []pipeline.CIResult{{URL: url, Error: err}}, ciPath); ciErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", ciErr)
+		}
 		return fmt.Errorf("scan failed: %w", err)
 	}
 
 	if verbose {
+		fmt.Fprintf(os.Stderr, "✓ Used %s adapter\n", result.Report.Adapter)
 		fmt.Fprintf(os.Stderr, "✓ Extracted %d claims\n", len(result.Report.Claims))
 		fmt.Fprintf(os.Stderr, "✓ Extracted %d evidence links\n", len(result.Report.Evidence))
 		fmt.Fprintf(os.Stderr, "✓ Calculated support index: %d/100\n", result.Report.Score.Index)
@@ -144,9 +202,202 @@ func runScan(cmd *cobra.Command, args []string) error {
 	}
 
 	// Render outputs
-	if err := p.RenderReport(result.Report, outJSON, outMD, verbose); err != nil {
+	if err := p.RenderReport(result.Report, outJSON, outMD, outHTML, verbose); err != nil {
 		return fmt.Errorf("render failed: %w", err)
 	}
 
+	// CI report and quality gate
+	ciResults := []pipeline.CIResult{{
+		URL:      url,
+		Report:   result.Report,
+		Failures: ciGate().Check(result.Report),
+	}}
+	if err := writeCIReport(renderer, ciResults, ciPath); err != nil {
+		return fmt.Errorf("render failed: %w", err)
+	}
+
+	return gateError(ciResults)
+}
+
+// scanSource scans a URL, or reads the page from a local file, stdin or a
+// WARC archive (the record matching --source-url, else the first page)
+func scanSource(ctx context.Context, p *pipeline.Pipeline, cfg *model.Config, source string) (*pipeline.ScanResult, error) {
+	switch {
+	case pipeline.IsWARC(source):
+		store, err := pipeline.NewWARCStore(source, cfg.HTTP.MaxBodyBytes)
+		if err != nil {
+			return nil, err
+		}
+		page := sourceURL
+		if page == "" {
+			page = store.URLs()[0]
+		}
+		doc, ok, err := store.Load(page)
+		if !ok {
+			return nil, fmt.Errorf("no HTML record for %s in %s", page, source)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return p.ScanDocument(ctx, doc)
+	case pipeline.IsLocalSource(source):
+		doc, err := pipeline.LoadSource(source, sourceURL, os.Stdin, cfg.HTTP.MaxBodyBytes)
+		if err != nil {
+			return nil, err
+		}
+		return p.ScanDocument(ctx, doc)
+	default:
+		return p.ScanURL(ctx, source)
+	}
+}
+
+// applyScanFlags copies the scan flags cmd defines into cfg; each flag
+// overrides the config file only when given (see flagOverride)
+func applyScanFlags(cmd *cobra.Command, cfg *model.Config) {
+	flagOverride(cmd, "ua", "http.user_agent", &cfg.HTTP.UserAgent, userAgent)
+	flagOverride(cmd, "max-bytes", "http.max_body_bytes", &cfg.HTTP.MaxBodyBytes, maxBytes)
+	flagOverride(cmd, "insecure", "http.insecure_tls", &cfg.HTTP.InsecureTLS, insecureTLS)
+	flagOverride(cmd, "http-proxy", "http.http_proxy", &cfg.HTTP.HTTPProxy, httpProxy)
+	flagOverride(cmd, "https-proxy", "http.https_proxy", &cfg.HTTP.HTTPSProxy, httpsProxy)
+	flagOverride(cmd, "concurrency", "concurrency.workers", &cfg.Concurrency.Workers, concurrency)
+	flagOverride(cmd, "no-cache", "cache.enabled", &cfg.Cache.Enabled, !noCache)
+	flagOverride(cmd, "no-history", "history.enabled", &cfg.History.Enabled, !noHistory)
+	flagOverride(cmd, "content-dates", "validation.content_dates", &cfg.Validation.ContentDates, contentDates)
+	flagOverride(cmd, "soft-404", "validation.soft_404", &cfg.Validation.Soft404, soft404)
+	flagOverride(cmd, "no-archive", "archive.enabled", &cfg.Archive.Enabled, !noArchive)
+	if archiveURL != "" {
+		cfg.Archive.CDXURL = archiveURL
+		cfg.Archive.SnapshotURL = "" // Derived from the CDX endpoint
+	}
+	flagOverride(cmd, "no-identifiers", "identifiers.enabled", &cfg.Identifiers.Enabled, !noIdentifiers)
+	applyIdentifierFlags(&cfg.Identifiers)
+	applyWikipediaFlags(&cfg.Wikipedia)
+	flagOverride(cmd, "adapter", "extraction.adapter", &cfg.Extraction.Adapter, adapterName)
+	flagOverride(cmd, "language", "extraction.language", &cfg.Extraction.Language, language)
+	flagOverride(cmd, "keyword-packs", "extraction.keyword_packs", &cfg.Extraction.KeywordPacks, keywordPacks)
+	flagOverride(cmd, "rules", "scoring.rules_file", &cfg.Scoring.RulesFile, rulesFile)
+	flagOverride(cmd, "entities", "entities.catalog_file", &cfg.Entities.CatalogFile, entitiesFile)
+	flagOverride(cmd, "verbose", "output.verbose", &cfg.Output.Verbose, verbose)
+	flagOverride(cmd, "no-footer", "output.include_footer", &cfg.Output.IncludeFooter, !noFooter)
+}
+
+// applyLLMFlags enables the LLM summary for --llm (provider and model from
+// the flags, else the config file) and reads the provider's API key from
+// the environment when the config file has none
+func applyLLMFlags(cmd *cobra.Command, cfg *model.Config) error {
+	if llmEnabled {
+		if cmd.Flags().Changed("llm-provider") || cfg.LLM.Provider == "" {
+			cfg.LLM.Provider = llmProvider
+		}
+		if cmd.Flags().Changed("llm-model") || cfg.LLM.Model == "" {
+			cfg.LLM.Model = llmModel
+		}
+	}
+	if cfg.LLM.Provider == "" {
+		return nil
+	}
+	cfg.LLM.StrictEvidence = true // Always enforce
+
+	// Get API key from environment
+	switch cfg.LLM.Provider {
+	case "openai":
+		if cfg.LLM.APIKey == "" {
+			cfg.LLM.APIKey = os.Getenv("OPENAI_API_KEY")
+		}
+		if cfg.LLM.APIKey == "" {
+			return fmt.Errorf("OPENAI_API_KEY environment variable not set")
+		}
+	case "anthropic", "claude":
+		if cfg.LLM.APIKey == "" {
+			cfg.LLM.APIKey = os.Getenv("ANTHROPIC_API_KEY")
+		}
+		if cfg.LLM.APIKey == "" {
+			return fmt.Errorf("ANTHROPIC_API_KEY environment variable not set")
+		}
+	case "ollama":
+		// Ollama doesn't need an API key
+		baseURL := os.Getenv("OLLAMA_BASE_URL")
+		if baseURL != "" {
+			cfg.LLM.BaseURL = baseURL
+		}
+	}
+	return nil
+}
+
+// validateScanConfig fails fast on a bad adapter, rules file, entity
+// catalog or keyword pack, whether it came from a flag or the config file
+func validateScanConfig(cfg *model.Config) error {
+	if err := validateAdapterName(cfg.Extraction.Adapter); err != nil {
+		return err
+	}
+	if err := validateRulesFile(cfg.Scoring.RulesFile); err != nil {
+		return err
+	}
+	if err := validateEntitiesFile(cfg.Entities.CatalogFile); err != nil {
+		return err
+	}
+	return validateLanguagePacks(cfg.Extraction.KeywordPacks, cfg.Extraction.Language)
+}
+
+// validateAdapterName checks a --adapter value against the registered adapters
+func validateAdapterName(name string) error {
+	if name == "" {
+		return nil
+	}
+	registry := adapters.NewRegistry()
+	if _, ok := registry.Get(name); !ok {
+		return fmt.Errorf("unknown adapter %q (available: %s)", name, strings.Join(registry.Names(), ", "))
+	}
 	return nil
 }
+
+// validateLanguagePacks fails fast on bad --keyword-packs or --language
+// values before any scanning starts
+func validateLanguagePacks(files []string, language string) error {
+	if language == "" && len(files) == 0 {
+		return nil
+	}
+	_, err := lang.Load(files, language)
+	return err
+}
+
+// identifierFlags registers the DOI/arXiv/ISBN resolution flags on cmd
+func identifierFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&noIdentifiers, "no-identifiers", false, "do not treat DOIs, arXiv IDs and ISBNs in page text as evidence")
+	cmd.Flags().StringVar(&doiResolver, "doi-resolver", "", "doi.org-compatible resolver for DOI evidence (default: https://doi.org)")
+	cmd.Flags().StringVar(&arxivResolver, "arxiv-resolver", "", "arXiv abstract page prefix (default: https://arxiv.org/abs)")
+	cmd.Flags().StringVar(&isbnResolver, "isbn-resolver", "", "book lookup prefix for ISBN evidence (default: https://openlibrary.org/isbn)")
+}
+
+// applyIdentifierFlags copies the identifier flags into cfg
+func applyIdentifierFlags(cfg *model.IdentifierConfig) {
+	if doiResolver != "" {
+		cfg.DOIResolver = doiResolver
+	}
+	if arxivResolver != "" {
+		cfg.ArXivResolver = arxivResolver
+	}
+	if isbnResolver != "" {
+		cfg.ISBNResolver = isbnResolver
+	}
+}
+
+// wikipediaFlags registers the Wikipedia revision history flags on cmd
+func wikipediaFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&wikipediaAPI, "wikipedia-api", "", "MediaWiki API endpoint for edit-war analysis, {lang} = page language (default: https://{lang}.wikipedia.org/w/api.php)")
+	cmd.Flags().DurationVar(&editWindow, "edit-window", 0, "revision history analyzed for edit wars (default: 720h)")
+	cmd.Flags().IntVar(&maxRevisions, "max-revisions", 0, "stop paging revision history after this many revisions (default: 1000)")
+}
+
+// applyWikipediaFlags copies the Wikipedia flags into cfg
+func applyWikipediaFlags(cfg *model.WikipediaConfig) {
+	if wikipediaAPI != "" {
+		cfg.APIURL = wikipediaAPI
+	}
+	if editWindow > 0 {
+		cfg.EditWindow = editWindow
+	}
+	if maxRevisions > 0 {
+		cfg.MaxRevisions = maxRevisions
+	}
+}
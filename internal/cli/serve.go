@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/pipeline"
+	"github.com/ppiankov/entropia/internal/server"
+	"github.com/ppiankov/entropia/internal/worker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr         string
+	serveScanTimeout  time.Duration
+	serveBatchTimeout time.Duration
+	serveMaxBatch     int
+	serveMaxReports   int
+	serveMaxJobs      int
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run an HTTP server exposing scans as a JSON API",
+	Long: `Serve keeps one warm pipeline (cache, rules, rate limits) behind a JSON API:
+- POST /scan          {"url": "..."}          synchronous scan, returns report and ID
+- POST /batch         {"urls": ["...", ...]}  starts an async job, returns job ID
+- GET  /jobs/{id}     job status and per-URL results
+- GET  /reports/{id}  a stored report
+- GET  /healthz       liveness check
+
+All requests share one per-domain rate limiter.
+
+Example:
+  entropia serve
+  entropia serve --addr 127.0.0.1:9090 --concurrency 8
+  curl -s -X POST localhost:8080/scan -d '{"url":"https://en.wikipedia.org/wiki/Laksa"}'`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	defaults := server.DefaultOptions()
+
+	// Server flags
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "listen address")
+	serveCmd.Flags().IntVar(&concurrency, "concurrency", runtime.NumCPU(), "number of concurrent workers per batch job")
+	serveCmd.Flags().DurationVar(&serveScanTimeout, "scan-timeout", defaults.ScanTimeout, "timeout for each POST /scan request")
+	serveCmd.Flags().DurationVar(&serveBatchTimeout, "batch-timeout", defaults.BatchTimeout, "total timeout for each batch job")
+	serveCmd.Flags().IntVar(&serveMaxBatch, "max-batch", defaults.MaxBatchURLs, "maximum URLs per batch job (0 = unlimited)")
+	serveCmd.Flags().IntVar(&serveMaxReports, "max-reports", defaults.MaxReports, "reports kept in memory before the oldest are evicted (0 = unlimited)")
+	serveCmd.Flags().IntVar(&serveMaxJobs, "max-jobs", defaults.MaxJobs, "batch jobs kept in memory before the oldest finished ones are evicted (0 = unlimited)")
+
+	// Scan flags
+	serveCmd.Flags().StringVar(&userAgent, "ua", "Entropia/0.1 (+https://github.com/ppiankov/entropia)", "HTTP User-Agent")
+	serveCmd.Flags().BoolVar(&noCache, "no-cache", false, "disable cache (force fresh fetch)")
+	serveCmd.Flags().BoolVar(&noHistory, "no-history", false, "do not record scans in the history store")
+	serveCmd.Flags().BoolVar(&contentDates, "content-dates", false, "GET evidence pages and read publication dates from their HTML (slower, more accurate freshness)")
+	serveCmd.Flags().BoolVar(&soft404, "soft-404", false, "GET evidence pages and flag \"not found\" and domain-parking pages served with 200")
+	serveCmd.Flags().BoolVar(&noArchive, "no-archive", false, "do not look up archived snapshots of dead evidence")
+	serveCmd.Flags().StringVar(&archiveURL, "archive-url", "", "Wayback CDX-compatible endpoint for dead evidence lookups (default: web.archive.org)")
+	identifierFlags(serveCmd)
+	wikipediaFlags(serveCmd)
+	serveCmd.Flags().StringVar(&httpProxy, "http-proxy", "", "HTTP proxy URL (overrides HTTP_PROXY env var)")
+	serveCmd.Flags().StringVar(&httpsProxy, "https-proxy", "", "HTTPS proxy URL (overrides HTTPS_PROXY env var)")
+	serveCmd.Flags().StringVar(&rulesFile, "rules", "", "path to custom scoring rules JSON (see 'entropia rules')")
+	serveCmd.Flags().StringVar(&entitiesFile, "entities", "", "path to a historical entity catalog, YAML or JSON (see 'entropia entities')")
+	serveCmd.Flags().StringVar(&adapterName, "adapter", "", "force a domain adapter for every URL (docs, wikipedia, legal, generic); default auto-detects per page")
+	serveCmd.Flags().StringVar(&language, "language", "", "force the claim keyword language for every URL (en, ru, uk, de, ja); default detects per page")
+	serveCmd.Flags().StringSliceVar(&keywordPacks, "keyword-packs", nil, "keyword pack files, YAML or JSON, adding or replacing languages (repeatable)")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	// Build configuration: config file, then flags given on the command line
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	applyScanFlags(cmd, cfg)
+	if err := validateScanConfig(cfg); err != nil {
+		return err
+	}
+
+	// One pipeline and one limiter for every request
+	p := pipeline.NewPipeline(cfg)
+	processor := worker.NewBatchProcessor(p, cfg.Concurrency.Workers, cfg.RateLimiting.RequestsPerSecond, cfg.RateLimiting.BurstSize)
+	if limiter := processor.Limiter(); limiter != nil && p.Robots() != nil {
+		limiter.SetRobotsChecker(p.Robots()) // Honor Crawl-delay per host
+	}
+
+	api := server.New(processor, server.Options{
+		ScanTimeout:  serveScanTimeout,
+		BatchTimeout: serveBatchTimeout,
+		MaxBatchURLs: serveMaxBatch,
+		MaxReports:   serveMaxReports,
+		MaxJobs:      serveMaxJobs,
+	})
+	defer api.Close()
+
+	httpServer := &http.Server{
+		Addr:              serveAddr,
+		Handler:           api.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	fmt.Fprintf(os.Stderr, "Entropia API listening on %s (workers: %d, rate: %.1f req/s per domain)\n",
+		serveAddr, cfg.Concurrency.Workers, cfg.RateLimiting.RequestsPerSecond)
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("serve: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	fmt.Fprintf(os.Stderr, "Shutting down...\n")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,377 @@
+package crawl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/pdf"
+	"github.com/ppiankov/entropia/internal/pipeline"
+	"github.com/ppiankov/entropia/internal/util"
+	"golang.org/x/net/html"
+)
+
+// Fetcher fetches one page (satisfied by *pipeline.Fetcher)
+type Fetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*pipeline.FetchResult, error)
+}
+
+// Limiter paces requests per host (satisfied by *worker.Limiter)
+type Limiter interface {
+	Wait(ctx context.Context, rawURL string) error
+}
+
+// skipExtensions are linked files that are never pages worth scanning
+var skipExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true, ".ico": true,
+	".css": true, ".js": true, ".json": true, ".xml": true, ".gz": true,
+	".zip": true, ".tar": true, ".tgz": true, ".exe": true, ".dmg": true,
+	".mp3": true, ".mp4": true, ".webm": true, ".woff": true, ".woff2": true, ".ttf": true,
+}
+
+// Crawler discovers same-host pages from sitemaps and links
+type Crawler struct {
+	fetcher Fetcher
+	limiter Limiter             // Optional (nil = no pacing)
+	robots  *util.RobotsChecker // Optional (nil = robots.txt not consulted)
+	cfg     model.CrawlConfig
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// NewCrawler creates a crawler; include/exclude patterns are validated here
+func NewCrawler(fetcher Fetcher, limiter Limiter, robots *util.RobotsChecker, cfg model.CrawlConfig) (*Crawler, error) {
+	include, err := compilePatterns(cfg.Include)
+	if err != nil {
+		return nil, fmt.Errorf("include pattern: %w", err)
+	}
+	exclude, err := compilePatterns(cfg.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("exclude pattern: %w", err)
+	}
+
+	return &Crawler{
+		fetcher: fetcher,
+		limiter: limiter,
+		robots:  robots,
+		cfg:     cfg,
+		include: include,
+		exclude: exclude,
+	}, nil
+}
+
+// Crawl returns pages on root's host: URLs from the host's sitemaps (when
+// enabled) followed by pages reached by following links breadth-first up to
+// MaxDepth hops. Pages that fail to load are skipped; an error is returned
+// only when nothing could be discovered.
+func (c *Crawler) Crawl(ctx context.Context, root string) ([]string, error) {
+	rootURL, err := url.Parse(root)
+	if err != nil || (rootURL.Scheme != "http" && rootURL.Scheme != "https") || rootURL.Host == "" {
+		return nil, fmt.Errorf("invalid root URL %q", root)
+	}
+	normalize(rootURL)
+
+	found := newPageSet(c.cfg.MaxPages)
+
+	if c.cfg.Sitemaps {
+		for _, loc := range c.hostSitemapPages(ctx, rootURL) {
+			if sameHost(loc, rootURL) && c.allowed(ctx, loc) {
+				found.add(loc.String())
+			}
+		}
+	}
+
+	type queued struct {
+		url   *url.URL
+		depth int
+	}
+	queue := []queued{{rootURL, 0}}
+	visited := map[string]bool{rootURL.String(): true}
+	var rootErr error
+
+	for len(queue) > 0 && !found.full() {
+		if err := ctx.Err(); err != nil {
+			return found.urls, err
+		}
+		current := queue[0]
+		queue = queue[1:]
+
+		page, err := c.fetch(ctx, current.url.String())
+		if err != nil {
+			if current.depth == 0 {
+				rootErr = err
+			}
+			continue
+		}
+		document := isPDF(page)
+		if !document && !isHTML(page.Meta.ContentType) {
+			continue
+		}
+
+		finalURL, err := url.Parse(page.FinalURL)
+		if err != nil || !sameHost(finalURL, rootURL) {
+			continue // Redirected off-site
+		}
+		normalize(finalURL)
+
+		if document {
+			// Scannable, but there are no links to follow
+			if c.matches(finalURL) {
+				found.add(finalURL.String())
+			}
+			continue
+		}
+
+		links, robotsMeta := parseLinks(page.HTML, finalURL)
+		if !robotsMeta.noIndex && c.matches(finalURL) {
+			found.add(finalURL.String())
+		}
+		if robotsMeta.noFollow || current.depth >= c.cfg.MaxDepth {
+			continue
+		}
+
+		for _, link := range links {
+			key := link.String()
+			if visited[key] || !sameHost(link, rootURL) || !c.matches(link) {
+				continue
+			}
+			visited[key] = true
+			queue = append(queue, queued{link, current.depth + 1})
+		}
+	}
+
+	if len(found.urls) == 0 && rootErr != nil {
+		return nil, fmt.Errorf("crawl %s: %w", root, rootErr)
+	}
+	return found.urls, nil
+}
+
+// Sitemap returns the page URLs listed in a sitemap (or sitemap index) at
+// source, a URL or local file, filtered by the include/exclude patterns,
+// robots.txt and MaxPages
+func (c *Crawler) Sitemap(ctx context.Context, source string) ([]string, error) {
+	locs, err := c.readSitemap(ctx, source, 0, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	found := newPageSet(c.cfg.MaxPages)
+	for _, loc := range locs {
+		if found.full() {
+			break
+		}
+		if c.allowed(ctx, loc) {
+			found.add(loc.String())
+		}
+	}
+	return found.urls, nil
+}
+
+// hostSitemapPages reads the sitemaps advertised in robots.txt, falling
+// back to /sitemap.xml. Failures just mean no sitemap.
+func (c *Crawler) hostSitemapPages(ctx context.Context, rootURL *url.URL) []*url.URL {
+	var sources []string
+	if c.robots != nil {
+		sources, _ = c.robots.Sitemaps(ctx, rootURL.String())
+	}
+	if len(sources) == 0 {
+		sources = []string{(&url.URL{Scheme: rootURL.Scheme, Host: rootURL.Host, Path: "/sitemap.xml"}).String()}
+	}
+
+	var pages []*url.URL
+	visited := map[string]bool{}
+	for _, source := range sources {
+		locs, err := c.readSitemap(ctx, source, 0, visited)
+		if err != nil {
+			continue
+		}
+		pages = append(pages, locs...)
+	}
+	return pages
+}
+
+// fetch waits for the per-host limiter, then fetches (the fetcher enforces
+// robots.txt)
+func (c *Crawler) fetch(ctx context.Context, rawURL string) (*pipeline.FetchResult, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx, rawURL); err != nil {
+			return nil, fmt.Errorf("rate limit: %w", err)
+		}
+	}
+	return c.fetcher.Fetch(ctx, rawURL)
+}
+
+// allowed applies the path patterns, asset filter and robots.txt to a
+// discovered URL that will not be fetched during discovery
+func (c *Crawler) allowed(ctx context.Context, u *url.URL) bool {
+	if !c.matches(u) {
+		return false
+	}
+	return c.robots == nil || c.robots.IsAllowed(ctx, u.String())
+}
+
+// matches reports whether a URL is a page that passes include/exclude
+func (c *Crawler) matches(u *url.URL) bool {
+	if skipExtensions[strings.ToLower(path.Ext(u.Path))] {
+		return false
+	}
+
+	p := u.Path
+	if p == "" {
+		p = "/"
+	}
+	for _, re := range c.exclude {
+		if re.MatchString(p) {
+			return false
+		}
+	}
+	if len(c.include) == 0 {
+		return true
+	}
+	for _, re := range c.include {
+		if re.MatchString(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// compilePatterns turns path globs into anchored regexps: `*` matches any
+// run of characters (including `/`) and `?` matches one character
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		expr := regexp.QuoteMeta(pattern)
+		expr = strings.ReplaceAll(expr, `\*`, `.*`)
+		expr = strings.ReplaceAll(expr, `\?`, `.`)
+		re, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// robotsMeta holds <meta name="robots"> directives
+type robotsMeta struct {
+	noIndex  bool
+	noFollow bool
+}
+
+// parseLinks returns the http(s) links of a page resolved against base
+// (fragments removed), plus its robots meta directives
+func parseLinks(body string, base *url.URL) ([]*url.URL, robotsMeta) {
+	var links []*url.URL
+	var meta robotsMeta
+
+	tokenizer := html.NewTokenizer(strings.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return links, meta
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "base":
+				if href := attr(token, "href"); href != "" {
+					if resolved, err := base.Parse(href); err == nil {
+						base = resolved
+					}
+				}
+			case "meta":
+				if strings.EqualFold(attr(token, "name"), "robots") {
+					content := strings.ToLower(attr(token, "content"))
+					meta.noIndex = meta.noIndex || strings.Contains(content, "noindex") || strings.Contains(content, "none")
+					meta.noFollow = meta.noFollow || strings.Contains(content, "nofollow") || strings.Contains(content, "none")
+				}
+			case "a":
+				href := strings.TrimSpace(attr(token, "href"))
+				if href == "" || strings.HasPrefix(href, "#") || strings.Contains(strings.ToLower(attr(token, "rel")), "nofollow") {
+					continue
+				}
+				link, err := base.Parse(href)
+				if err != nil || (link.Scheme != "http" && link.Scheme != "https") {
+					continue
+				}
+				normalize(link)
+				links = append(links, link)
+			}
+		}
+	}
+}
+
+// attr returns the value of the named attribute
+func attr(token html.Token, name string) string {
+	for _, a := range token.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// normalize drops the fragment and gives an empty path its root slash
+func normalize(u *url.URL) {
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+}
+
+// sameHost compares hosts case-insensitively, ignoring a leading "www."
+func sameHost(u, root *url.URL) bool {
+	trim := func(host string) string {
+		return strings.TrimPrefix(strings.ToLower(host), "www.")
+	}
+	return trim(u.Host) == trim(root.Host)
+}
+
+// isHTML reports whether a Content-Type is HTML (empty counts as HTML)
+func isHTML(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return contentType == "" || strings.Contains(contentType, "html")
+}
+
+// isPDF reports whether a fetched page is a PDF, by Content-Type or by the
+// %PDF- signature
+func isPDF(page *pipeline.FetchResult) bool {
+	mediaType, _, _ := strings.Cut(page.Meta.ContentType, ";")
+	switch strings.ToLower(strings.TrimSpace(mediaType)) {
+	case "application/pdf", "application/x-pdf":
+		return true
+	}
+	return pdf.IsPDF([]byte(page.HTML))
+}
+
+// pageSet collects unique URLs in discovery order up to a limit
+type pageSet struct {
+	urls  []string
+	seen  map[string]bool
+	limit int
+}
+
+func newPageSet(limit int) *pageSet {
+	return &pageSet{seen: map[string]bool{}, limit: limit}
+}
+
+func (s *pageSet) add(u string) {
+	if s.seen[u] || s.full() {
+		return
+	}
+	s.seen[u] = true
+	s.urls = append(s.urls, u)
+}
+
+func (s *pageSet) full() bool {
+	return s.limit > 0 && len(s.urls) >= s.limit
+}
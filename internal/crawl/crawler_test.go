@@ -0,0 +1,241 @@
+package crawl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/pipeline"
+	"github.com/ppiankov/entropia/internal/util"
+)
+
+// countingLimiter records every URL it paces
+type countingLimiter struct {
+	mu   sync.Mutex
+	urls []string
+}
+
+func (l *countingLimiter) Wait(ctx context.Context, rawURL string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.urls = append(l.urls, rawURL)
+	return nil
+}
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestCrawler(t *testing.T, cfg model.CrawlConfig, withRobots bool) (*Crawler, *countingLimiter) {
+	t.Helper()
+	fetcher := pipeline.NewFetcher(5*time.Second, "EntropiaTest/1.0", MaxSitemapBytes, false, "", "", "")
+	var robots *util.RobotsChecker
+	if withRobots {
+		robots = util.NewRobotsChecker("EntropiaTest/1.0", 5*time.Second)
+		fetcher.SetRobotsChecker(robots)
+	}
+	limiter := &countingLimiter{}
+	crawler, err := NewCrawler(fetcher, limiter, robots, cfg)
+	if err != nil {
+		t.Fatalf("NewCrawler failed: %v", err)
+	}
+	return crawler, limiter
+}
+
+func docsSite(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := func(body string) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = fmt.Fprintf(w, "<html><body>%s</body></html>", body)
+		}
+		switch r.URL.Path {
+		case "/robots.txt":
+			_, _ = fmt.Fprintf(w, "User-agent: *\nDisallow: /private/\nSitemap: %s/sitemap-index.xml\n", server.URL)
+		case "/sitemap-index.xml":
+			_, _ = fmt.Fprintf(w, `<?xml version="1.0"?><sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><sitemap><loc>%s/sitemap-docs.xml.gz</loc></sitemap></sitemapindex>`, server.URL)
+		case "/sitemap-docs.xml.gz":
+			w.Header().Set("Content-Type", "application/x-gzip")
+			_, _ = w.Write(gzipBytes(t, fmt.Sprintf(`<?xml version="1.0"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>%[1]s/docs/from-sitemap</loc></url><url><loc>%[1]s/private/secret</loc></url><url><loc>https://other.example.com/docs/x</loc></url></urlset>`, server.URL)))
+		case "/":
+			page(`<a href="/files/spec.pdf">Spec</a> <a href="/docs/intro">Intro</a> <a href="/blog/post">Blog</a> <a href="/private/page">Private</a> <a href="https://other.example.com/">Off-site</a> <a href="/logo.png">Logo</a>`)
+		case "/docs/intro":
+			page(`<a href="/docs/guide#setup">Guide</a> <a href="/docs/intro">Self</a> <a href="/docs/noindex">Hidden</a>`)
+		case "/docs/guide":
+			page(`<a href="/docs/deep">Deep</a>`)
+		case "/docs/deep":
+			page(`<a href="/docs/deeper">Deeper</a>`)
+		case "/files/spec.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF-1.4\n%%EOF\n"))
+		case "/docs/noindex":
+			page(`<meta name="robots" content="noindex">`)
+		case "/blog/post", "/docs/from-sitemap", "/private/page", "/docs/deeper":
+			page(`text`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCrawler_Crawl(t *testing.T) {
+	server := docsSite(t)
+
+	crawler, limiter := newTestCrawler(t, model.CrawlConfig{MaxDepth: 2, Sitemaps: true, Exclude: []string{"/blog/*"}}, true)
+	urls, err := crawler.Crawl(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Crawl failed: %v", err)
+	}
+
+	want := []string{
+		server.URL + "/docs/from-sitemap", // Sitemap pages come first
+		server.URL + "/",
+		server.URL + "/files/spec.pdf", // Linked PDFs are scannable pages
+		server.URL + "/docs/intro",
+		server.URL + "/docs/guide", // Fragment dropped; depth 2
+	}
+	if !reflect.DeepEqual(urls, want) {
+		t.Errorf("Crawl() = %v, want %v", urls, want)
+	}
+
+	for _, u := range limiter.urls {
+		if u == server.URL+"/blog/post" || u == server.URL+"/docs/deep" {
+			t.Errorf("Excluded or too-deep page %s should not be fetched", u)
+		}
+	}
+	if len(limiter.urls) == 0 {
+		t.Error("Expected discovery requests to go through the limiter")
+	}
+}
+
+func TestCrawler_IncludeAndMaxPages(t *testing.T) {
+	server := docsSite(t)
+
+	crawler, _ := newTestCrawler(t, model.CrawlConfig{MaxDepth: 5, MaxPages: 3, Include: []string{"/docs/*"}}, false)
+	urls, err := crawler.Crawl(context.Background(), server.URL+"/")
+	if err != nil {
+		t.Fatalf("Crawl failed: %v", err)
+	}
+
+	want := []string{server.URL + "/docs/intro", server.URL + "/docs/guide", server.URL + "/docs/deep"}
+	if !reflect.DeepEqual(urls, want) {
+		t.Errorf("Crawl() = %v, want %v", urls, want)
+	}
+}
+
+func TestCrawler_RootFailure(t *testing.T) {
+	server := docsSite(t)
+
+	crawler, _ := newTestCrawler(t, model.CrawlConfig{MaxDepth: 1}, false)
+	if _, err := crawler.Crawl(context.Background(), server.URL+"/missing"); err == nil {
+		t.Error("Expected an error when nothing can be discovered")
+	}
+	if _, err := crawler.Crawl(context.Background(), "ftp://example.com/"); err == nil {
+		t.Error("Expected an error for a non-HTTP root")
+	}
+}
+
+func TestCrawler_Sitemap(t *testing.T) {
+	server := docsSite(t)
+
+	crawler, _ := newTestCrawler(t, model.CrawlConfig{}, true)
+	urls, err := crawler.Sitemap(context.Background(), server.URL+"/sitemap-index.xml")
+	if err != nil {
+		t.Fatalf("Sitemap failed: %v", err)
+	}
+	sort.Strings(urls)
+	want := []string{server.URL + "/docs/from-sitemap", "https://other.example.com/docs/x"}
+	if !reflect.DeepEqual(urls, want) {
+		t.Errorf("Sitemap() = %v, want %v (robots.txt should drop /private/)", urls, want)
+	}
+
+	// Local gzipped file
+	path := filepath.Join(t.TempDir(), "sitemap.xml.gz")
+	local := `<urlset><url><loc>https://docs.example.com/a</loc></url><url><loc>https://docs.example.com/b</loc></url><url><loc>not a url</loc></url></urlset>`
+	if err := os.WriteFile(path, gzipBytes(t, local), 0644); err != nil {
+		t.Fatal(err)
+	}
+	crawler, _ = newTestCrawler(t, model.CrawlConfig{MaxPages: 1}, false)
+	urls, err = crawler.Sitemap(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Sitemap(file) failed: %v", err)
+	}
+	if !reflect.DeepEqual(urls, []string{"https://docs.example.com/a"}) {
+		t.Errorf("Sitemap(file) = %v, want first URL only", urls)
+	}
+}
+
+func TestParseSitemap(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		children int
+		pages    int
+		wantErr  bool
+	}{
+		{"urlset", `<urlset><url><loc> https://a.example/x </loc></url></urlset>`, 0, 1, false},
+		{"index", `<sitemapindex><sitemap><loc>https://a.example/s1.xml</loc></sitemap><sitemap><loc>https://a.example/s2.xml</loc></sitemap></sitemapindex>`, 2, 0, false},
+		{"plain text", "\xef\xbb\xbfhttps://a.example/x\nhttps://a.example/y\n", 0, 2, false},
+		{"html page", `<html><body>Not found</body></html>`, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			children, pages, err := parseSitemap([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSitemap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(children) != tt.children || len(pages) != tt.pages {
+				t.Errorf("Expected %d children and %d pages, got %v %v", tt.children, tt.pages, children, pages)
+			}
+		})
+	}
+}
+
+func TestCompilePatterns(t *testing.T) {
+	patterns, err := compilePatterns([]string{"/docs/*", "/v?/api", " "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 2 {
+		t.Fatalf("Expected blank pattern to be dropped, got %d", len(patterns))
+	}
+
+	tests := map[string]bool{
+		"/docs/a/b/c": true,
+		"/docs/":      true,
+		"/docs":       false,
+		"/v2/api":     true,
+		"/v10/api":    false,
+		"/blog/docs/": false,
+	}
+	for path, want := range tests {
+		got := patterns[0].MatchString(path) || patterns[1].MatchString(path)
+		if got != want {
+			t.Errorf("match(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package crawl
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// MaxSitemapBytes is the sitemap protocol's size limit (uncompressed); use
+// it as the body limit of the discovery fetcher
+const MaxSitemapBytes = 50 << 20
+
+// maxSitemapDepth bounds nested sitemap indexes
+const maxSitemapDepth = 3
+
+// errNotSitemap is returned for documents that are neither a urlset nor a
+// sitemapindex
+var errNotSitemap = errors.New("not a sitemap")
+
+// sitemapDocument covers both <urlset> and <sitemapindex>
+type sitemapDocument struct {
+	XMLName  xml.Name
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+// readSitemap loads a sitemap from a URL or local file and returns its page
+// URLs, following sitemap indexes up to maxSitemapDepth levels
+func (c *Crawler) readSitemap(ctx context.Context, source string, depth int, visited map[string]bool) ([]*url.URL, error) {
+	if visited[source] {
+		return nil, nil
+	}
+	visited[source] = true
+
+	data, err := c.loadSitemap(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	children, pages, err := parseSitemap(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse sitemap %s: %w", source, err)
+	}
+
+	if len(children) > 0 && depth >= maxSitemapDepth {
+		return pages, nil
+	}
+	for _, child := range children {
+		if err := ctx.Err(); err != nil {
+			return pages, err
+		}
+		childPages, err := c.readSitemap(ctx, child, depth+1, visited)
+		if err != nil {
+			continue // One broken child sitemap should not hide the rest
+		}
+		pages = append(pages, childPages...)
+	}
+
+	return pages, nil
+}
+
+// loadSitemap reads a sitemap from disk or over HTTP, decompressing gzip
+func (c *Crawler) loadSitemap(ctx context.Context, source string) ([]byte, error) {
+	var data []byte
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		page, err := c.fetch(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("fetch sitemap: %w", err)
+		}
+		data = []byte(page.HTML)
+	} else {
+		var err error
+		if data, err = os.ReadFile(source); err != nil {
+			return nil, fmt.Errorf("read sitemap: %w", err)
+		}
+	}
+
+	// Gzipped sitemaps (sitemap.xml.gz) arrive compressed; detect by magic bytes
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("decompress sitemap: %w", err)
+		}
+		defer func() { _ = reader.Close() }()
+		if data, err = io.ReadAll(io.LimitReader(reader, MaxSitemapBytes)); err != nil {
+			return nil, fmt.Errorf("decompress sitemap: %w", err)
+		}
+	}
+
+	return data, nil
+}
+
+// parseSitemap returns the child sitemaps of a sitemap index and the page
+// URLs of a urlset. Plain-text sitemaps (one URL per line) are accepted too.
+func parseSitemap(data []byte) (children []string, pages []*url.URL, err error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(trimmed) > 0 && trimmed[0] != '<' {
+		scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+		for scanner.Scan() {
+			if page := parseLoc(scanner.Text()); page != nil {
+				pages = append(pages, page)
+			}
+		}
+		return nil, pages, scanner.Err()
+	}
+
+	var doc sitemapDocument
+	if err := xml.Unmarshal(trimmed, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	switch doc.XMLName.Local {
+	case "sitemapindex":
+		for _, sitemap := range doc.Sitemaps {
+			if loc := strings.TrimSpace(sitemap.Loc); loc != "" {
+				children = append(children, loc)
+			}
+		}
+	case "urlset":
+		for _, entry := range doc.URLs {
+			if page := parseLoc(entry.Loc); page != nil {
+				pages = append(pages, page)
+			}
+		}
+	default:
+		return nil, nil, errNotSitemap
+	}
+
+	return children, pages, nil
+}
+
+// parseLoc parses an absolute http(s) page URL
+func parseLoc(loc string) *url.URL {
+	u, err := url.Parse(strings.TrimSpace(loc))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil
+	}
+	normalize(u)
+	return u
+}
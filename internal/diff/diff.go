@@ -0,0 +1,246 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// ReportDiff describes how a source drifted between two scans
+type ReportDiff struct {
+	SourceURL string    `json:"source_url"`
+	OldScan   time.Time `json:"old_fetched_at"`
+	NewScan   time.Time `json:"new_fetched_at"`
+
+	Index IndexChange `json:"index"`
+
+	ClaimsAdded   []string `json:"claims_added,omitempty"`
+	ClaimsRemoved []string `json:"claims_removed,omitempty"`
+
+	EvidenceAdded   []string `json:"evidence_added,omitempty"`
+	EvidenceRemoved []string `json:"evidence_removed,omitempty"`
+
+	NewlyDead   []string     `json:"newly_dead,omitempty"`   // Evidence that was alive and is now dead
+	Revived     []string     `json:"revived,omitempty"`      // Evidence that was dead and is now alive
+	TierChanges []TierChange `json:"tier_changes,omitempty"` // Authority tier shifts for the same URL
+
+	SignalsAppeared []SignalChange `json:"signals_appeared,omitempty"`
+	SignalsCleared  []SignalChange `json:"signals_cleared,omitempty"`
+	SignalsChanged  []SignalChange `json:"signals_changed,omitempty"` // Same type, different severity
+}
+
+// IndexChange captures the support index movement
+type IndexChange struct {
+	Old           int    `json:"old"`
+	New           int    `json:"new"`
+	Delta         int    `json:"delta"`
+	OldConfidence string `json:"old_confidence"`
+	NewConfidence string `json:"new_confidence"`
+}
+
+// TierChange records an authority reclassification of one evidence URL
+type TierChange struct {
+	URL string `json:"url"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// SignalChange records a signal that appeared, cleared or changed severity
+type SignalChange struct {
+	Type        model.SignalType     `json:"type"`
+	OldSeverity model.SignalSeverity `json:"old_severity,omitempty"`
+	NewSeverity model.SignalSeverity `json:"new_severity,omitempty"`
+	Description string               `json:"description"`
+}
+
+// HasChanges reports whether anything beyond timestamps differs
+func (d *ReportDiff) HasChanges() bool {
+	return d.Index.Delta != 0 || d.Index.OldConfidence != d.Index.NewConfidence ||
+		len(d.ClaimsAdded) > 0 || len(d.ClaimsRemoved) > 0 ||
+		len(d.EvidenceAdded) > 0 || len(d.EvidenceRemoved) > 0 ||
+		len(d.NewlyDead) > 0 || len(d.Revived) > 0 || len(d.TierChanges) > 0 ||
+		len(d.SignalsAppeared) > 0 || len(d.SignalsCleared) > 0 || len(d.SignalsChanged) > 0
+}
+
+// LoadReport reads a JSON report written by `entropia scan`
+func LoadReport(path string) (*model.Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read report: %w", err)
+	}
+
+	var report model.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parse report %s: %w", path, err)
+	}
+
+	return &report, nil
+}
+
+// Compare computes the delta from oldReport to newReport
+func Compare(oldReport, newReport *model.Report) *ReportDiff {
+	d := &ReportDiff{
+		SourceURL: newReport.SourceURL,
+		OldScan:   oldReport.FetchedAt,
+		NewScan:   newReport.FetchedAt,
+		Index: IndexChange{
+			Old:           oldReport.Score.Index,
+			New:           newReport.Score.Index,
+			Delta:         newReport.Score.Index - oldReport.Score.Index,
+			OldConfidence: oldReport.Score.Confidence,
+			NewConfidence: newReport.Score.Confidence,
+		},
+	}
+
+	// Claims are matched on normalized text
+	d.ClaimsAdded, d.ClaimsRemoved = setDelta(claimTexts(oldReport.Claims), claimTexts(newReport.Claims))
+
+	// Evidence is matched on URL
+	d.EvidenceAdded, d.EvidenceRemoved = setDelta(evidenceURLs(oldReport.Evidence), evidenceURLs(newReport.Evidence))
+
+	// Validation state changes for URLs present in both scans
+	oldValidation := validationByURL(oldReport.Validation)
+	newValidation := validationByURL(newReport.Validation)
+	for _, url := range sortedKeys(newValidation) {
+		newResult := newValidation[url]
+		oldResult, ok := oldValidation[url]
+		if !ok {
+			continue
+		}
+
+		if !oldResult.Dead() && newResult.Dead() {
+			d.NewlyDead = append(d.NewlyDead, url)
+		} else if oldResult.Dead() && !newResult.Dead() {
+			d.Revived = append(d.Revived, url)
+		}
+
+		if oldResult.Authority != newResult.Authority {
+			d.TierChanges = append(d.TierChanges, TierChange{
+				URL: url,
+				Old: oldResult.Authority.String(),
+				New: newResult.Authority.String(),
+			})
+		}
+	}
+
+	d.SignalsAppeared, d.SignalsCleared, d.SignalsChanged = compareSignals(oldReport.Score.Signals, newReport.Score.Signals)
+
+	return d
+}
+
+// compareSignals matches signals by type, using the most severe signal of each type
+func compareSignals(oldSignals, newSignals []model.Signal) (appeared, cleared, changed []SignalChange) {
+	oldByType := strongestByType(oldSignals)
+	newByType := strongestByType(newSignals)
+
+	for _, signalType := range sortedKeys(newByType) {
+		newSignal := newByType[signalType]
+		oldSignal, ok := oldByType[signalType]
+		if !ok {
+			appeared = append(appeared, SignalChange{
+				Type:        signalType,
+				NewSeverity: newSignal.Severity,
+				Description: newSignal.Description,
+			})
+			continue
+		}
+		if oldSignal.Severity != newSignal.Severity {
+			changed = append(changed, SignalChange{
+				Type:        signalType,
+				OldSeverity: oldSignal.Severity,
+				NewSeverity: newSignal.Severity,
+				Description: newSignal.Description,
+			})
+		}
+	}
+
+	for _, signalType := range sortedKeys(oldByType) {
+		if _, ok := newByType[signalType]; !ok {
+			oldSignal := oldByType[signalType]
+			cleared = append(cleared, SignalChange{
+				Type:        signalType,
+				OldSeverity: oldSignal.Severity,
+				Description: oldSignal.Description,
+			})
+		}
+	}
+
+	return appeared, cleared, changed
+}
+
+func strongestByType(signals []model.Signal) map[model.SignalType]model.Signal {
+	byType := make(map[model.SignalType]model.Signal)
+	for _, signal := range signals {
+		if existing, ok := byType[signal.Type]; !ok || severityRank(signal.Severity) > severityRank(existing.Severity) {
+			byType[signal.Type] = signal
+		}
+	}
+	return byType
+}
+
+func severityRank(severity model.SignalSeverity) int {
+	switch severity {
+	case model.SeverityCritical:
+		return 2
+	case model.SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func claimTexts(claims []model.Claim) map[string]string {
+	texts := make(map[string]string, len(claims))
+	for _, claim := range claims {
+		key := strings.ToLower(strings.TrimSpace(claim.Text))
+		if key != "" {
+			texts[key] = claim.Text
+		}
+	}
+	return texts
+}
+
+func evidenceURLs(evidence []model.Evidence) map[string]string {
+	urls := make(map[string]string, len(evidence))
+	for _, ev := range evidence {
+		urls[ev.URL] = ev.URL
+	}
+	return urls
+}
+
+func validationByURL(validation []model.ValidationResult) map[string]model.ValidationResult {
+	byURL := make(map[string]model.ValidationResult, len(validation))
+	for _, v := range validation {
+		byURL[v.URL] = v
+	}
+	return byURL
+}
+
+// setDelta returns the display values added to and removed from a keyed set, sorted
+func setDelta(oldSet, newSet map[string]string) (added, removed []string) {
+	for _, key := range sortedKeys(newSet) {
+		if _, ok := oldSet[key]; !ok {
+			added = append(added, newSet[key])
+		}
+	}
+	for _, key := range sortedKeys(oldSet) {
+		if _, ok := newSet[key]; !ok {
+			removed = append(removed, oldSet[key])
+		}
+	}
+	return added, removed
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
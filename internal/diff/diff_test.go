@@ -0,0 +1,129 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+func baseReport() *model.Report {
+	return &model.Report{
+		SourceURL: "https://example.com/laksa",
+		FetchedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Claims: []model.Claim{
+			{Text: "Laksa originated in Malaysia."},
+			{Text: "Laksa was first served in Penang."},
+		},
+		Evidence: []model.Evidence{
+			{URL: "https://a.example/1"},
+			{URL: "https://b.example/2"},
+		},
+		Validation: []model.ValidationResult{
+			{URL: "https://a.example/1", IsAccessible: true, Authority: model.TierTertiary},
+			{URL: "https://b.example/2", IsAccessible: true, Authority: model.TierSecondary},
+		},
+		Score: model.Score{
+			Index:      72,
+			Confidence: "medium",
+			Signals: []model.Signal{
+				{Type: model.SignalAccessibility, Severity: model.SeverityInfo, Description: "Accessibility: 2/2"},
+				{Type: model.SignalConflict, Severity: model.SeverityWarning, Description: "Conflicting origin claims"},
+			},
+		},
+	}
+}
+
+func TestCompare_NoChanges(t *testing.T) {
+	d := Compare(baseReport(), baseReport())
+	if d.HasChanges() {
+		t.Errorf("Expected no changes, got %+v", d)
+	}
+	if !strings.Contains(RenderMarkdown(d), "No changes detected") {
+		t.Error("Expected markdown to state no changes")
+	}
+}
+
+func TestCompare_Drift(t *testing.T) {
+	oldReport := baseReport()
+	newReport := baseReport()
+	newReport.FetchedAt = oldReport.FetchedAt.Add(7 * 24 * time.Hour)
+
+	// Claim rewritten (case-only changes are not drift)
+	newReport.Claims = []model.Claim{
+		{Text: "LAKSA ORIGINATED IN MALAYSIA."},
+		{Text: "Laksa was first served in Singapore."},
+	}
+	// One link died, one was reclassified, one added
+	newReport.Evidence = append(newReport.Evidence, model.Evidence{URL: "https://c.example/3"})
+	newReport.Validation = []model.ValidationResult{
+		{URL: "https://a.example/1", IsDead: true, Authority: model.TierTertiary},
+		{URL: "https://b.example/2", IsAccessible: true, Authority: model.TierPrimary},
+		{URL: "https://c.example/3", IsAccessible: true, Authority: model.TierTertiary},
+	}
+	newReport.Score = model.Score{
+		Index:      55,
+		Confidence: "low",
+		Signals: []model.Signal{
+			{Type: model.SignalAccessibility, Severity: model.SeverityWarning, Description: "Accessibility: 2/3"},
+			{Type: model.SignalEditWar, Severity: model.SeverityCritical, Description: "Edit war detected"},
+		},
+	}
+
+	d := Compare(oldReport, newReport)
+
+	if d.Index.Delta != -17 {
+		t.Errorf("Expected index delta -17, got %d", d.Index.Delta)
+	}
+	if len(d.ClaimsAdded) != 1 || d.ClaimsAdded[0] != "Laksa was first served in Singapore." {
+		t.Errorf("Unexpected claims added: %v", d.ClaimsAdded)
+	}
+	if len(d.ClaimsRemoved) != 1 || d.ClaimsRemoved[0] != "Laksa was first served in Penang." {
+		t.Errorf("Unexpected claims removed: %v", d.ClaimsRemoved)
+	}
+	if len(d.EvidenceAdded) != 1 || d.EvidenceAdded[0] != "https://c.example/3" {
+		t.Errorf("Unexpected evidence added: %v", d.EvidenceAdded)
+	}
+	if len(d.NewlyDead) != 1 || d.NewlyDead[0] != "https://a.example/1" {
+		t.Errorf("Unexpected newly dead: %v", d.NewlyDead)
+	}
+	if len(d.TierChanges) != 1 || d.TierChanges[0].Old != "secondary" || d.TierChanges[0].New != "primary" {
+		t.Errorf("Unexpected tier changes: %+v", d.TierChanges)
+	}
+	if len(d.SignalsAppeared) != 1 || d.SignalsAppeared[0].Type != model.SignalEditWar {
+		t.Errorf("Unexpected signals appeared: %+v", d.SignalsAppeared)
+	}
+	if len(d.SignalsCleared) != 1 || d.SignalsCleared[0].Type != model.SignalConflict {
+		t.Errorf("Unexpected signals cleared: %+v", d.SignalsCleared)
+	}
+	if len(d.SignalsChanged) != 1 || d.SignalsChanged[0].NewSeverity != model.SeverityWarning {
+		t.Errorf("Unexpected signals changed: %+v", d.SignalsChanged)
+	}
+
+	md := RenderMarkdown(d)
+	for _, want := range []string{"72 → 55 (-17)", "Evidence Gone Dead (1)", "secondary → primary", "**edit_war** (critical)"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestCompare_Soft404CountsAsDead(t *testing.T) {
+	oldReport := baseReport()
+	newReport := baseReport()
+	newReport.Validation = []model.ValidationResult{
+		{URL: "https://a.example/1", IsAccessible: true, Soft404: model.Soft404ParkedDomain, Authority: model.TierTertiary},
+		{URL: "https://b.example/2", IsAccessible: true, Authority: model.TierSecondary},
+	}
+
+	d := Compare(oldReport, newReport)
+	if len(d.NewlyDead) != 1 || d.NewlyDead[0] != "https://a.example/1" {
+		t.Errorf("Expected the parked link reported as newly dead, got %v", d.NewlyDead)
+	}
+
+	d = Compare(newReport, oldReport)
+	if len(d.Revived) != 1 || d.Revived[0] != "https://a.example/1" {
+		t.Errorf("Expected the link reported as revived, got %v", d.Revived)
+	}
+}
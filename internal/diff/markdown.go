@@ -0,0 +1,77 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderMarkdown renders the diff as a Markdown drift report
+func RenderMarkdown(d *ReportDiff) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Entropia Drift Report\n\n")
+	fmt.Fprintf(&b, "**Source:** %s\n\n", d.SourceURL)
+	fmt.Fprintf(&b, "**Compared:** %s → %s\n\n",
+		d.OldScan.Format("2006-01-02 15:04:05 UTC"), d.NewScan.Format("2006-01-02 15:04:05 UTC"))
+
+	fmt.Fprintf(&b, "## Support Index: %d → %d (%+d)\n\n", d.Index.Old, d.Index.New, d.Index.Delta)
+	if d.Index.OldConfidence != d.Index.NewConfidence {
+		fmt.Fprintf(&b, "**Confidence:** %s → %s\n\n", d.Index.OldConfidence, d.Index.NewConfidence)
+	}
+
+	if !d.HasChanges() {
+		b.WriteString("*No changes detected*\n")
+		return b.String()
+	}
+
+	writeList(&b, "Evidence Gone Dead", d.NewlyDead)
+	writeList(&b, "Evidence Revived", d.Revived)
+
+	if len(d.TierChanges) > 0 {
+		fmt.Fprintf(&b, "## Authority Tier Changes (%d)\n\n", len(d.TierChanges))
+		for _, change := range d.TierChanges {
+			fmt.Fprintf(&b, "- %s: %s → %s\n", change.URL, change.Old, change.New)
+		}
+		b.WriteString("\n")
+	}
+
+	writeSignals(&b, "Signals Appeared", d.SignalsAppeared)
+	writeSignals(&b, "Signals Cleared", d.SignalsCleared)
+	writeSignals(&b, "Signals Changed Severity", d.SignalsChanged)
+
+	writeList(&b, "Claims Added", d.ClaimsAdded)
+	writeList(&b, "Claims Removed", d.ClaimsRemoved)
+	writeList(&b, "Evidence Added", d.EvidenceAdded)
+	writeList(&b, "Evidence Removed", d.EvidenceRemoved)
+
+	return b.String()
+}
+
+func writeList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %s (%d)\n\n", title, len(items))
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+	b.WriteString("\n")
+}
+
+func writeSignals(b *strings.Builder, title string, changes []SignalChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %s (%d)\n\n", title, len(changes))
+	for _, change := range changes {
+		switch {
+		case change.OldSeverity != "" && change.NewSeverity != "":
+			fmt.Fprintf(b, "- **%s** (%s → %s): %s\n", change.Type, change.OldSeverity, change.NewSeverity, change.Description)
+		case change.NewSeverity != "":
+			fmt.Fprintf(b, "- **%s** (%s): %s\n", change.Type, change.NewSeverity, change.Description)
+		default:
+			fmt.Fprintf(b, "- **%s** (%s): %s\n", change.Type, change.OldSeverity, change.Description)
+		}
+	}
+	b.WriteString("\n")
+}
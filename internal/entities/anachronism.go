@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// relativePattern marks claims about an entity's precursors or aftermath
+// ("after the collapse of the USSR in 1995"), which legitimately date
+// events outside its lifespan
+var relativePattern = regexp.MustCompile(`(?i)\b(?:former(?:ly)?|after|since|later|before|prior to|collapse|dissolution|break-?up|fall of|successor|predecessor|post-|pre-|became)\b`)
+
+// Anachronism is a claim that dates a catalog entity to years when it did
+// not exist
+type Anachronism struct {
+	Claim  model.Claim
+	Entity Entity
+	Name   string          // Entity name found in the claim
+	Date   model.ClaimDate // The claim's date nearest that name
+}
+
+// Anachronisms cross-checks the dates of each claim against the lifespans
+// of the entities it names. A claim is anachronistic when the date written
+// nearest an entity's name falls entirely outside the years the entity
+// existed; claims that relate the entity to what came before or after it
+// are skipped. Claims must carry their dates (extract.AnnotateDates).
+func (c *Catalog) Anachronisms(claims []model.Claim) []Anachronism {
+	var found []Anachronism
+
+	for _, claim := range claims {
+		if len(claim.Dates) == 0 || relativePattern.MatchString(claim.Text) {
+			continue
+		}
+		textLower := strings.ToLower(claim.Text)
+
+		for _, entity := range c.Entities {
+			name, at := locate(textLower, entity)
+			if at < 0 {
+				continue
+			}
+
+			nearest, distance := -1, 0
+			for i, date := range claim.Dates {
+				idx := strings.Index(textLower, strings.ToLower(date.Text))
+				if idx < 0 {
+					continue
+				}
+				if d := max(idx-at, at-idx); nearest < 0 || d < distance {
+					nearest, distance = i, d
+				}
+			}
+			if nearest < 0 {
+				continue
+			}
+
+			date := claim.Dates[nearest]
+			if date.From > entity.EndYear || (entity.StartYear != 0 && date.To < entity.StartYear) {
+				found = append(found, Anachronism{Claim: claim, Entity: entity, Name: name, Date: date})
+			}
+		}
+	}
+
+	return found
+}
+
+// locate returns the first of the entity's names found in textLower and
+// its offset, or -1
+func locate(textLower string, entity Entity) (string, int) {
+	for _, name := range entity.AllNames() {
+		if idx := strings.Index(textLower, strings.ToLower(name)); idx >= 0 {
+			return name, idx
+		}
+	}
+	return "", -1
+}
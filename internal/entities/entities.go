@@ -0,0 +1,199 @@
+// Package entities holds the catalog of historical entities (states and
+// polities that no longer exist) used to flag anachronistic origin claims.
+package entities
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// contextRadius is the number of bytes of text kept on each side of a match
+const contextRadius = 50
+
+//go:embed catalog.yaml
+var bundledCatalog []byte
+
+// Entity is a state or polity that no longer exists
+type Entity struct {
+	Name        string              `json:"name" yaml:"name"`                                   // Primary (English) name
+	Aliases     []string            `json:"aliases,omitempty" yaml:"aliases,omitempty"`         // Alternative names and spellings
+	Names       map[string][]string `json:"names,omitempty" yaml:"names,omitempty"`             // Names by language code (ru, uk, de, ...)
+	StartYear   int                 `json:"start_year,omitempty" yaml:"start_year,omitempty"`   // Year it came into existence (0 = unknown)
+	EndYear     int                 `json:"end_year" yaml:"end_year"`                           // Year it ceased to exist
+	Successors  []string            `json:"successors,omitempty" yaml:"successors,omitempty"`   // States that took over its territory
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"` // Brief description
+}
+
+// Catalog is a list of historical entities, loaded from a YAML or JSON file
+type Catalog struct {
+	Name     string   `json:"-" yaml:"-"` // Source of the catalog ("builtin" or a file path)
+	Entities []Entity `json:"entities" yaml:"entities"`
+}
+
+// Match is an entity whose names appear in a text
+type Match struct {
+	Entity  Entity
+	Names   []string // Names found in the text
+	Context []string // Text surrounding the first occurrence of each name
+}
+
+// DefaultCatalogName is the name of the bundled catalog
+const DefaultCatalogName = "builtin"
+
+// Default returns the bundled catalog
+func Default() *Catalog {
+	catalog, err := parse(bundledCatalog, false)
+	if err != nil {
+		panic(fmt.Sprintf("bundled entity catalog: %v", err))
+	}
+	catalog.Name = DefaultCatalogName
+	return catalog
+}
+
+// Load reads a catalog file: JSON when the extension is .json, YAML otherwise
+func Load(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read entity catalog: %w", err)
+	}
+
+	catalog, err := parse(data, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid entity catalog %s: %w", path, err)
+	}
+	catalog.Name = path
+	return catalog, nil
+}
+
+func parse(data []byte, isJSON bool) (*Catalog, error) {
+	var catalog Catalog
+	if isJSON {
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("parse JSON: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("parse YAML: %w", err)
+	}
+	if err := catalog.Validate(); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// Validate checks that every entity has a name and a consistent lifespan.
+// All problems are reported at once.
+func (c *Catalog) Validate() error {
+	var errs []error
+	if len(c.Entities) == 0 {
+		errs = append(errs, errors.New("catalog has no entities"))
+	}
+
+	seen := make(map[string]bool)
+	for i, entity := range c.Entities {
+		name := strings.TrimSpace(entity.Name)
+		if name == "" {
+			errs = append(errs, fmt.Errorf("entity %d: name is required", i+1))
+			continue
+		}
+		if seen[strings.ToLower(name)] {
+			errs = append(errs, fmt.Errorf("entity %q: listed more than once", name))
+		}
+		seen[strings.ToLower(name)] = true
+		if entity.EndYear == 0 {
+			errs = append(errs, fmt.Errorf("entity %q: end_year is required", name))
+		}
+		if entity.StartYear != 0 && entity.StartYear > entity.EndYear {
+			errs = append(errs, fmt.Errorf("entity %q: start_year %d is after end_year %d", name, entity.StartYear, entity.EndYear))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// AllNames returns the entity's name, aliases and names in every language,
+// without duplicates (compared case-insensitively)
+func (e Entity) AllNames() []string {
+	names := append([]string{e.Name}, e.Aliases...)
+
+	langs := make([]string, 0, len(e.Names))
+	for lang := range e.Names {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		names = append(names, e.Names[lang]...)
+	}
+
+	seen := make(map[string]bool)
+	unique := names[:0]
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, name)
+	}
+	return unique
+}
+
+// Lifespan formats the years the entity existed ("1922-1991", or "-1795"
+// when the start is unknown)
+func (e Entity) Lifespan() string {
+	if e.StartYear == 0 {
+		return fmt.Sprintf("-%d", e.EndYear)
+	}
+	return fmt.Sprintf("%d-%d", e.StartYear, e.EndYear)
+}
+
+// Find returns the catalog entities any of whose names appear in text,
+// matched case-insensitively, in catalog order
+func (c *Catalog) Find(text string) []Match {
+	var matches []Match
+	textLower := strings.ToLower(text)
+
+	for _, entity := range c.Entities {
+		var match Match
+		for _, name := range entity.AllNames() {
+			nameLower := strings.ToLower(name)
+			idx := strings.Index(textLower, nameLower)
+			if idx < 0 {
+				continue
+			}
+			match.Names = append(match.Names, name)
+			match.Context = append(match.Context, snippet(text, idx, len(nameLower)))
+		}
+		if len(match.Names) > 0 {
+			match.Entity = entity
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+// snippet returns the text around [idx, idx+n), trimmed to rune boundaries
+// (lowercasing may shift offsets in non-ASCII text)
+func snippet(text string, idx, n int) string {
+	start := max(idx-contextRadius, 0)
+	end := min(idx+n+contextRadius, len(text))
+	if start > end {
+		start = end
+	}
+	for start < end && !utf8.RuneStart(text[start]) {
+		start++
+	}
+	for end < len(text) && end > start && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return strings.TrimSpace(text[start:end])
+}
@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+func writeCatalogFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDefault(t *testing.T) {
+	catalog := Default()
+	if catalog.Name != DefaultCatalogName {
+		t.Errorf("Expected name %q, got %q", DefaultCatalogName, catalog.Name)
+	}
+	if len(catalog.Entities) < 8 {
+		t.Fatalf("Expected the bundled entities, got %d", len(catalog.Entities))
+	}
+	for _, entity := range catalog.Entities {
+		if entity.StartYear == 0 || len(entity.Successors) == 0 {
+			t.Errorf("%s: bundled entities should have a start year and successors", entity.Name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	yamlPath := writeCatalogFile(t, "entities.yaml", `
+entities:
+  - name: Kingdom of Prussia
+    aliases: [Prussia]
+    names:
+      de: [Königreich Preußen]
+    start_year: 1701
+    end_year: 1918
+    successors: [Free State of Prussia]
+`)
+	catalog, err := Load(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if catalog.Name != yamlPath || len(catalog.Entities) != 1 {
+		t.Fatalf("Unexpected catalog %+v", catalog)
+	}
+	if got := catalog.Entities[0].AllNames(); strings.Join(got, "|") != "Kingdom of Prussia|Prussia|Königreich Preußen" {
+		t.Errorf("Unexpected names %v", got)
+	}
+
+	jsonPath := writeCatalogFile(t, "entities.json", `{"entities": [{"name": "Roman Empire", "start_year": -27, "end_year": 476}]}`)
+	catalog, err = Load(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if catalog.Entities[0].Lifespan() != "-27-476" {
+		t.Errorf("Unexpected lifespan %q", catalog.Entities[0].Lifespan())
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"empty", "entities: []", "no entities"},
+		{"no name", "entities:\n  - end_year: 1900", "name is required"},
+		{"no end year", "entities:\n  - name: Prussia", "end_year is required"},
+		{"reversed", "entities:\n  - name: Prussia\n    start_year: 1918\n    end_year: 1701", "is after end_year"},
+		{"duplicate", "entities:\n  - {name: Prussia, end_year: 1918}\n  - {name: prussia, end_year: 1947}", "more than once"},
+		{"syntax", "entities: [", "parse YAML"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeCatalogFile(t, "entities.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestCatalog_Find(t *testing.T) {
+	text := `The dish originates from Kyivan Rus in the 13th century.
+	In the Soviet Union (СССР), it became a symbol of Eastern European cuisine.
+	Ukrainian sources call the state Київська Русь.`
+
+	matches := Default().Find(text)
+	byName := make(map[string]Match)
+	for _, m := range matches {
+		byName[m.Entity.Name] = m
+	}
+
+	if len(matches) != 2 {
+		t.Fatalf("Expected Kyivan Rus and the USSR, got %+v", matches)
+	}
+	if rus := byName["Kyivan Rus"]; strings.Join(rus.Names, "|") != "Kyivan Rus|Київська Русь" {
+		t.Errorf("Expected the English and Ukrainian names, got %v", rus.Names)
+	}
+	ussr := byName["USSR"]
+	if strings.Join(ussr.Names, "|") != "Soviet Union|СССР" {
+		t.Errorf("Expected the alias and Russian name, got %v", ussr.Names)
+	}
+	if len(ussr.Context) != 2 || !strings.Contains(ussr.Context[0], "In the Soviet Union") {
+		t.Errorf("Expected context around each name, got %q", ussr.Context)
+	}
+}
+
+func TestSnippet_RuneBoundaries(t *testing.T) {
+	// Lowercasing may change byte lengths, so a match offset can land inside
+	// a multi-byte rune; the snippet must never split one
+	text := strings.Repeat("Ж", 40) + "Osmanlı" + strings.Repeat("Ж", 40)
+	for i := range 10 {
+		got := snippet(text, 80+i, 7)
+		if !strings.HasPrefix(got, "Ж") || !strings.HasSuffix(got, "Ж") {
+			t.Errorf("snippet at %d split a rune: %q", 80+i, got)
+		}
+	}
+}
+
+func TestCatalog_Anachronisms(t *testing.T) {
+	claims := []model.Claim{
+		// Kyivan Rus ended in 1240
+		{Text: "The dish originates from Kyivan Rus in the 15th century.", Dates: []model.ClaimDate{{Text: "15th century", From: 1401, To: 1500}}},
+		{Text: "The dish originates from Kyivan Rus in the 12th century.", Dates: []model.ClaimDate{{Text: "12th century", From: 1101, To: 1200}}},
+		// The USSR did not exist before 1922; the nearest date belongs to it
+		{Text: "Known in Kyivan Rus in the 11th century, it reached the Soviet Union in 1887.", Dates: []model.ClaimDate{{Text: "11th century", From: 1001, To: 1100}, {Text: "1887", From: 1887, To: 1887}}},
+		// Relating an entity to its aftermath is not an anachronism
+		{Text: "After the collapse of the USSR in 1995, the recipe spread west.", Dates: []model.ClaimDate{{Text: "1995", From: 1995, To: 1995}}},
+		{Text: "The Soviet Union promoted the dish.", Dates: nil},
+	}
+
+	found := Default().Anachronisms(claims)
+	if len(found) != 2 {
+		t.Fatalf("Expected two anachronisms, got %+v", found)
+	}
+	if a := found[0]; a.Entity.Name != "Kyivan Rus" || a.Date.Text != "15th century" || a.Claim.Text != claims[0].Text {
+		t.Errorf("Expected Kyivan Rus in the 15th century, got %+v", a)
+	}
+	if a := found[1]; a.Entity.Name != "USSR" || a.Name != "Soviet Union" || a.Date.Text != "1887" {
+		t.Errorf("Expected the Soviet Union in 1887, got %+v", a)
+	}
+}
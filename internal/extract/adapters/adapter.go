@@ -3,6 +3,7 @@ package adapters
 import (
 	"strings"
 
+	"github.com/ppiankov/entropia/internal/lang"
 	"github.com/ppiankov/entropia/internal/model"
 	"golang.org/x/net/html"
 )
@@ -34,7 +35,8 @@ func NewRegistry() *Registry {
 		adapters: make([]Adapter, 0),
 	}
 
-	// Register built-in adapters
+	// Register built-in adapters (docs first: a Markdown file under /law/ is still docs)
+	registry.Register(NewDocsAdapter())
 	registry.Register(NewWikipediaAdapter())
 	registry.Register(NewLegalAdapter())
 
@@ -44,6 +46,15 @@ func NewRegistry() *Registry {
 	return registry
 }
 
+// SetLanguagePacks sets the keyword packs every adapter extracts claims with
+func (r *Registry) SetLanguagePacks(packs *lang.Packs) {
+	for _, adapter := range append([]Adapter{r.generic}, r.adapters...) {
+		if a, ok := adapter.(interface{ SetLanguagePacks(*lang.Packs) }); ok {
+			a.SetLanguagePacks(packs)
+		}
+	}
+}
+
 // Register registers a new adapter
 func (r *Registry) Register(adapter Adapter) {
 	r.adapters = append(r.adapters, adapter)
@@ -62,6 +73,31 @@ func (r *Registry) FindAdapter(url string, contentType string) Adapter {
 	return r.generic
 }
 
+// Get returns the adapter registered under the given name (including "generic")
+func (r *Registry) Get(name string) (Adapter, bool) {
+	if r.generic != nil && r.generic.Name() == name {
+		return r.generic, true
+	}
+	for _, adapter := range r.adapters {
+		if adapter.Name() == name {
+			return adapter, true
+		}
+	}
+	return nil, false
+}
+
+// Names returns the names of all registered adapters, generic last
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.adapters)+1)
+	for _, adapter := range r.adapters {
+		names = append(names, adapter.Name())
+	}
+	if r.generic != nil {
+		names = append(names, r.generic.Name())
+	}
+	return names
+}
+
 // BaseAdapter provides common functionality for adapters
 type BaseAdapter struct{}
 
@@ -0,0 +1,159 @@
+package adapters
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/extract"
+	"github.com/ppiankov/entropia/internal/lang"
+	"github.com/ppiankov/entropia/internal/markup"
+	"github.com/ppiankov/entropia/internal/model"
+	"golang.org/x/net/html"
+)
+
+// DocsAdapter extracts content from Markdown and reStructuredText docs,
+// as converted to HTML by the markup package
+type DocsAdapter struct {
+	BaseAdapter
+	packs *lang.Packs
+}
+
+// NewDocsAdapter creates a new docs adapter
+func NewDocsAdapter() *DocsAdapter {
+	return &DocsAdapter{
+		packs: lang.Default(),
+	}
+}
+
+// SetLanguagePacks sets the keyword packs claims are extracted with
+func (a *DocsAdapter) SetLanguagePacks(packs *lang.Packs) {
+	a.packs = packs
+}
+
+// Name returns the adapter name
+func (a *DocsAdapter) Name() string {
+	return "docs"
+}
+
+// CanHandle checks for a Markdown or reStructuredText source
+func (a *DocsAdapter) CanHandle(rawURL string, contentType string) bool {
+	name := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		name = parsed.Path
+	}
+	return markup.Format(contentType, name) != ""
+}
+
+// ExtractClaims runs the claim extractor over each paragraph, list item
+// and table cell (footnote text is evidence, not claims)
+func (a *DocsAdapter) ExtractClaims(doc *html.Node, rawURL string) ([]model.Claim, error) {
+	blocks := a.FindAll(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || a.inFootnotes(n) {
+			return false
+		}
+		return n.Data == "p" || n.Data == "li" || n.Data == "td"
+	})
+
+	extractor := extract.NewClaimExtractorFor(a.packs.Select(doc, rawURL))
+	var claims []model.Claim
+	for i, block := range blocks {
+		found, err := extractor.Extract(renderHTML(block))
+		if err != nil {
+			return nil, err
+		}
+		for _, claim := range found {
+			claim.Sentence = i
+			claims = append(claims, claim)
+		}
+	}
+
+	return a.dedupeClaims(claims), nil
+}
+
+// ExtractEvidence collects every link: links in footnotes are citations,
+// reference-style links are references, the rest are external links.
+// Relative links in files read from disk stay file:// URLs, so they are
+// validated as files in the repository.
+func (a *DocsAdapter) ExtractEvidence(doc *html.Node, rawURL string) ([]model.Evidence, error) {
+	baseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	links := a.FindAll(doc, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "a" && a.GetAttribute(n, "href") != ""
+	})
+
+	var evidence []model.Evidence
+	seen := make(map[string]bool)
+	for _, link := range links {
+		resolved := a.resolve(baseURL, strings.TrimSpace(a.GetAttribute(link, "href")))
+		if resolved == nil || seen[resolved.String()] {
+			continue
+		}
+		seen[resolved.String()] = true
+
+		kind := model.EvidenceKindExternalLink
+		switch {
+		case a.inFootnotes(link):
+			kind = model.EvidenceKindCitation
+		case a.GetAttribute(link, markup.RefAttr) != "":
+			kind = model.EvidenceKindReference
+		}
+
+		evidence = append(evidence, model.Evidence{
+			URL:        resolved.String(),
+			Kind:       kind,
+			Host:       resolved.Host,
+			IsSameHost: resolved.Host == baseURL.Host,
+			Text:       a.ExtractText(link),
+		})
+	}
+
+	return evidence, nil
+}
+
+// resolve resolves a link against the page URL, keeping http(s) links and,
+// for pages read from disk, file links
+func (a *DocsAdapter) resolve(base *url.URL, href string) *url.URL {
+	if strings.HasPrefix(href, "#") {
+		return nil
+	}
+	parsed, err := url.Parse(href)
+	if err != nil {
+		return nil
+	}
+	resolved := base.ResolveReference(parsed)
+	switch {
+	case resolved.Scheme == "http" || resolved.Scheme == "https":
+		return resolved
+	case resolved.Scheme == "file" && base.Scheme == "file":
+		return resolved
+	}
+	return nil
+}
+
+// inFootnotes reports whether a node is inside the footnotes section
+func (a *DocsAdapter) inFootnotes(n *html.Node) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Data == "section" && a.HasClass(p, markup.FootnoteClass) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *DocsAdapter) dedupeClaims(claims []model.Claim) []model.Claim {
+	seen := make(map[string]bool)
+	var unique []model.Claim
+
+	for _, claim := range claims {
+		key := strings.ToLower(strings.TrimSpace(claim.Text))
+		if !seen[key] && key != "" {
+			seen[key] = true
+			unique = append(unique, claim)
+		}
+	}
+
+	return unique
+}
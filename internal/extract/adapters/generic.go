@@ -1,7 +1,10 @@
 package adapters
 
 import (
+	"bytes"
+
 	"github.com/ppiankov/entropia/internal/extract"
+	"github.com/ppiankov/entropia/internal/lang"
 	"github.com/ppiankov/entropia/internal/model"
 	"golang.org/x/net/html"
 )
@@ -9,18 +12,23 @@ import (
 // GenericAdapter is the fallback adapter for unknown domains
 type GenericAdapter struct {
 	BaseAdapter
-	claimExtractor    *extract.ClaimExtractor
+	packs             *lang.Packs
 	evidenceExtractor *extract.EvidenceExtractor
 }
 
 // NewGenericAdapter creates a new generic adapter
 func NewGenericAdapter() *GenericAdapter {
 	return &GenericAdapter{
-		claimExtractor:    extract.NewClaimExtractor(),
+		packs:             lang.Default(),
 		evidenceExtractor: extract.NewEvidenceExtractor(),
 	}
 }
 
+// SetLanguagePacks sets the keyword packs claims are extracted with
+func (a *GenericAdapter) SetLanguagePacks(packs *lang.Packs) {
+	a.packs = packs
+}
+
 // Name returns the adapter name
 func (a *GenericAdapter) Name() string {
 	return "generic"
@@ -31,12 +39,12 @@ func (a *GenericAdapter) CanHandle(url string, contentType string) bool {
 	return true
 }
 
-// ExtractClaims delegates to the generic claim extractor
+// ExtractClaims delegates to the generic claim extractor for the page's language
 func (a *GenericAdapter) ExtractClaims(doc *html.Node, url string) ([]model.Claim, error) {
 	// Convert HTML node back to string for the generic extractor
 	// This is not efficient but maintains compatibility
 	htmlContent := renderHTML(doc)
-	return a.claimExtractor.Extract(htmlContent)
+	return extract.NewClaimExtractorFor(a.packs.Select(doc, url)).Extract(htmlContent)
 }
 
 // ExtractEvidence delegates to the generic evidence extractor
@@ -45,10 +53,15 @@ func (a *GenericAdapter) ExtractEvidence(doc *html.Node, url string) ([]model.Ev
 	return a.evidenceExtractor.Extract(htmlContent, url)
 }
 
-// renderHTML renders an HTML node back to string
+// renderHTML renders an HTML node back to markup so the generic
+// extractors see the same document (including anchors) they would parse
+// from the raw response
 func renderHTML(n *html.Node) string {
-	// Simple serialization - just get the text content
-	return extractAllText(n)
+	var buf bytes.Buffer
+	if err := html.Render(&buf, n); err != nil {
+		return extractAllText(n)
+	}
+	return buf.String()
 }
 
 func extractAllText(n *html.Node) string {
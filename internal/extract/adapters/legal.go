@@ -3,6 +3,8 @@ package adapters
 import (
 	"strings"
 
+	"github.com/ppiankov/entropia/internal/extract"
+	"github.com/ppiankov/entropia/internal/lang"
 	"github.com/ppiankov/entropia/internal/model"
 	"golang.org/x/net/html"
 )
@@ -10,27 +12,30 @@ import (
 // LegalAdapter extracts content from legal documents
 type LegalAdapter struct {
 	BaseAdapter
-	legalKeywords []string
-	legalDomains  map[string]bool
+	packs             *lang.Packs
+	legalDomains      map[string]bool
+	evidenceExtractor *extract.EvidenceExtractor
 }
 
 // NewLegalAdapter creates a new legal document adapter
 func NewLegalAdapter() *LegalAdapter {
 	return &LegalAdapter{
-		legalKeywords: []string{
-			"shall", "must", "is required", "is defined as",
-			"under this act", "under the law", "according to",
-			"statute", "regulation", "provision",
-		},
+		packs: lang.Default(),
 		legalDomains: map[string]bool{
 			"legislation.gov.uk": true,
 			"law.cornell.edu":    true,
 			"gov.uk":             true,
 			"justice.gov":        true,
 		},
+		evidenceExtractor: extract.NewEvidenceExtractor(),
 	}
 }
 
+// SetLanguagePacks sets the keyword packs claims are extracted with
+func (a *LegalAdapter) SetLanguagePacks(packs *lang.Packs) {
+	a.packs = packs
+}
+
 // Name returns the adapter name
 func (a *LegalAdapter) Name() string {
 	return "legal"
@@ -58,9 +63,11 @@ func (a *LegalAdapter) CanHandle(rawURL string, contentType string) bool {
 	return false
 }
 
-// ExtractClaims extracts claims from legal documents
+// ExtractClaims extracts claims from legal documents, using the keyword
+// pack for the document's language
 func (a *LegalAdapter) ExtractClaims(doc *html.Node, rawURL string) ([]model.Claim, error) {
 	var claims []model.Claim
+	pack := a.packs.Select(doc, rawURL)
 
 	// Focus on main content areas
 	mainContent := a.FindFirst(doc, func(n *html.Node) bool {
@@ -86,19 +93,16 @@ func (a *LegalAdapter) ExtractClaims(doc *html.Node, rawURL string) ([]model.Cla
 
 	for i, node := range textNodes {
 		text := a.ExtractText(node)
-		sentences := splitSentences(text)
+		sentences := pack.SplitSentences(text)
 
 		for _, sentence := range sentences {
-			lower := strings.ToLower(sentence)
-			for _, keyword := range a.legalKeywords {
-				if strings.Contains(lower, keyword) {
-					claims = append(claims, model.Claim{
-						Text:      strings.TrimSpace(sentence),
-						Heuristic: "legal:" + keyword,
-						Sentence:  i,
-					})
-					break
-				}
+			if keyword, ok := lang.Match(sentence, pack.Legal); ok {
+				claims = append(claims, model.Claim{
+					Text:      strings.TrimSpace(sentence),
+					Heuristic: "legal:" + keyword,
+					Sentence:  i,
+					Language:  pack.Language,
+				})
 			}
 		}
 	}
@@ -108,25 +112,11 @@ func (a *LegalAdapter) ExtractClaims(doc *html.Node, rawURL string) ([]model.Cla
 
 // ExtractEvidence extracts evidence from legal documents
 func (a *LegalAdapter) ExtractEvidence(doc *html.Node, rawURL string) ([]model.Evidence, error) {
-	// Legal documents typically reference other laws and statutes
-	// For now, use generic evidence extraction
-	// This can be enhanced later with specific legal citation parsing
-
-	var evidence []model.Evidence
-
-	// Find all links
-	links := a.FindAll(doc, func(n *html.Node) bool {
-		return n.Type == html.ElementNode && n.Data == "a" && a.GetAttribute(n, "href") != ""
-	})
-
-	for _, link := range links {
-		_ = a.GetAttribute(link, "href")
-		// NOTE: Evidence extraction for legal documents is planned for v0.2.0
-		// Legal citations require specialized parsing (e.g., Bluebook format, statutory references)
-		// For now, this adapter focuses on claim extraction from legal text
-	}
-
-	return evidence, nil
+	// Legal documents typically reference other laws and statutes.
+	// Legal citations require specialized parsing (e.g., Bluebook format,
+	// statutory references); until then, use generic link extraction so
+	// legal pages still contribute evidence.
+	return a.evidenceExtractor.Extract(renderHTML(doc), rawURL)
 }
 
 func (a *LegalAdapter) dedupeClaims(claims []model.Claim) []model.Claim {
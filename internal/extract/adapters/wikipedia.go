@@ -2,9 +2,13 @@ package adapters
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/ppiankov/entropia/internal/entities"
+	"github.com/ppiankov/entropia/internal/lang"
 	"github.com/ppiankov/entropia/internal/model"
 	"golang.org/x/net/html"
 )
@@ -12,20 +16,21 @@ import (
 // WikipediaAdapter extracts content specifically from Wikipedia pages
 type WikipediaAdapter struct {
 	BaseAdapter
-	claimKeywords []string
+	packs *lang.Packs
 }
 
 // NewWikipediaAdapter creates a new Wikipedia adapter
 func NewWikipediaAdapter() *WikipediaAdapter {
 	return &WikipediaAdapter{
-		claimKeywords: []string{
-			"originated", "origin", "first", "introduced", "invented",
-			"according to", "is defined as", "established", "founded",
-			"created", "discovered", "developed",
-		},
+		packs: lang.Default(),
 	}
 }
 
+// SetLanguagePacks sets the keyword packs claims are extracted with
+func (a *WikipediaAdapter) SetLanguagePacks(packs *lang.Packs) {
+	a.packs = packs
+}
+
 // Name returns the adapter name
 func (a *WikipediaAdapter) Name() string {
 	return "wikipedia"
@@ -36,9 +41,11 @@ func (a *WikipediaAdapter) CanHandle(rawURL string, contentType string) bool {
 	return strings.Contains(rawURL, "wikipedia.org")
 }
 
-// ExtractClaims extracts claims from Wikipedia with focus on lead section
+// ExtractClaims extracts claims from Wikipedia with focus on lead section,
+// using the keyword pack for the article's language
 func (a *WikipediaAdapter) ExtractClaims(doc *html.Node, rawURL string) ([]model.Claim, error) {
 	var claims []model.Claim
+	pack := a.packs.Select(doc, rawURL)
 
 	// Find the main content area
 	content := a.FindFirst(doc, func(n *html.Node) bool {
@@ -51,22 +58,17 @@ func (a *WikipediaAdapter) ExtractClaims(doc *html.Node, rawURL string) ([]model
 	}
 
 	// Extract from lead section (before first h2)
-	leadSection := a.extractLeadSection(content)
-	if leadSection != nil {
-		claims = append(claims, a.extractClaimsFromSection(leadSection, "lead")...)
-	}
+	claims = append(claims, a.extractClaimsFromSection(a.extractLeadSection(content), pack)...)
 
 	// Extract from specific sections of interest
 	sections := a.FindAll(content, func(n *html.Node) bool {
 		if n.Type != html.ElementNode {
 			return false
 		}
-		// Look for h2/h3 headers with specific keywords
+		// Look for h2/h3 headers with the pack's section keywords
 		if n.Data == "h2" || n.Data == "h3" {
-			text := strings.ToLower(a.ExtractText(n))
-			return strings.Contains(text, "origin") ||
-				strings.Contains(text, "history") ||
-				strings.Contains(text, "etymology")
+			_, ok := lang.Match(a.ExtractText(n), pack.Sections)
+			return ok
 		}
 		return false
 	})
@@ -74,7 +76,7 @@ func (a *WikipediaAdapter) ExtractClaims(doc *html.Node, rawURL string) ([]model
 	for _, section := range sections {
 		// Get content after this header until next header
 		sectionContent := a.getSectionContent(section)
-		claims = append(claims, a.extractClaimsFromSection(sectionContent, "section")...)
+		claims = append(claims, a.extractClaimsFromSection(sectionContent, pack)...)
 	}
 
 	return a.dedupeClaims(claims), nil
@@ -85,53 +87,9 @@ func (a *WikipediaAdapter) ExtractEvidence(doc *html.Node, rawURL string) ([]mod
 	baseURL, _ := url.Parse(rawURL)
 	var evidence []model.Evidence
 
-	// Extract citation links (class="reference")
-	citations := a.FindAll(doc, func(n *html.Node) bool {
-		return n.Type == html.ElementNode && n.Data == "a" && a.HasClass(n, "reference")
-	})
-
-	for _, citation := range citations {
-		href := a.GetAttribute(citation, "href")
-		if href != "" {
-			// Find the actual citation target
-			if strings.HasPrefix(href, "#") {
-				targetID := strings.TrimPrefix(href, "#")
-				target := a.FindFirst(doc, func(n *html.Node) bool {
-					return a.GetAttribute(n, "id") == targetID
-				})
-
-				if target != nil {
-					// Extract URLs from the citation
-					links := a.FindAll(target, func(n *html.Node) bool {
-						return n.Type == html.ElementNode && n.Data == "a" &&
-							a.GetAttribute(n, "class") == "external text"
-					})
-
-					for _, link := range links {
-						linkHref := a.GetAttribute(link, "href")
-						if linkHref != "" {
-							resolved := resolveURL(baseURL, linkHref)
-							if resolved != "" {
-								parsed, _ := url.Parse(resolved)
-								host := ""
-								if parsed != nil {
-									host = parsed.Host
-								}
-
-								evidence = append(evidence, model.Evidence{
-									URL:        resolved,
-									Kind:       model.EvidenceKindCitation,
-									Host:       host,
-									IsSameHost: false,
-									Text:       a.ExtractText(link),
-								})
-							}
-						}
-					}
-				}
-			}
-		}
-	}
+	// Extract every reference list entry and the notes citation markers point to
+	archived := make(map[string]bool)
+	evidence = append(evidence, a.extractReferences(doc, baseURL, archived)...)
 
 	// Extract external links section
 	externalLinksSection := a.FindFirst(doc, func(n *html.Node) bool {
@@ -143,10 +101,12 @@ func (a *WikipediaAdapter) ExtractEvidence(doc *html.Node, rawURL string) ([]mod
 	})
 
 	if externalLinksSection != nil {
-		sectionContent := a.getSectionContent(externalLinksSection)
-		links := a.FindAll(sectionContent, func(n *html.Node) bool {
-			return n.Type == html.ElementNode && n.Data == "a" && a.GetAttribute(n, "href") != ""
-		})
+		var links []*html.Node
+		for _, node := range a.getSectionContent(externalLinksSection) {
+			links = append(links, a.FindAll(node, func(n *html.Node) bool {
+				return n.Type == html.ElementNode && n.Data == "a" && a.GetAttribute(n, "href") != ""
+			})...)
+		}
 
 		for _, link := range links {
 			href := a.GetAttribute(link, "href")
@@ -190,8 +150,8 @@ func (a *WikipediaAdapter) ExtractEvidence(doc *html.Node, rawURL string) ([]mod
 					host = parsed.Host
 				}
 
-				// Skip internal Wikipedia links
-				if host != baseURL.Host {
+				// Skip internal Wikipedia links and snapshots attached to references
+				if host != baseURL.Host && !archived[resolved] {
 					evidence = append(evidence, model.Evidence{
 						URL:        resolved,
 						Kind:       model.EvidenceKindExternalLink,
@@ -207,10 +167,10 @@ func (a *WikipediaAdapter) ExtractEvidence(doc *html.Node, rawURL string) ([]mod
 	return a.dedupeEvidence(evidence), nil
 }
 
-// extractLeadSection extracts the lead section (before first h2)
-func (a *WikipediaAdapter) extractLeadSection(content *html.Node) *html.Node {
-	// Create a virtual node to hold lead section content
-	lead := &html.Node{Type: html.ElementNode, Data: "div"}
+// extractLeadSection collects the paragraphs of the lead section (before first h2).
+// Nodes are collected rather than re-parented so the document tree stays intact.
+func (a *WikipediaAdapter) extractLeadSection(content *html.Node) []*html.Node {
+	var lead []*html.Node
 
 	var inLead = true
 	var walk func(*html.Node)
@@ -231,9 +191,10 @@ func (a *WikipediaAdapter) extractLeadSection(content *html.Node) *html.Node {
 			return
 		}
 
-		// Add paragraph nodes to lead
+		// Collect paragraph nodes for the lead
 		if n.Type == html.ElementNode && n.Data == "p" {
-			lead.AppendChild(n)
+			lead = append(lead, n)
+			return
 		}
 
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
@@ -245,46 +206,52 @@ func (a *WikipediaAdapter) extractLeadSection(content *html.Node) *html.Node {
 	return lead
 }
 
-// getSectionContent gets content after a section header
-func (a *WikipediaAdapter) getSectionContent(header *html.Node) *html.Node {
-	section := &html.Node{Type: html.ElementNode, Data: "div"}
+// getSectionContent collects the sibling nodes after a section header
+func (a *WikipediaAdapter) getSectionContent(header *html.Node) []*html.Node {
+	var section []*html.Node
+
+	// Newer skins wrap headings in <div class="mw-heading">; walk its siblings instead
+	start := header
+	if header.Parent != nil && a.HasClass(header.Parent, "mw-heading") {
+		start = header.Parent
+	}
 
 	// Get siblings until next header of same level
-	for sibling := header.NextSibling; sibling != nil; sibling = sibling.NextSibling {
+	for sibling := start.NextSibling; sibling != nil; sibling = sibling.NextSibling {
 		if sibling.Type == html.ElementNode &&
-			(sibling.Data == "h2" || sibling.Data == "h3") {
+			(sibling.Data == "h2" || sibling.Data == "h3" || a.HasClass(sibling, "mw-heading")) {
 			break
 		}
-		section.AppendChild(sibling)
+		section = append(section, sibling)
 	}
 
 	return section
 }
 
-// extractClaimsFromSection extracts claims from a section
-func (a *WikipediaAdapter) extractClaimsFromSection(section *html.Node, sectionType string) []model.Claim {
+// extractClaimsFromSection extracts claims from the nodes of a section
+func (a *WikipediaAdapter) extractClaimsFromSection(section []*html.Node, pack *lang.Pack) []model.Claim {
 	var claims []model.Claim
 
 	// Extract text from paragraphs
-	paragraphs := a.FindAll(section, func(n *html.Node) bool {
-		return n.Type == html.ElementNode && n.Data == "p"
-	})
+	var paragraphs []*html.Node
+	for _, node := range section {
+		paragraphs = append(paragraphs, a.FindAll(node, func(n *html.Node) bool {
+			return n.Type == html.ElementNode && n.Data == "p"
+		})...)
+	}
 
 	for i, p := range paragraphs {
 		text := a.ExtractText(p)
-		sentences := splitSentences(text)
+		sentences := pack.SplitSentences(text)
 
 		for _, sentence := range sentences {
-			lower := strings.ToLower(sentence)
-			for _, keyword := range a.claimKeywords {
-				if strings.Contains(lower, keyword) {
-					claims = append(claims, model.Claim{
-						Text:      strings.TrimSpace(sentence),
-						Heuristic: "wikipedia:" + keyword,
-						Sentence:  i,
-					})
-					break
-				}
+			if keyword, ok := lang.Match(sentence, pack.Wikipedia); ok {
+				claims = append(claims, model.Claim{
+					Text:      strings.TrimSpace(sentence),
+					Heuristic: "wikipedia:" + keyword,
+					Sentence:  i,
+					Language:  pack.Language,
+				})
 			}
 		}
 	}
@@ -294,28 +261,6 @@ func (a *WikipediaAdapter) extractClaimsFromSection(section *html.Node, sectionT
 
 // Helper functions
 
-func splitSentences(text string) []string {
-	text = strings.ReplaceAll(text, "\n", " ")
-	var sentences []string
-	var current strings.Builder
-
-	for i, r := range text {
-		current.WriteRune(r)
-
-		if r == '.' || r == '!' || r == '?' {
-			if i+1 < len(text) && (text[i+1] == ' ' || text[i+1] == '\t') {
-				sentence := strings.TrimSpace(current.String())
-				if len(sentence) >= 30 && len(sentence) <= 500 {
-					sentences = append(sentences, sentence)
-				}
-				current.Reset()
-			}
-		}
-	}
-
-	return sentences
-}
-
 func resolveURL(base *url.URL, href string) string {
 	if strings.HasPrefix(href, "#") || strings.HasPrefix(href, "javascript:") || strings.HasPrefix(href, "mailto:") {
 		return ""
@@ -364,13 +309,14 @@ func (a *WikipediaAdapter) dedupeEvidence(evidence []model.Evidence) []model.Evi
 }
 
 // DetectWikipediaConflicts checks for Wikipedia-specific conflict indicators
-// Returns signals for edit wars and historical entity anachronisms
-// Takes both the HTML content string and parsed document
-func (a *WikipediaAdapter) DetectWikipediaConflicts(ctx context.Context, rawURL string, htmlContent string, doc *html.Node) []model.Signal {
+// Returns signals for edit wars, historical entity anachronisms and cleanup tags
+// Takes both the HTML content string and parsed document, the revision
+// history settings for edit war analysis and the historical entity catalog
+func (a *WikipediaAdapter) DetectWikipediaConflicts(ctx context.Context, rawURL string, htmlContent string, doc *html.Node, history model.WikipediaConfig, catalog *entities.Catalog) []model.Signal {
 	var signals []model.Signal
 
 	// 1. Check for edit wars (high edit frequency / reverts)
-	editWar, err := DetectEditWar(ctx, rawURL)
+	editWar, err := DetectEditWar(ctx, rawURL, history)
 	if err == nil && editWar.IsHighConflict {
 		severity := model.SeverityWarning
 		if editWar.ConflictSeverity == "high" {
@@ -384,8 +330,13 @@ func (a *WikipediaAdapter) DetectWikipediaConflicts(ctx context.Context, rawURL
 			Data: map[string]interface{}{
 				"recent_edits":      editWar.RecentEdits,
 				"revert_count":      editWar.RevertCount,
+				"reverted_edits":    editWar.RevertedEdits,
 				"unique_editors":    editWar.UniqueEditors,
 				"edit_frequency":    editWar.EditFrequency,
+				"window_days":       editWar.WindowDays,
+				"protection":        editWar.Protection,
+				"talk_edits":        editWar.TalkEdits,
+				"talk_editors":      editWar.TalkEditors,
 				"conflict_severity": editWar.ConflictSeverity,
 				"last_edit":         editWar.LastEditTime.Format("2006-01-02"),
 				"explanation":       "High edit frequency and reverts suggest this content is disputed",
@@ -394,25 +345,28 @@ func (a *WikipediaAdapter) DetectWikipediaConflicts(ctx context.Context, rawURL
 	}
 
 	// 2. Check for historical entity anachronisms
-	// Search in the raw HTML content for historical entity names
-	historicalConflicts := DetectHistoricalEntities(htmlContent)
-
-	for _, hc := range historicalConflicts {
+	// Search in the raw HTML content for the catalog's entity names
+	currentYear := time.Now().Year()
+	for _, match := range catalog.Find(htmlContent) {
 		// Only flag if entity has been gone for >30 years (avoids recent political entities)
-		yearsSinceEnd := 2026 - hc.Entity.EndYear
+		yearsSinceEnd := currentYear - match.Entity.EndYear
 		if yearsSinceEnd > 30 {
 			signalData := map[string]interface{}{
-				"entity":        hc.Entity.Name,
-				"ended":         hc.Entity.EndYear,
+				"entity":        match.Entity.Name,
+				"started":       match.Entity.StartYear,
+				"ended":         match.Entity.EndYear,
 				"years_ago":     yearsSinceEnd,
-				"occurrences":   hc.Occurrences,
-				"description":   hc.Entity.Description,
+				"occurrences":   len(match.Names),
+				"matched_names": match.Names,
+				"successors":    match.Entity.Successors,
+				"description":   match.Entity.Description,
+				"catalog":       catalog.Name,
 				"explanation":   "Attributing origins to historical entities that no longer exist may indicate contested modern identity claims",
 			}
 
 			// Add example context if available
-			if len(hc.Context) > 0 {
-				signalData["example"] = hc.Context[0]
+			if len(match.Context) > 0 {
+				signalData["example"] = match.Context[0]
 			}
 
 			signals = append(signals, model.Signal{
@@ -424,5 +378,25 @@ func (a *WikipediaAdapter) DetectWikipediaConflicts(ctx context.Context, rawURL
 		}
 	}
 
+	// 3. Count inline cleanup tags editors left on unsupported or disputed statements
+	if counts := a.CountCleanupTags(doc); len(counts) > 0 {
+		total := 0
+		for _, n := range counts {
+			total += n
+		}
+		signals = append(signals, model.Signal{
+			Type:        model.SignalCleanupTags,
+			Severity:    model.SeverityWarning,
+			Description: fmt.Sprintf("Cleanup tags: %d statements tagged by editors (%d citation needed, %d dubious, %d unreliable source)", total, counts[TagCitationNeeded], counts[TagDubious], counts[TagUnreliableSource]),
+			Data: map[string]interface{}{
+				TagCitationNeeded:   counts[TagCitationNeeded],
+				TagDubious:          counts[TagDubious],
+				TagUnreliableSource: counts[TagUnreliableSource],
+				"total":             total,
+				"explanation":       "Editors flagged these statements as lacking a source, disputed, or resting on an unreliable source",
+			},
+		})
+	}
+
 	return signals
 }
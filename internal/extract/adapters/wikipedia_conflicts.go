@@ -7,69 +7,12 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
-)
-
-// HistoricalEntity represents a state/entity that no longer exists
-type HistoricalEntity struct {
-	Name        string   // Primary name
-	Aliases     []string // Alternative names/spellings
-	EndYear     int      // Year it ceased to exist
-	Description string   // Brief description
-}
 
-// Common historical entities that appear in modern origin disputes
-var historicalEntities = []HistoricalEntity{
-	{
-		Name:        "Kyivan Rus",
-		Aliases:     []string{"Киевская Русь", "Kievan Rus", "Kievan Rus'", "Kiev Rus"},
-		EndYear:     1240,
-		Description: "Medieval East Slavic state (9th-13th century)",
-	},
-	{
-		Name:        "USSR",
-		Aliases:     []string{"Soviet Union", "СССР", "Советский Союз", "CCCP"},
-		EndYear:     1991,
-		Description: "Soviet Union (1922-1991)",
-	},
-	{
-		Name:        "Yugoslavia",
-		Aliases:     []string{"Jugoslavija", "Југославија", "SFRY", "SFR Yugoslavia"},
-		EndYear:     1992,
-		Description: "Socialist Federal Republic of Yugoslavia (1945-1992)",
-	},
-	{
-		Name:        "Czechoslovakia",
-		Aliases:     []string{"Československo", "ČSSR"},
-		EndYear:     1993,
-		Description: "Czechoslovakia (1918-1993)",
-	},
-	{
-		Name:        "Ottoman Empire",
-		Aliases:     []string{"Osmanlı", "Османская империя"},
-		EndYear:     1922,
-		Description: "Ottoman Empire (1299-1922)",
-	},
-	{
-		Name:        "Austria-Hungary",
-		Aliases:     []string{"Austro-Hungarian Empire", "Österreich-Ungarn"},
-		EndYear:     1918,
-		Description: "Austria-Hungary (1867-1918)",
-	},
-	{
-		Name:        "Polish-Lithuanian Commonwealth",
-		Aliases:     []string{"Commonwealth", "Rzeczpospolita Obojga Narodów"},
-		EndYear:     1795,
-		Description: "Polish-Lithuanian Commonwealth (1569-1795)",
-	},
-	{
-		Name:        "Grand Duchy of Lithuania",
-		Aliases:     []string{"Lietuvos Didžioji Kunigaikštystė"},
-		EndYear:     1795,
-		Description: "Grand Duchy of Lithuania (1236-1795)",
-	},
-}
+	"github.com/ppiankov/entropia/internal/model"
+)
 
 // WikipediaRevision represents a page revision
 type WikipediaRevision struct {
@@ -78,37 +21,57 @@ type WikipediaRevision struct {
 	User      string `json:"user"`
 	Comment   string `json:"comment"`
 	Size      int    `json:"size"`
+	SHA1      string `json:"sha1"` // Content hash; identical hashes mean identical page text
+}
+
+// WikipediaProtection is one protection applied to a page
+type WikipediaProtection struct {
+	Type   string `json:"type"`   // edit, move, upload
+	Level  string `json:"level"`  // autoconfirmed, extendedconfirmed, sysop
+	Expiry string `json:"expiry"` // "infinity" or an ISO 8601 time
 }
 
 // WikipediaRevisionsResponse represents the API response
 type WikipediaRevisionsResponse struct {
+	Continue struct {
+		RvContinue string `json:"rvcontinue"`
+	} `json:"continue"`
 	Query struct {
 		Pages map[string]struct {
-			Revisions []WikipediaRevision `json:"revisions"`
+			Revisions  []WikipediaRevision   `json:"revisions"`
+			Protection []WikipediaProtection `json:"protection"`
 		} `json:"pages"`
 	} `json:"query"`
 }
 
 // EditWarIndicators contains metrics for detecting edit wars
 type EditWarIndicators struct {
-	RecentEdits      int       // Edits in last 30 days
-	RevertCount      int       // Number of reverts detected
+	RecentEdits      int       // Edits in the analyzed window
+	RevertCount      int       // Revisions restoring an earlier revision's exact content
+	RevertedEdits    int       // Revisions undone by those reverts
 	UniqueEditors    int       // Number of different editors
 	EditFrequency    float64   // Edits per day
+	WindowDays       float64   // Days of history analyzed
+	Protection       string    // Edit protection level (autoconfirmed, extendedconfirmed, sysop); "" if unprotected
+	ProtectionExpiry string    // When the edit protection ends ("infinity" if never)
+	TalkEdits        int       // Talk page edits in the window
+	TalkEditors      int       // Different talk page editors in the window
 	IsHighConflict   bool      // Overall assessment
 	LastEditTime     time.Time // Most recent edit
 	ConflictSeverity string    // low, medium, high
 }
 
-// HistoricalEntityConflict represents detected historical entity usage
-type HistoricalEntityConflict struct {
-	Entity      HistoricalEntity
-	Occurrences int
-	Context     []string // Surrounding text snippets
+// wikipediaAPI queries a MediaWiki action API endpoint
+type wikipediaAPI struct {
+	endpoint string
+	client   *http.Client
 }
 
-// DetectEditWar checks Wikipedia revision history for edit war patterns
-func DetectEditWar(ctx context.Context, pageURL string) (*EditWarIndicators, error) {
+// DetectEditWar checks the revision history of a Wikipedia page within
+// cfg.EditWindow for edit war patterns. Reverts are revisions whose content
+// hash matches an earlier revision's; page protection and talk page activity
+// are supporting indicators.
+func DetectEditWar(ctx context.Context, pageURL string, cfg model.WikipediaConfig) (*EditWarIndicators, error) {
 	// Extract page title from URL
 	title, err := extractWikipediaTitle(pageURL)
 	if err != nil {
@@ -121,156 +84,196 @@ func DetectEditWar(ctx context.Context, pageURL string) (*EditWarIndicators, err
 		decodedTitle = title // Use as-is if decode fails
 	}
 
-	// Construct API URL for revision history (last 30 days, max 100 revisions)
-	lang := extractWikipediaLang(pageURL)
-	apiURL := fmt.Sprintf("https://%s.wikipedia.org/w/api.php?action=query&titles=%s&prop=revisions&rvlimit=100&rvprop=timestamp|user|comment|size&format=json",
-		lang, url.QueryEscape(decodedTitle))
+	api := wikipediaAPI{
+		endpoint: strings.ReplaceAll(cfg.APIURL, "{lang}", extractWikipediaLang(pageURL)),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+	now := time.Now()
+	since := now.Add(-cfg.EditWindow)
 
-	// Make HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	revisions, err := api.revisions(ctx, decodedTitle, since, cfg.MaxRevisions, "ids|timestamp|user|sha1")
 	if err != nil {
 		return nil, err
 	}
 
+	// History cut short by max_revisions covers less than the window
+	span := cfg.EditWindow
+	if cfg.MaxRevisions > 0 && len(revisions) >= cfg.MaxRevisions {
+		if oldest, err := time.Parse(time.RFC3339, revisions[len(revisions)-1].Timestamp); err == nil {
+			span = now.Sub(oldest)
+		}
+	}
+	indicators := analyzeRevisions(revisions, span)
+
+	// Supporting indicators; the analysis stands without them
+	if protection, err := api.protection(ctx, decodedTitle); err == nil {
+		for _, p := range protection {
+			if p.Type == "edit" {
+				indicators.Protection = p.Level
+				indicators.ProtectionExpiry = p.Expiry
+			}
+		}
+	}
+	if talk, err := api.revisions(ctx, "Talk:"+decodedTitle, since, cfg.MaxRevisions, "timestamp|user"); err == nil {
+		editors := make(map[string]bool)
+		for _, rev := range talk {
+			editors[rev.User] = true
+		}
+		indicators.TalkEdits = len(talk)
+		indicators.TalkEditors = len(editors)
+	}
+
+	assessConflict(indicators)
+	return indicators, nil
+}
+
+// revisions pages through a page's revisions newer than since, newest
+// first, stopping after limit revisions (0 = no limit)
+func (api wikipediaAPI) revisions(ctx context.Context, title string, since time.Time, limit int, props string) ([]WikipediaRevision, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("prop", "revisions")
+	params.Set("titles", title)
+	params.Set("rvprop", props)
+	params.Set("rvend", since.UTC().Format(time.RFC3339))
+
+	var revisions []WikipediaRevision
+	for {
+		batch := 500 // API maximum for regular clients
+		if limit > 0 {
+			batch = min(batch, limit-len(revisions))
+		}
+		params.Set("rvlimit", strconv.Itoa(batch))
+
+		var apiResp WikipediaRevisionsResponse
+		if err := api.get(ctx, params, &apiResp); err != nil {
+			return nil, err
+		}
+		for _, page := range apiResp.Query.Pages {
+			revisions = append(revisions, page.Revisions...)
+			break // Only one page expected
+		}
+
+		if apiResp.Continue.RvContinue == "" || (limit > 0 && len(revisions) >= limit) {
+			return revisions, nil
+		}
+		params.Set("rvcontinue", apiResp.Continue.RvContinue)
+	}
+}
+
+// protection returns the protections applied to a page
+func (api wikipediaAPI) protection(ctx context.Context, title string) ([]WikipediaProtection, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("prop", "info")
+	params.Set("inprop", "protection")
+	params.Set("titles", title)
+
+	var apiResp WikipediaRevisionsResponse
+	if err := api.get(ctx, params, &apiResp); err != nil {
+		return nil, err
+	}
+	for _, page := range apiResp.Query.Pages {
+		return page.Protection, nil
+	}
+	return nil, nil
+}
+
+// get runs an API query and decodes its JSON response into out
+func (api wikipediaAPI) get(ctx context.Context, params url.Values, out interface{}) error {
+	params.Set("format", "json")
+	req, err := http.NewRequestWithContext(ctx, "GET", api.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
 	// Set User-Agent (Wikipedia API requires it)
 	req.Header.Set("User-Agent", "Entropia/0.1 (+https://github.com/ppiankov/entropia)")
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := api.client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	// Check if response is actually JSON
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("wikipedia API returned status %d", resp.StatusCode)
+		return fmt.Errorf("wikipedia API returned status %d", resp.StatusCode)
 	}
 
-	var apiResp WikipediaRevisionsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode Wikipedia API response: %w", err)
-	}
-
-	// Extract revisions from response
-	var revisions []WikipediaRevision
-	for _, page := range apiResp.Query.Pages {
-		revisions = page.Revisions
-		break // Only one page expected
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Wikipedia API response: %w", err)
 	}
-
-	if len(revisions) == 0 {
-		return &EditWarIndicators{}, nil
-	}
-
-	// Analyze revisions
-	indicators := analyzeRevisions(revisions)
-	return indicators, nil
+	return nil
 }
 
-// analyzeRevisions processes revision data to detect edit war patterns
-func analyzeRevisions(revisions []WikipediaRevision) *EditWarIndicators {
-	now := time.Now()
-	thirtyDaysAgo := now.Add(-30 * 24 * time.Hour)
-
-	indicators := &EditWarIndicators{}
+// analyzeRevisions counts edits, editors and reverts in revisions (newest
+// first, as the API lists them) covering span. A revert is a revision whose
+// content hash matches a revision before its immediate predecessor: the
+// edits in between were undone, whatever the edit summary says.
+func analyzeRevisions(revisions []WikipediaRevision, span time.Duration) *EditWarIndicators {
+	indicators := &EditWarIndicators{WindowDays: span.Hours() / 24}
 	editorsMap := make(map[string]bool)
-	var recentEdits []WikipediaRevision
-
-	// Count recent edits and unique editors
-	for _, rev := range revisions {
-		t, err := time.Parse(time.RFC3339, rev.Timestamp)
-		if err != nil {
-			continue
-		}
+	lastSeen := make(map[string]int) // Content hash -> latest chronological position
 
-		if t.After(thirtyDaysAgo) {
-			recentEdits = append(recentEdits, rev)
-			editorsMap[rev.User] = true
+	for i := len(revisions) - 1; i >= 0; i-- {
+		rev := revisions[i]
+		position := len(revisions) - 1 - i
 
-			if indicators.LastEditTime.IsZero() || t.After(indicators.LastEditTime) {
-				indicators.LastEditTime = t
-			}
+		if t, err := time.Parse(time.RFC3339, rev.Timestamp); err == nil && t.After(indicators.LastEditTime) {
+			indicators.LastEditTime = t
 		}
+		editorsMap[rev.User] = true
 
-		// Detect reverts in comment
-		comment := strings.ToLower(rev.Comment)
-		if strings.Contains(comment, "revert") || strings.Contains(comment, "rv ") ||
-			strings.Contains(comment, "undo") || strings.Contains(comment, "undid") {
+		// Hidden (suppressed) revisions have no hash
+		if rev.SHA1 == "" {
+			continue
+		}
+		if earlier, ok := lastSeen[rev.SHA1]; ok && earlier < position-1 {
 			indicators.RevertCount++
+			indicators.RevertedEdits += position - 1 - earlier
 		}
+		lastSeen[rev.SHA1] = position
 	}
 
-	indicators.RecentEdits = len(recentEdits)
+	indicators.RecentEdits = len(revisions)
 	indicators.UniqueEditors = len(editorsMap)
 
 	// Calculate edit frequency (edits per day)
-	if len(recentEdits) > 0 {
-		oldestEdit, _ := time.Parse(time.RFC3339, recentEdits[len(recentEdits)-1].Timestamp)
-		daysSinceOldest := now.Sub(oldestEdit).Hours() / 24
-		if daysSinceOldest > 0 {
-			indicators.EditFrequency = float64(indicators.RecentEdits) / daysSinceOldest
+	if indicators.WindowDays > 0 {
+		indicators.EditFrequency = float64(indicators.RecentEdits) / indicators.WindowDays
+	}
+
+	return indicators
+}
+
+// assessConflict sets the conflict severity from the indicators. Counts are
+// scaled to a 30-day month so thresholds hold for any window.
+//
+//	High: >10 edits/month AND >3 reverts/month, OR >5 edits/day
+//	Medium: >5 edits/month AND >1 revert/month, OR >2 edits/day, OR reverts
+//	on an edit-protected page or alongside >20 talk page edits/month
+//	Low: any revert or edit protection
+func assessConflict(indicators *EditWarIndicators) {
+	monthly := func(count int) float64 {
+		if indicators.WindowDays <= 0 {
+			return float64(count)
 		}
+		return float64(count) * 30 / indicators.WindowDays
 	}
+	edits, reverts, talk := monthly(indicators.RecentEdits), monthly(indicators.RevertCount), monthly(indicators.TalkEdits)
+	protected := indicators.Protection != ""
 
-	// Determine conflict severity
-	// High conflict: >10 edits/month AND >3 reverts, OR >5 edits/day
-	// Medium conflict: >5 edits/month AND >1 revert, OR >2 edits/day
-	if (indicators.RecentEdits > 10 && indicators.RevertCount > 3) || indicators.EditFrequency > 5 {
+	if (edits > 10 && reverts > 3) || indicators.EditFrequency > 5 {
 		indicators.IsHighConflict = true
 		indicators.ConflictSeverity = "high"
-	} else if (indicators.RecentEdits > 5 && indicators.RevertCount > 1) || indicators.EditFrequency > 2 {
+	} else if (edits > 5 && reverts > 1) || indicators.EditFrequency > 2 ||
+		(indicators.RevertCount > 0 && (protected || talk > 20)) {
 		indicators.IsHighConflict = true
 		indicators.ConflictSeverity = "medium"
-	} else if indicators.RevertCount > 0 {
+	} else if indicators.RevertCount > 0 || protected {
 		indicators.ConflictSeverity = "low"
 	}
-
-	return indicators
-}
-
-// DetectHistoricalEntities scans text for references to non-existent historical entities
-func DetectHistoricalEntities(text string) []HistoricalEntityConflict {
-	var conflicts []HistoricalEntityConflict
-	textLower := strings.ToLower(text)
-
-	for _, entity := range historicalEntities {
-		occurrences := 0
-		var contexts []string
-
-		// Check primary name and all aliases
-		names := append([]string{entity.Name}, entity.Aliases...)
-		for _, name := range names {
-			nameLower := strings.ToLower(name)
-			if strings.Contains(textLower, nameLower) {
-				occurrences++
-
-				// Extract context (50 chars before and after)
-				if idx := strings.Index(textLower, nameLower); idx >= 0 {
-					start := idx - 50
-					if start < 0 {
-						start = 0
-					}
-					end := idx + len(nameLower) + 50
-					if end > len(text) {
-						end = len(text)
-					}
-					context := text[start:end]
-					contexts = append(contexts, strings.TrimSpace(context))
-				}
-			}
-		}
-
-		if occurrences > 0 {
-			conflicts = append(conflicts, HistoricalEntityConflict{
-				Entity:      entity,
-				Occurrences: occurrences,
-				Context:     contexts,
-			})
-		}
-	}
-
-	return conflicts
 }
 
 // extractWikipediaTitle extracts the page title from a Wikipedia URL
@@ -0,0 +1,374 @@
+package adapters
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/archive"
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/util"
+	"golang.org/x/net/html"
+)
+
+// linkRotPage is the project page Wikipedia's {{dead link}} tag links to
+const linkRotPage = "Wikipedia:Link_rot"
+
+// coinsSpanClass marks the COinS span cite templates emit
+const coinsSpanClass = "Z3988"
+
+// Cleanup tag kinds counted by the cleanup_tags signal
+const (
+	TagCitationNeeded   = "citation_needed"
+	TagDubious          = "dubious"
+	TagUnreliableSource = "unreliable_source"
+)
+
+// cleanupTags maps the bracketed text of Wikipedia's inline cleanup
+// templates to the kind they are counted as
+var cleanupTags = map[string]string{
+	"citation needed":    TagCitationNeeded,
+	"dubious":            TagDubious,
+	"unreliable source?": TagUnreliableSource,
+	"unreliable source":  TagUnreliableSource,
+}
+
+// extractReferences returns the evidence cited by every entry of the
+// reference lists (<ol class="references">), then by any other footnote a
+// citation marker points to. Archive snapshots linked beside a source are
+// attached to it and added to archived rather than returned as evidence.
+func (a *WikipediaAdapter) extractReferences(doc *html.Node, baseURL *url.URL, archived map[string]bool) []model.Evidence {
+	var notes []*html.Node
+	seen := make(map[*html.Node]bool)
+
+	for _, list := range a.FindAll(doc, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "ol" && a.HasClass(n, "references")
+	}) {
+		for li := list.FirstChild; li != nil; li = li.NextSibling {
+			if li.Type == html.ElementNode && li.Data == "li" && !seen[li] {
+				seen[li] = true
+				notes = append(notes, li)
+			}
+		}
+	}
+
+	// Citation markers (class="reference" on the anchor or its <sup> wrapper)
+	// may point at notes outside a reference list
+	ids := make(map[string]*html.Node)
+	for _, n := range a.FindAll(doc, func(n *html.Node) bool { return a.GetAttribute(n, "id") != "" }) {
+		if id := a.GetAttribute(n, "id"); ids[id] == nil {
+			ids[id] = n
+		}
+	}
+	markers := a.FindAll(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || n.Data != "a" {
+			return false
+		}
+		return a.HasClass(n, "reference") || (n.Parent != nil && a.HasClass(n.Parent, "reference"))
+	})
+	for _, marker := range markers {
+		href := a.GetAttribute(marker, "href")
+		if !strings.HasPrefix(href, "#") {
+			continue
+		}
+		if target := ids[strings.TrimPrefix(href, "#")]; target != nil && !seen[target] {
+			seen[target] = true
+			notes = append(notes, target)
+		}
+	}
+
+	var evidence []model.Evidence
+	for _, note := range notes {
+		// A note may bundle several citations; each <cite> is its own work
+		works := a.FindAll(note, func(n *html.Node) bool {
+			return n.Type == html.ElementNode && n.Data == "cite"
+		})
+		if len(works) == 0 {
+			works = []*html.Node{note}
+		}
+		for _, work := range works {
+			evidence = append(evidence, a.referenceEvidence(work, note, baseURL, archived)...)
+		}
+	}
+	return evidence
+}
+
+// referenceEvidence returns the evidence for one cited work: each of its
+// external links, or the note itself (offline) when the work has none
+func (a *WikipediaAdapter) referenceEvidence(work, note *html.Node, baseURL *url.URL, archived map[string]bool) []model.Evidence {
+	var sources []model.Evidence
+	var snapshots []string
+
+	links := a.FindAll(work, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "a" && a.HasClass(n, "external")
+	})
+	for _, link := range links {
+		resolved := resolveURL(baseURL, a.GetAttribute(link, "href"))
+		if resolved == "" {
+			continue
+		}
+		if archive.IsArchiveURL(resolved) {
+			snapshots = append(snapshots, resolved)
+			continue
+		}
+		parsed, _ := url.Parse(resolved)
+		host := ""
+		if parsed != nil {
+			host = parsed.Host
+		}
+		sources = append(sources, model.Evidence{
+			URL:        resolved,
+			Kind:       model.EvidenceKindCitation,
+			Host:       host,
+			IsSameHost: false,
+			Text:       a.ExtractText(link),
+		})
+	}
+
+	text := a.referenceText(work)
+	switch {
+	case len(sources) == 0 && len(snapshots) > 0:
+		// Only the snapshot survives; it is the source
+		sources = append(sources, model.Evidence{
+			URL:  snapshots[0],
+			Kind: model.EvidenceKindCitation,
+			Host: hostOf(snapshots[0]),
+			Text: text,
+		})
+		snapshots = snapshots[1:]
+	case len(sources) == 0:
+		// A print source (book, journal issue) cited without a link
+		id := a.GetAttribute(note, "id")
+		if id == "" || text == "" {
+			return nil
+		}
+		ref := *baseURL
+		ref.Fragment = id
+		return []model.Evidence{{
+			URL:        ref.String(),
+			Kind:       model.EvidenceKindCitation,
+			Host:       baseURL.Host,
+			IsSameHost: true,
+			Text:       text,
+			Citation:   referenceCitation(text, a.italicText(work)),
+			Offline:    true,
+		}}
+	}
+
+	// Snapshots embed the URL they capture; unmatched ones belong to the
+	// work's main link
+	for _, snapshot := range snapshots {
+		archived[snapshot] = true
+		target := 0
+		if original := archive.Original(snapshot); original != "" {
+			for i := range sources {
+				if strings.TrimSuffix(sources[i].URL, "/") == strings.TrimSuffix(original, "/") {
+					target = i
+					break
+				}
+			}
+		}
+		if sources[target].ArchiveURL == "" {
+			sources[target].ArchiveURL = snapshot
+		}
+	}
+
+	dead := a.deadLinkTagged(work)
+	for i := range sources {
+		sources[i].Citation = referenceCitation(text, a.italicText(work))
+		sources[i].MarkedDead = dead
+	}
+	return sources
+}
+
+// deadLinkTagged reports whether editors tagged a cited work's link with
+// {{dead link}}, which follows the <cite> inside its note
+func (a *WikipediaAdapter) deadLinkTagged(work *html.Node) bool {
+	nodes := []*html.Node{work}
+	if work.Data == "cite" {
+		for sibling := work.NextSibling; sibling != nil; sibling = sibling.NextSibling {
+			if sibling.Type == html.ElementNode && a.FindFirst(sibling, func(n *html.Node) bool {
+				return n.Type == html.ElementNode && n.Data == "cite"
+			}) != nil {
+				break
+			}
+			nodes = append(nodes, sibling)
+		}
+	}
+
+	for _, node := range nodes {
+		tag := a.FindFirst(node, func(n *html.Node) bool {
+			if n.Type != html.ElementNode {
+				return false
+			}
+			if n.Data == "a" && strings.Contains(a.GetAttribute(n, "href"), linkRotPage) {
+				return true
+			}
+			return n.Data == "sup" && strings.HasSuffix(bracketedText(a.ExtractText(n)), "dead link")
+		})
+		if tag != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// CountCleanupTags counts the inline cleanup tags ([citation needed],
+// [dubious], [unreliable source?]) editors placed in the page, by kind
+func (a *WikipediaAdapter) CountCleanupTags(doc *html.Node) map[string]int {
+	counts := make(map[string]int)
+	for _, sup := range a.FindAll(doc, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "sup"
+	}) {
+		if kind, ok := cleanupTags[bracketedText(a.ExtractText(sup))]; ok {
+			counts[kind]++
+		}
+	}
+	return counts
+}
+
+// referenceText returns the visible text of a cited work, without the
+// backlinks, inline tags and template styles Wikipedia renders beside it
+func (a *WikipediaAdapter) referenceText(n *html.Node) string {
+	var text strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			text.WriteString(n.Data)
+			return
+		}
+		if n.Type == html.ElementNode {
+			switch {
+			case n.Data == "style" || n.Data == "script" || n.Data == "sup":
+				return
+			case a.HasClass(n, "mw-cite-backlink") || a.HasClass(n, coinsSpanClass):
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+// italicText returns the text of the first <i> in a cited work: the title
+// of a book or the name of a periodical
+func (a *WikipediaAdapter) italicText(work *html.Node) string {
+	italic := a.FindFirst(work, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "i"
+	})
+	if italic == nil {
+		return ""
+	}
+	return a.referenceText(italic)
+}
+
+// referenceCitation reads the title, publication date and access date from
+// the text of a cited work. Citation Style 1 quotes the title of an article
+// or web page and italicizes that of a book, so a quoted title wins.
+func referenceCitation(text, italic string) *model.Citation {
+	citation := &model.Citation{Sources: []model.CitationSource{model.CitationSourceReference}}
+
+	quoted := quotedTitle(text)
+	citation.Title = quoted
+	if citation.Title == "" {
+		citation.Title = italic
+	}
+
+	// Dates after these markers are when the link was archived or read
+	published := text
+	for _, marker := range []string{"Archived", "Retrieved"} {
+		if i := strings.Index(published, marker); i >= 0 {
+			published = published[:i]
+		}
+	}
+	for _, title := range []string{quoted, italic} {
+		if title != "" {
+			published = strings.Replace(published, title, "", 1)
+		}
+	}
+	citation.PublishedAt = referenceDate(published)
+
+	if _, after, ok := strings.Cut(text, "Retrieved"); ok {
+		if before, _, ok := strings.Cut(after, ". "); ok {
+			after = before
+		}
+		if t, ok := util.ParseDate(strings.Trim(after, " .,;")); ok {
+			citation.AccessedAt = &t
+		}
+	}
+
+	if citation.Title == "" && citation.PublishedAt == nil && citation.AccessedAt == nil {
+		return nil
+	}
+	return citation
+}
+
+// quotedTitle returns the first "..." or “...” span of text
+func quotedTitle(text string) string {
+	for _, quotes := range [][2]string{{`"`, `"`}, {"“", "”"}} {
+		start := strings.Index(text, quotes[0])
+		if start < 0 {
+			continue
+		}
+		rest := text[start+len(quotes[0]):]
+		if end := strings.Index(rest, quotes[1]); end > 0 {
+			return strings.TrimSpace(strings.TrimRight(rest[:end], ".,"))
+		}
+	}
+	return ""
+}
+
+// referenceDate returns the first plausible publication date in the text
+// of a cited work: a parenthesized date after the authors, else a date
+// between periods or commas ("Publisher. 12 May 2019.")
+func referenceDate(text string) *time.Time {
+	plausible := func(value string) *time.Time {
+		t, ok := util.ParseDate(strings.Trim(value, " .,;:()"))
+		if !ok || t.Year() < 1000 || t.After(time.Now()) {
+			return nil
+		}
+		return &t
+	}
+
+	for rest := text; ; {
+		open := strings.Index(rest, "(")
+		if open < 0 {
+			break
+		}
+		end := strings.Index(rest[open:], ")")
+		if end < 0 {
+			break
+		}
+		if t := plausible(rest[open+1 : open+end]); t != nil {
+			return t
+		}
+		rest = rest[open+end:]
+	}
+
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == '.' || r == ';' }) {
+		if t := plausible(part); t != nil {
+			return t
+		}
+		for _, field := range strings.Split(part, ",") {
+			if t := plausible(field); t != nil {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+// bracketedText normalizes the text of an inline tag ("[ citation needed ]")
+func bracketedText(text string) string {
+	return strings.ToLower(strings.Trim(strings.Join(strings.Fields(text), " "), "[] "))
+}
+
+func hostOf(rawURL string) string {
+	if parsed, err := url.Parse(rawURL); err == nil {
+		return parsed.Host
+	}
+	return ""
+}
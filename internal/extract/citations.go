@@ -0,0 +1,205 @@
+package extract
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/util"
+	"golang.org/x/net/html"
+)
+
+// coinsClass marks a COinS span (<span class="Z3988" title="ctx_ver=...">)
+const coinsClass = "Z3988"
+
+// AttachCitations reads the COinS spans in doc (emitted by Wikipedia's cite
+// templates, Zotero-friendly sites and reference managers) and attaches
+// their bibliographic fields to the evidence each one describes: evidence
+// whose URL or identifier matches an rft_id, else the first evidence link
+// next to the span. Access dates come from the "Retrieved ..." text of a
+// Wikipedia citation.
+func AttachCitations(doc *html.Node, sourceURL string, evidence []model.Evidence) []model.Evidence {
+	base, err := url.Parse(sourceURL)
+	if err != nil {
+		return evidence
+	}
+
+	byURL := make(map[string][]int)
+	byID := make(map[string][]int)
+	for i, ev := range evidence {
+		byURL[ev.URL] = append(byURL[ev.URL], i)
+		if ev.Identifier != nil {
+			byID[ev.Identifier.String()] = append(byID[ev.Identifier.String()], i)
+		}
+	}
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "span" && hasClass(n, coinsClass) {
+			citation, ids := parseCOinS(attrValue(n, "title"))
+			if citation != nil {
+				if n.Parent != nil {
+					citation.AccessedAt = accessDate(n.Parent)
+				}
+
+				var targets []int
+				for _, id := range ids {
+					if resolved := resolveURL(base, id); resolved != "" {
+						targets = append(targets, byURL[resolved]...)
+					} else if identifier, ok := parseRFTIdentifier(id); ok {
+						targets = append(targets, byID[identifier.String()]...)
+					}
+				}
+				if len(targets) == 0 && n.Parent != nil {
+					targets = firstLinked(n.Parent, base, byURL)
+				}
+				for _, i := range targets {
+					evidence[i].Citation = evidence[i].Citation.Merge(citation)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return evidence
+}
+
+// parseCOinS decodes an OpenURL ContextObject (the title of a COinS span)
+// into bibliographic fields and the rft_id values naming the work
+func parseCOinS(title string) (*model.Citation, []string) {
+	values, err := url.ParseQuery(title)
+	if err != nil || len(values) == 0 {
+		return nil, nil
+	}
+
+	citation := &model.Citation{Sources: []model.CitationSource{model.CitationSourceCOinS}}
+	citation.Title = firstValue(values, "rft.atitle", "rft.btitle", "rft.title")
+	citation.Publisher = firstValue(values, "rft.jtitle", "rft.pub", "rft.inst")
+	if t, ok := util.ParseDate(values.Get("rft.date")); ok {
+		citation.PublishedAt = &t
+	}
+
+	if last := strings.TrimSpace(values.Get("rft.aulast")); last != "" {
+		citation.Authors = append(citation.Authors, strings.TrimSpace(values.Get("rft.aufirst")+" "+last))
+	}
+	for _, name := range values["rft.au"] {
+		if name = strings.TrimSpace(name); name != "" && !containsString(citation.Authors, name) {
+			citation.Authors = append(citation.Authors, name)
+		}
+	}
+
+	ids := values["rft_id"]
+	if isbn := values.Get("rft.isbn"); isbn != "" {
+		ids = append(ids, "urn:isbn:"+isbn)
+	}
+
+	if citation.Title == "" && citation.Publisher == "" && citation.PublishedAt == nil && len(citation.Authors) == 0 {
+		return nil, nil
+	}
+	return citation, ids
+}
+
+// parseRFTIdentifier reads an rft_id URI (info:doi/..., info:arxiv/...,
+// urn:isbn:...) as an identifier
+func parseRFTIdentifier(id string) (model.Identifier, bool) {
+	lower := strings.ToLower(id)
+	switch {
+	case strings.HasPrefix(lower, "info:doi/"):
+		return parseIdentifier(model.IdentifierDOI, id[len("info:doi/"):])
+	case strings.HasPrefix(lower, "info:arxiv/"):
+		return parseIdentifier(model.IdentifierArXiv, id[len("info:arxiv/"):])
+	case strings.HasPrefix(lower, "urn:isbn:"):
+		return parseIdentifier(model.IdentifierISBN, id[len("urn:isbn:"):])
+	}
+	return model.Identifier{}, false
+}
+
+// firstLinked returns the evidence for the first link under n
+func firstLinked(n *html.Node, base *url.URL, byURL map[string][]int) []int {
+	var found []int
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if found != nil {
+			return
+		}
+		if n.Type == html.ElementNode && n.Data == "a" {
+			if resolved := resolveURL(base, strings.TrimSpace(attrValue(n, "href"))); resolved != "" {
+				found = byURL[resolved]
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return found
+}
+
+// accessDate reads the "Retrieved 12 March 2020" date of a Wikipedia
+// citation (<span class="reference-accessdate">)
+func accessDate(n *html.Node) *time.Time {
+	var date *time.Time
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if date != nil {
+			return
+		}
+		if n.Type == html.ElementNode && hasClass(n, "reference-accessdate") {
+			var text strings.Builder
+			writeText(n, &text)
+			value := strings.Join(strings.Fields(text.String()), " ")
+			if _, after, ok := strings.Cut(value, "Retrieved"); ok {
+				value = after
+			}
+			if t, ok := util.ParseDate(strings.Trim(value, " .,;")); ok {
+				date = &t
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return date
+}
+
+func firstValue(values url.Values, keys ...string) string {
+	for _, key := range keys {
+		if value := strings.TrimSpace(values.Get(key)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, field := range strings.Fields(attrValue(n, "class")) {
+		if field == class {
+			return true
+		}
+	}
+	return false
+}
+
+func attrValue(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
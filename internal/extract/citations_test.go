@@ -0,0 +1,58 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"golang.org/x/net/html"
+)
+
+func TestAttachCitations(t *testing.T) {
+	content := `<html><body><ol class="references">
+	<li id="cite_note-1"><span class="reference-text"><cite class="citation web">Smith, Jane (12 May 2019).
+		<a class="external text" href="https://news.example.com/bridge">"Bridge reopens"</a>. <i>Daily News</i>.
+		<span class="reference-accessdate">. Retrieved <span class="nowrap">3 June</span> 2021</span>.</cite>
+		<span title="ctx_ver=Z39.88-2004&amp;rft_val_fmt=info%3Aofi%2Ffmt%3Akev%3Amtx%3Abook&amp;rft.genre=unknown&amp;rft.btitle=Bridge+reopens&amp;rft.pub=Daily+News&amp;rft.date=2019-05-12&amp;rft.aulast=Smith&amp;rft.aufirst=Jane&amp;rft_id=https%3A%2F%2Fnews.example.com%2Fbridge" class="Z3988"></span></span></li>
+	<li id="cite_note-2"><span class="reference-text"><cite class="citation journal">Doe, J.; Roe, R. (2001). "Decay". <i>Physics</i>.
+		<a href="https://publisher.example.org/landing">Full text</a> <a href="https://doi.org/10.1000%2Fdecay">10.1000/decay</a>.</cite>
+		<span title="ctx_ver=Z39.88-2004&amp;rft.atitle=Decay&amp;rft.jtitle=Physics&amp;rft.date=2001&amp;rft.au=Doe%2C+J.&amp;rft.au=Roe%2C+R.&amp;rft_id=info%3Adoi%2F10.1000%2Fdecay" class="Z3988"></span></span></li>
+	</ol></body></html>`
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceURL := "https://en.wikipedia.org/wiki/Bridge"
+	evidence, err := NewEvidenceExtractor().Extract(content, sourceURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evidence = NewIdentifierExtractor(model.DefaultConfig().Identifiers).Extract(doc, evidence)
+	evidence = AttachCitations(doc, sourceURL, evidence)
+
+	byURL := make(map[string]*model.Citation)
+	for _, ev := range evidence {
+		byURL[ev.URL] = ev.Citation
+	}
+
+	web := byURL["https://news.example.com/bridge"]
+	if web == nil || web.Title != "Bridge reopens" || web.Publisher != "Daily News" || len(web.Authors) != 1 || web.Authors[0] != "Jane Smith" {
+		t.Fatalf("Expected COinS fields on the cited page, got %+v", web)
+	}
+	if web.PublishedAt == nil || web.PublishedAt.Format("2006-01-02") != "2019-05-12" {
+		t.Errorf("Expected publication date 2019-05-12, got %v", web.PublishedAt)
+	}
+	if web.AccessedAt == nil || web.AccessedAt.Format("2006-01-02") != "2021-06-03" {
+		t.Errorf("Expected access date 2021-06-03, got %v", web.AccessedAt)
+	}
+
+	// rft_id names the DOI, so the DOI link gets the fields, not the first link
+	journal := byURL["https://doi.org/10.1000%2Fdecay"]
+	if journal == nil || journal.Title != "Decay" || journal.Publisher != "Physics" || len(journal.Authors) != 2 {
+		t.Errorf("Expected COinS fields on the DOI link, got %+v", journal)
+	}
+	if byURL["https://publisher.example.org/landing"] != nil {
+		t.Error("Expected the landing page link to be left alone")
+	}
+}
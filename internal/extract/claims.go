@@ -3,25 +3,25 @@ package extract
 import (
 	"strings"
 
+	"github.com/ppiankov/entropia/internal/lang"
 	"github.com/ppiankov/entropia/internal/model"
 	"golang.org/x/net/html"
 )
 
 // ClaimExtractor extracts claims from HTML
 type ClaimExtractor struct {
-	keywords []string
+	pack *lang.Pack
 }
 
-// NewClaimExtractor creates a new claim extractor
+// NewClaimExtractor creates a new claim extractor for English text
 func NewClaimExtractor() *ClaimExtractor {
-	return &ClaimExtractor{
-		keywords: []string{
-			"originated", "origin", "first", "introduced", "invented",
-			"according to", "is defined as", "is legally", "under the law",
-			"under this act", "shall", "must", "is required", "established",
-			"founded", "created", "discovered", "developed",
-		},
-	}
+	return NewClaimExtractorFor(lang.Default().For(lang.DefaultLanguage))
+}
+
+// NewClaimExtractorFor creates a claim extractor using a language's
+// keywords and sentence rules
+func NewClaimExtractorFor(pack *lang.Pack) *ClaimExtractor {
+	return &ClaimExtractor{pack: pack}
 }
 
 // Extract extracts claims from HTML content
@@ -35,21 +35,18 @@ func (e *ClaimExtractor) Extract(htmlContent string) ([]model.Claim, error) {
 	text := extractVisibleText(doc)
 
 	// Split into sentences
-	sentences := splitSentences(text)
+	sentences := e.pack.SplitSentences(text)
 
-	// Extract claims by keyword matching
+	// Extract claims by keyword matching (only once per sentence)
 	var claims []model.Claim
 	for i, sentence := range sentences {
-		lower := strings.ToLower(sentence)
-		for _, keyword := range e.keywords {
-			if strings.Contains(lower, keyword) {
-				claims = append(claims, model.Claim{
-					Text:      strings.TrimSpace(sentence),
-					Heuristic: "keyword:" + keyword,
-					Sentence:  i,
-				})
-				break // Only match once per sentence
-			}
+		if keyword, ok := lang.Match(sentence, e.pack.Claims); ok {
+			claims = append(claims, model.Claim{
+				Text:      strings.TrimSpace(sentence),
+				Heuristic: "keyword:" + keyword,
+				Sentence:  i,
+				Language:  e.pack.Language,
+			})
 		}
 	}
 
@@ -87,40 +84,9 @@ func extractVisibleText(n *html.Node) string {
 	return buf.String()
 }
 
-// splitSentences splits text into sentences (simple heuristic)
+// splitSentences splits English text into sentences
 func splitSentences(text string) []string {
-	// Replace newlines with spaces
-	text = strings.ReplaceAll(text, "\n", " ")
-
-	// Split by sentence terminators
-	var sentences []string
-	var current strings.Builder
-
-	for i, r := range text {
-		current.WriteRune(r)
-
-		// Check for sentence terminators
-		if r == '.' || r == '!' || r == '?' {
-			// Look ahead to avoid splitting on abbreviations
-			if i+1 < len(text) && (text[i+1] == ' ' || text[i+1] == '\t') {
-				sentence := strings.TrimSpace(current.String())
-				if len(sentence) >= 30 && len(sentence) <= 500 {
-					sentences = append(sentences, sentence)
-				}
-				current.Reset()
-			}
-		}
-	}
-
-	// Add remaining text if it looks like a sentence
-	if current.Len() > 0 {
-		sentence := strings.TrimSpace(current.String())
-		if len(sentence) >= 30 && len(sentence) <= 500 {
-			sentences = append(sentences, sentence)
-		}
-	}
-
-	return sentences
+	return lang.Default().For(lang.DefaultLanguage).SplitSentences(text)
 }
 
 // dedupeClaims removes duplicate claims
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ppiankov/entropia/internal/lang"
 	"golang.org/x/net/html"
 )
 
@@ -56,6 +57,29 @@ func TestClaimExtractor_BasicExtraction(t *testing.T) {
 	}
 }
 
+func TestClaimExtractorFor_Japanese(t *testing.T) {
+	extractor := NewClaimExtractorFor(lang.Default().For("ja"))
+
+	claims, err := extractor.Extract(`<html lang="ja"><body>
+		<p>ラクサは十五世紀にマラッカで発祥したとされる。今日は晴れて暖かい日だった。研究者によると沿岸部に広まった。</p>
+	</body></html>`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(claims) != 2 {
+		t.Fatalf("Expected 2 claims from sentences split on 。, got %+v", claims)
+	}
+	if claims[0].Text != "ラクサは十五世紀にマラッカで発祥したとされる。" || claims[0].Heuristic != "keyword:発祥" {
+		t.Errorf("Unexpected first claim %+v", claims[0])
+	}
+	for _, claim := range claims {
+		if claim.Language != "ja" {
+			t.Errorf("Expected language ja, got %q", claim.Language)
+		}
+	}
+}
+
 func TestClaimExtractor_LegalKeywords(t *testing.T) {
 	extractor := NewClaimExtractor()
 
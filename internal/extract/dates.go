@@ -0,0 +1,247 @@
+package extract
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+var (
+	// centuryRangePattern matches "9th–13th centuries" and "10th to 12th century BC"
+	centuryRangePattern = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\s*(?:[-–—]|to|and)\s*(\d{1,2})(?:st|nd|rd|th)[\s-]+centur(?:y|ies)\b(?:\s*(BCE?|AD|CE)\b)?`)
+
+	// centuryPattern matches "the 13th century", "early 20th-century" and "5th century BC"
+	centuryPattern = regexp.MustCompile(`(?i)\b(?:(early|mid|late)[\s-]+)?(\d{1,2})(?:st|nd|rd|th)[\s-]+century\b(?:\s*(BCE?|AD|CE)\b)?`)
+
+	// yearRangePattern matches "1922–1991", "from 882 to 1240" and "between 1867 and 1918"
+	yearRangePattern = regexp.MustCompile(`(?i)\b(\d{4})\s*[-–—]\s*(\d{4})\b|\b(?:from|between)\s+(\d{3,4})\s+(?:to|until|and)\s+(\d{3,4})\b`)
+
+	// decadePattern matches "the 1880s" (a decade) and "the 1800s" (a century)
+	decadePattern = regexp.MustCompile(`\b(\d{3})0'?s\b`)
+
+	// eraYearPattern matches "500 BC", "882 AD" and "AD 882"
+	eraYearPattern = regexp.MustCompile(`\b(\d{1,4})\s*(BCE?|AD|CE)\b|\b(AD|CE)\s*(\d{1,4})\b`)
+
+	// yearPattern matches a bare three- or four-digit number that may be a year
+	yearPattern = regexp.MustCompile(`\b\d{3,4}\b`)
+)
+
+// yearCues are words that, directly before a number, make it a year
+var yearCues = map[string]bool{
+	"in": true, "since": true, "by": true, "from": true, "until": true, "till": true,
+	"around": true, "circa": true, "c.": true, "ca.": true, "about": true, "before": true,
+	"after": true, "during": true, "of": true, "year": true, "early": true, "mid": true, "late": true,
+	"january": true, "february": true, "march": true, "april": true, "may": true, "june": true,
+	"july": true, "august": true, "september": true, "october": true, "november": true, "december": true,
+}
+
+// shortYearCues are the cues that make a three-digit number a year ("in 882");
+// the others precede quantities too often ("by 500 soldiers")
+var shortYearCues = map[string]bool{
+	"in": true, "since": true, "until": true, "till": true, "around": true, "circa": true, "c.": true, "ca.": true,
+}
+
+// clauseWords may follow a year in running text ("in 1887 the city ..."),
+// unlike the nouns that follow a quantity ("1500 factories")
+var clauseWords = map[string]bool{
+	"the": true, "a": true, "an": true, "and": true, "or": true, "but": true, "when": true, "as": true,
+	"it": true, "he": true, "she": true, "they": true, "its": true, "his": true, "her": true, "their": true,
+	"in": true, "on": true, "at": true, "by": true, "under": true, "after": true, "before": true, "during": true,
+	"was": true, "were": true, "is": true, "had": true, "to": true, "with": true, "from": true,
+}
+
+// DateMatch is a temporal expression located in text
+type DateMatch struct {
+	Start, End int // Byte range of the expression
+	Date       model.ClaimDate
+}
+
+// FindDates returns the temporal expressions in text, in order of
+// appearance: centuries and century ranges, year ranges, decades, years
+// with an era, and numbers read as years from their context. Bare numbers
+// followed by a noun ("1500 factories") or after the current year are not
+// years.
+func FindDates(text string) []DateMatch {
+	var matches []DateMatch
+	add := func(start, end, from, to int) {
+		for _, m := range matches {
+			if start < m.End && m.Start < end {
+				return // Part of an expression already found
+			}
+		}
+		matches = append(matches, DateMatch{
+			Start: start,
+			End:   end,
+			Date:  model.ClaimDate{Text: text[start:end], From: from, To: to},
+		})
+	}
+
+	for _, loc := range centuryRangePattern.FindAllStringSubmatchIndex(text, -1) {
+		bc := isBC(text, loc[6], loc[7])
+		first, _ := strconv.Atoi(text[loc[2]:loc[3]])
+		last, _ := strconv.Atoi(text[loc[4]:loc[5]])
+		if from1, to1, ok := centurySpan(first, bc); ok {
+			if from2, to2, ok := centurySpan(last, bc); ok {
+				add(loc[0], loc[1], min(from1, from2), max(to1, to2))
+			}
+		}
+	}
+
+	for _, loc := range centuryPattern.FindAllStringSubmatchIndex(text, -1) {
+		n, _ := strconv.Atoi(text[loc[4]:loc[5]])
+		from, to, ok := centurySpan(n, isBC(text, loc[6], loc[7]))
+		if !ok {
+			continue
+		}
+		if loc[2] >= 0 {
+			// Thirds of the century
+			switch strings.ToLower(text[loc[2]:loc[3]]) {
+			case "early":
+				to = from + 32
+			case "mid":
+				from, to = from+33, from+66
+			case "late":
+				from += 67
+			}
+		}
+		add(loc[0], loc[1], from, to)
+	}
+
+	for _, loc := range yearRangePattern.FindAllStringSubmatchIndex(text, -1) {
+		groups := loc[2:6]
+		if loc[2] < 0 {
+			groups = loc[6:10]
+		}
+		from, _ := strconv.Atoi(text[groups[0]:groups[1]])
+		to, _ := strconv.Atoi(text[groups[2]:groups[3]])
+		if from <= to && plausibleYear(to) {
+			add(loc[0], loc[1], from, to)
+		}
+	}
+
+	for _, loc := range decadePattern.FindAllStringSubmatchIndex(text, -1) {
+		prefix, _ := strconv.Atoi(text[loc[2]:loc[3]])
+		from, to := prefix*10, prefix*10+9
+		if prefix%10 == 0 {
+			to = from + 99
+		}
+		if from >= 100 && plausibleYear(from) {
+			add(loc[0], loc[1], from, to)
+		}
+	}
+
+	for _, loc := range eraYearPattern.FindAllStringSubmatchIndex(text, -1) {
+		digits, era := loc[2:4], loc[4:6]
+		if loc[2] < 0 {
+			digits, era = loc[8:10], loc[6:8]
+		}
+		year, _ := strconv.Atoi(text[digits[0]:digits[1]])
+		if year == 0 {
+			continue
+		}
+		if strings.HasPrefix(text[era[0]:era[1]], "B") {
+			year = -year
+		} else if !plausibleYear(year) {
+			continue
+		}
+		add(loc[0], loc[1], year, year)
+	}
+
+	for _, loc := range yearPattern.FindAllStringIndex(text, -1) {
+		year, _ := strconv.Atoi(text[loc[0]:loc[1]])
+		if !plausibleYear(year) || inNumber(text, loc[0], loc[1]) {
+			continue
+		}
+		cue := strings.ToLower(previousWord(text, loc[0]))
+		switch {
+		case year < 1000:
+			if year >= 100 && shortYearCues[cue] && !quantityFollows(text, loc[1]) {
+				add(loc[0], loc[1], year, year)
+			}
+		case yearCues[cue] || !quantityFollows(text, loc[1]):
+			add(loc[0], loc[1], year, year)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+	return matches
+}
+
+// AnnotateDates records the temporal expressions of each claim
+func AnnotateDates(claims []model.Claim) []model.Claim {
+	for i := range claims {
+		claims[i].Dates = nil
+		for _, m := range FindDates(claims[i].Text) {
+			claims[i].Dates = append(claims[i].Dates, m.Date)
+		}
+	}
+	return claims
+}
+
+// centurySpan returns the years of the nth century (1st = 1-100, 5th BC =
+// -500 to -401)
+func centurySpan(n int, bc bool) (int, int, bool) {
+	if n < 1 || n > 21 {
+		return 0, 0, false
+	}
+	if bc {
+		return -n * 100, -(n-1)*100 - 1, true
+	}
+	return (n-1)*100 + 1, n * 100, true
+}
+
+func isBC(text string, start, end int) bool {
+	return start >= 0 && strings.HasPrefix(strings.ToUpper(text[start:end]), "B")
+}
+
+func plausibleYear(year int) bool {
+	return year <= time.Now().Year()
+}
+
+// inNumber reports whether text[start:end] is part of a longer number
+// ("1,500", "3.1415", "10.1038/...")
+func inNumber(text string, start, end int) bool {
+	if start >= 2 && strings.IndexByte(".,", text[start-1]) >= 0 && isDigit(text[start-2]) {
+		return true
+	}
+	if end+1 < len(text) && strings.IndexByte(".,", text[end]) >= 0 && isDigit(text[end+1]) {
+		return true
+	}
+	return end < len(text) && text[end] == '%'
+}
+
+// quantityFollows reports whether the number ending at end is followed by a
+// noun it counts ("1500 factories"), rather than by a clause ("1887 the city")
+func quantityFollows(text string, end int) bool {
+	word := nextWord(text, end)
+	return word != "" && word[0] >= 'a' && word[0] <= 'z' && !clauseWords[word]
+}
+
+// nextWord returns the word after end when only spaces separate them
+func nextWord(text string, end int) string {
+	rest := strings.TrimLeft(text[end:], " ")
+	if len(rest) == len(text[end:]) {
+		return ""
+	}
+	if i := strings.IndexFunc(rest, func(r rune) bool { return r == ' ' || strings.ContainsRune(".,;:!?)]", r) }); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
+
+// previousWord returns the word before start ("in" for "in 1887")
+func previousWord(text string, start int) string {
+	before := strings.TrimRight(text[:start], " ")
+	if i := strings.LastIndexAny(before, " ([\n\t"); i >= 0 {
+		before = before[i+1:]
+	}
+	return strings.TrimRight(before, ",")
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
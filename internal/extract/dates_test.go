@@ -0,0 +1,51 @@
+package extract
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+func TestFindDates(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string // "text=from..to"
+	}{
+		{"The bridge was opened in 1887 by the council.", []string{"1887=1887..1887"}},
+		{"It dates to the 13th century and spread in the 1800s.", []string{"13th century=1201..1300", "1800s=1800..1899"}},
+		{"Popular in the 1880s, and again in the early 20th-century revival.", []string{"1880s=1880..1889", "early 20th-century=1901..1933"}},
+		{"The state existed 1922–1991, from 882 to 1240, and between 1867 and 1918.", []string{"1922–1991=1922..1991", "from 882 to 1240=882..1240", "between 1867 and 1918=1867..1918"}},
+		{"Recipes appear in the 9th–13th centuries and the 5th century BC.", []string{"9th–13th centuries=801..1300", "5th century BC=-500..-401"}},
+		{"Founded in 882 AD, or AD 862 by other accounts, and first settled in 500 BC.", []string{"882 AD=882..882", "AD 862=862..862", "500 BC=-500..-500"}},
+		{"Borscht (1584) was served on 12 May 1887.", []string{"1584=1584..1584", "1887=1887..1887"}},
+		{"The plant had 1500 factories, 1,500 workers and a 1200% growth; see doi 10.1038/x.", nil},
+		{"By 500 soldiers in 3000 units in the year 2999.", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, m := range FindDates(tt.text) {
+			if tt.text[m.Start:m.End] != m.Date.Text {
+				t.Errorf("%q: range %d-%d does not match %q", tt.text, m.Start, m.End, m.Date.Text)
+			}
+			got = append(got, fmt.Sprintf("%s=%d..%d", m.Date.Text, m.Date.From, m.Date.To))
+		}
+		if strings.Join(got, " | ") != strings.Join(tt.want, " | ") {
+			t.Errorf("FindDates(%q)\n got %v\nwant %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestAnnotateDates(t *testing.T) {
+	claims := AnnotateDates([]model.Claim{
+		{Text: "The dish originates from Kyivan Rus in the 15th century."},
+		{Text: "The dish is popular today."},
+	})
+	if len(claims[0].Dates) != 1 || claims[0].Dates[0] != (model.ClaimDate{Text: "15th century", From: 1401, To: 1500}) {
+		t.Errorf("Expected the century on the first claim, got %+v", claims[0].Dates)
+	}
+	if claims[1].Dates != nil {
+		t.Errorf("Expected no dates on the second claim, got %+v", claims[1].Dates)
+	}
+}
@@ -49,6 +49,11 @@ func (e *EvidenceExtractor) Extract(htmlContent string, sourceURL string) ([]mod
 
 			if href != "" {
 				resolvedURL := resolveURL(baseURL, href)
+				// Links between saved HTML files are not evidence: the rest
+				// of the site was not saved (the docs adapter keeps them)
+				if strings.HasPrefix(resolvedURL, "file:") {
+					resolvedURL = ""
+				}
 				if resolvedURL != "" && !isWikipediaNavigationLink(resolvedURL, baseURL.String()) {
 					parsed, _ := url.Parse(resolvedURL)
 					host := ""
@@ -96,8 +101,11 @@ func resolveURL(base *url.URL, href string) string {
 
 	resolved := base.ResolveReference(parsed)
 
-	// Only keep http/https URLs
-	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+	// Only keep http/https URLs, and file URLs on pages read from disk
+	switch {
+	case resolved.Scheme == "http" || resolved.Scheme == "https":
+	case resolved.Scheme == "file" && base.Scheme == "file":
+	default:
 		return ""
 	}
 
@@ -0,0 +1,330 @@
+package extract
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"golang.org/x/net/html"
+)
+
+var (
+	// doiPattern matches a DOI (Crossref's recommended pattern, case-insensitive)
+	doiPattern = regexp.MustCompile(`(?i)\b10\.\d{4,9}/[^\s"<>\x{a0}]+`)
+
+	// arxivPattern matches "arXiv:" followed by a new-style (2101.00001v2)
+	// or old-style (hep-th/9901001, math.GT/0309136) identifier
+	arxivPattern = regexp.MustCompile(`(?i)\barxiv:\s*(\d{4}\.\d{4,5}(?:v\d+)?|[a-z][a-z-]*(?:\.[a-z]{2})?/\d{7}(?:v\d+)?)\b`)
+
+	// isbnPattern matches "ISBN" followed by an ISBN-10 or ISBN-13 with
+	// optional hyphens or spaces; the checksum is verified separately
+	isbnPattern = regexp.MustCompile(`(?i)\bISBN(?:-1[03])?:?[\s\x{a0}]*((?:97[89][\s\x{a0}-]?)?(?:\d[\s\x{a0}-]?){9}[\dX])\b`)
+)
+
+// IdentifierMatch is an identifier located in text
+type IdentifierMatch struct {
+	Start, End int // Byte range of the identifier (including its "arXiv:"/"ISBN" prefix)
+	Identifier model.Identifier
+}
+
+// FindIdentifiers returns the DOIs, arXiv IDs and ISBNs (with a valid
+// checksum) in text, in order of appearance
+func FindIdentifiers(text string) []IdentifierMatch {
+	var matches []IdentifierMatch
+
+	for _, loc := range doiPattern.FindAllStringIndex(text, -1) {
+		doi := trimIdentifier(text[loc[0]:loc[1]])
+		matches = append(matches, IdentifierMatch{
+			Start:      loc[0],
+			End:        loc[0] + len(doi),
+			Identifier: model.Identifier{Type: model.IdentifierDOI, Value: strings.ToLower(doi)},
+		})
+	}
+
+	for _, loc := range arxivPattern.FindAllStringSubmatchIndex(text, -1) {
+		matches = append(matches, IdentifierMatch{
+			Start:      loc[0],
+			End:        loc[1],
+			Identifier: model.Identifier{Type: model.IdentifierArXiv, Value: normalizeArXiv(text[loc[2]:loc[3]])},
+		})
+	}
+
+	for _, loc := range isbnPattern.FindAllStringSubmatchIndex(text, -1) {
+		if isbn, ok := normalizeISBN(text[loc[2]:loc[3]]); ok {
+			matches = append(matches, IdentifierMatch{
+				Start:      loc[0],
+				End:        loc[1],
+				Identifier: model.Identifier{Type: model.IdentifierISBN, Value: isbn},
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+	return matches
+}
+
+// trimIdentifier drops sentence punctuation and unbalanced closing brackets
+// from the end of a DOI (DOIs may contain balanced parentheses)
+func trimIdentifier(s string) string {
+	for s != "" {
+		last := s[len(s)-1]
+		switch {
+		case strings.IndexByte(".,;:'", last) >= 0:
+			s = s[:len(s)-1]
+		case last == ')' && strings.Count(s, "(") < strings.Count(s, ")"):
+			s = s[:len(s)-1]
+		case last == ']' && strings.Count(s, "[") < strings.Count(s, "]"):
+			s = s[:len(s)-1]
+		default:
+			return s
+		}
+	}
+	return s
+}
+
+// normalizeArXiv lowercases an arXiv ID except an old-style subject class
+// (math.GT/0309136)
+func normalizeArXiv(id string) string {
+	id = strings.ToLower(id)
+	if archive, number, ok := strings.Cut(id, "/"); ok {
+		if name, class, ok := strings.Cut(archive, "."); ok {
+			archive = name + "." + strings.ToUpper(class)
+		}
+		return archive + "/" + number
+	}
+	return id
+}
+
+// normalizeISBN verifies an ISBN-10 or ISBN-13 checksum and returns the
+// ISBN-13 digits
+func normalizeISBN(raw string) (string, bool) {
+	var digits []byte
+	for i := 0; i < len(raw); i++ {
+		switch c := raw[i]; {
+		case c >= '0' && c <= '9', c == 'X':
+			digits = append(digits, c)
+		case c == 'x':
+			digits = append(digits, 'X')
+		}
+	}
+
+	switch len(digits) {
+	case 10:
+		sum := 0
+		for i, c := range digits {
+			value := int(c - '0')
+			if c == 'X' {
+				if i != 9 {
+					return "", false
+				}
+				value = 10
+			}
+			sum += value * (10 - i)
+		}
+		if sum%11 != 0 {
+			return "", false
+		}
+		isbn := "978" + string(digits[:9])
+		return isbn + string(isbn13Check(isbn)), true
+	case 13:
+		if strings.IndexByte(string(digits), 'X') >= 0 || isbn13Check(string(digits[:12])) != digits[12] {
+			return "", false
+		}
+		return string(digits), true
+	}
+	return "", false
+}
+
+// isbn13Check returns the ISBN-13 check digit for the first 12 digits
+func isbn13Check(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(digits[i]-'0') * weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// IdentifierExtractor finds DOIs, arXiv IDs and ISBNs cited in page text
+type IdentifierExtractor struct {
+	resolvers model.IdentifierConfig
+}
+
+// NewIdentifierExtractor creates an identifier extractor that links
+// identifiers to the configured resolvers
+func NewIdentifierExtractor(resolvers model.IdentifierConfig) *IdentifierExtractor {
+	return &IdentifierExtractor{resolvers: resolvers}
+}
+
+// Extract tags evidence links to an identifier resolver (doi.org,
+// arxiv.org/abs, or a configured resolver) with their identifier, points
+// works cited without a link at the resolver of the first identifier in
+// their text, then appends identifier evidence for each identifier in the
+// visible text of doc that no evidence already cites
+func (e *IdentifierExtractor) Extract(doc *html.Node, evidence []model.Evidence) []model.Evidence {
+	seen := make(map[string]bool)
+	for i, ev := range evidence {
+		if id, ok := e.fromURL(ev.URL); ok {
+			evidence[i].Identifier = &id
+			seen[id.String()] = true
+		}
+	}
+
+	resolved := make([]model.Evidence, 0, len(evidence))
+	for _, ev := range evidence {
+		if ev.Offline {
+			for _, m := range FindIdentifiers(ev.Text) {
+				id := m.Identifier
+				link := e.resolvers.URL(id)
+				if link == "" {
+					continue
+				}
+				ev.URL, ev.Host, ev.IsSameHost, ev.Identifier, ev.Offline = link, hostOf(link), false, &id, false
+				break
+			}
+			if ev.Identifier != nil && seen[ev.Identifier.String()] {
+				// Another citation of the same work already covers it
+				continue
+			}
+			if ev.Identifier != nil {
+				seen[ev.Identifier.String()] = true
+			}
+		}
+		resolved = append(resolved, ev)
+	}
+	evidence = resolved
+
+	var text strings.Builder
+	writeText(doc, &text)
+	body := text.String()
+
+	for _, m := range FindIdentifiers(body) {
+		id := m.Identifier
+		if seen[id.String()] {
+			continue
+		}
+		link := e.resolvers.URL(id)
+		if link == "" {
+			continue
+		}
+		seen[id.String()] = true
+
+		evidence = append(evidence, model.Evidence{
+			URL:        link,
+			Kind:       model.EvidenceKindIdentifier,
+			Host:       hostOf(link),
+			Text:       body[m.Start:m.End],
+			Identifier: &id,
+		})
+	}
+
+	return evidence
+}
+
+func hostOf(rawURL string) string {
+	if parsed, err := url.Parse(rawURL); err == nil {
+		return parsed.Host
+	}
+	return ""
+}
+
+// fromURL returns the identifier a resolver link points to
+func (e *IdentifierExtractor) fromURL(rawURL string) (model.Identifier, bool) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return model.Identifier{}, false
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+	path := strings.TrimPrefix(parsed.Path, "/")
+
+	// A configured resolver counts like the public one
+	for _, resolver := range []struct {
+		prefix string
+		typ    model.IdentifierType
+	}{
+		{e.resolvers.DOIResolver, model.IdentifierDOI},
+		{e.resolvers.ArXivResolver, model.IdentifierArXiv},
+		{e.resolvers.ISBNResolver, model.IdentifierISBN},
+	} {
+		prefix := strings.TrimSuffix(resolver.prefix, "/") + "/"
+		if resolver.prefix == "" || !strings.HasPrefix(rawURL, prefix) {
+			continue
+		}
+		unescaped, err := url.PathUnescape(strings.TrimPrefix(rawURL, prefix))
+		if err != nil {
+			return model.Identifier{}, false
+		}
+		return parseIdentifier(resolver.typ, unescaped)
+	}
+
+	switch {
+	case host == "doi.org" || host == "dx.doi.org":
+		return parseIdentifier(model.IdentifierDOI, path)
+	case host == "arxiv.org" && strings.HasPrefix(path, "abs/"):
+		return parseIdentifier(model.IdentifierArXiv, strings.TrimPrefix(path, "abs/"))
+	case host == "arxiv.org" && strings.HasPrefix(path, "pdf/"):
+		return parseIdentifier(model.IdentifierArXiv, strings.TrimSuffix(strings.TrimPrefix(path, "pdf/"), ".pdf"))
+	}
+	return model.Identifier{}, false
+}
+
+// parseIdentifier validates and normalizes a bare identifier of a known type
+func parseIdentifier(typ model.IdentifierType, value string) (model.Identifier, bool) {
+	var prefix string
+	switch typ {
+	case model.IdentifierArXiv:
+		prefix = "arXiv:"
+	case model.IdentifierISBN:
+		prefix = "ISBN "
+	}
+	text := prefix + value
+	for _, m := range FindIdentifiers(text) {
+		if m.Identifier.Type == typ && m.Start == 0 && m.End == len(text) {
+			return m.Identifier, true
+		}
+	}
+	return model.Identifier{}, false
+}
+
+// writeText writes the visible text of n, with a line break between block
+// elements so identifiers never run into the next paragraph
+func writeText(n *html.Node, w *strings.Builder) {
+	if n.Type == html.ElementNode {
+		switch n.Data {
+		case "script", "style", "noscript", "iframe":
+			return
+		case "br":
+			w.WriteByte('\n')
+			return
+		}
+	}
+	if n.Type == html.TextNode {
+		w.WriteString(n.Data)
+		return
+	}
+
+	block := breaksText(n)
+	if block {
+		w.WriteByte('\n')
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeText(c, w)
+	}
+	if block {
+		w.WriteByte('\n')
+	}
+}
+
+// breaksText reports whether n ends a run of text: a block element, table
+// row or <div>
+func breaksText(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	return blockElements[n.Data] || n.Data == "div" || n.Data == "tr"
+}
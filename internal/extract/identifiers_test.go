@@ -0,0 +1,132 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"golang.org/x/net/html"
+)
+
+func TestFindIdentifiers(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"See doi:10.1000/XYZ123.", []string{"doi:10.1000/xyz123"}},
+		{"(Smith 2001, 10.1002/(SICI)1097-4571(199806)49:8<693::AID-ASI4>3.0.CO;2-0)", []string{"doi:10.1002/(sici)1097-4571(199806)49:8"}},
+		{"Preprint arXiv:2101.00001v2 [cs.CL] and arXiv: math.gt/0309136", []string{"arxiv:2101.00001v2", "arxiv:math.GT/0309136"}},
+		{"ISBN 978-0-306-40615-7, ISBN-10: 0-306-40615-2", []string{"isbn:9780306406157", "isbn:9780306406157"}},
+		{"ISBN 080442957X", []string{"isbn:9780804429573"}},
+		{"ISBN 0-306-40615-3 has a bad checksum; page 10.5 and 2101.00001 are not identifiers", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, m := range FindIdentifiers(tt.text) {
+			got = append(got, m.Identifier.String())
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("FindIdentifiers(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestIdentifierExtractor_Extract(t *testing.T) {
+	content := `<html><body>
+	<p>The effect was first described in 2019 (doi:10.1000/abc.1).</p>
+	<ol class="references">
+		<li>Smith, J. <i>Physics</i>. <a href="https://doi.org/10.1038%2Fnature12373">doi:10.1038/nature12373</a></li>
+		<li>Jones, K. arXiv:2101.00001</li>
+		<li>Brown, A. <i>A Book</i>. ISBN&nbsp;<a href="/wiki/Special:BookSources/0-306-40615-2"><bdi>0-306-40615-2</bdi></a></li>
+	</ol>
+	<script>var doi = "10.9999/hidden";</script>
+	</body></html>`
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evidence, err := NewEvidenceExtractor().Extract(content, "https://en.wikipedia.org/wiki/Effect")
+	if err != nil {
+		t.Fatal(err)
+	}
+	evidence = NewIdentifierExtractor(model.DefaultConfig().Identifiers).Extract(doc, evidence)
+
+	want := map[string]model.EvidenceKind{
+		"https://doi.org/10.1038%2Fnature12373":      model.EvidenceKindExternalLink,
+		"https://doi.org/10.1000/abc.1":              model.EvidenceKindIdentifier,
+		"https://arxiv.org/abs/2101.00001":           model.EvidenceKindIdentifier,
+		"https://openlibrary.org/isbn/9780306406157": model.EvidenceKindIdentifier,
+	}
+	if len(evidence) != len(want) {
+		t.Fatalf("Expected %d evidence, got %+v", len(want), evidence)
+	}
+	for _, ev := range evidence {
+		kind, ok := want[ev.URL]
+		if !ok || ev.Kind != kind {
+			t.Errorf("Unexpected evidence %s (%s)", ev.URL, ev.Kind)
+		}
+		if ev.Identifier == nil {
+			t.Errorf("%s: missing identifier", ev.URL)
+		}
+	}
+	if id := evidence[0].Identifier; id == nil || id.String() != "doi:10.1038/nature12373" {
+		t.Errorf("Expected the doi.org link to be tagged with its DOI, got %+v", id)
+	}
+}
+
+func TestLinkClaims_Identifiers(t *testing.T) {
+	content := `<html><body>
+	<p>The effect was first described by the group in 2019 (doi:10.1000/abc.1).</p>
+	<p>The method was later extended according to the authors.<sup><a href="#cite_note-1">[1]</a></sup></p>
+	<ol class="references">
+		<li id="cite_note-1">Jones, K. arXiv:2101.00001</li>
+	</ol>
+	</body></html>`
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := NewClaimExtractor().Extract(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evidence := NewIdentifierExtractor(model.DefaultConfig().Identifiers).Extract(doc, nil)
+	claims = LinkClaims(doc, "https://site.example.com/page", claims, evidence)
+
+	described := findClaim(t, claims, "first described")
+	if len(described.EvidenceRefs) != 1 || described.EvidenceRefs[0] != "https://doi.org/10.1000/abc.1" {
+		t.Errorf("Expected the DOI in the sentence to support it, got %v", described.EvidenceRefs)
+	}
+	extended := findClaim(t, claims, "later extended")
+	if len(extended.EvidenceRefs) != 1 || extended.EvidenceRefs[0] != "https://arxiv.org/abs/2101.00001" {
+		t.Errorf("Expected the arXiv ID in the footnote to support it, got %v", extended.EvidenceRefs)
+	}
+}
+
+func TestIdentifierExtractor_Offline(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body><ol class="references">
+		<li id="cite_note-1">Brown, A. <i>A Book</i>. ISBN 0-306-40615-2</li>
+		<li id="cite_note-2">Brown, A. <i>A Book</i>, 2nd printing. ISBN 978-0-306-40615-7</li>
+		<li id="cite_note-3">Green, B. <i>Letters</i>. Private collection.</li>
+	</ol></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	evidence := []model.Evidence{
+		{URL: "https://en.wikipedia.org/wiki/Effect#cite_note-1", Text: "Brown, A. A Book. ISBN 0-306-40615-2", Offline: true},
+		{URL: "https://en.wikipedia.org/wiki/Effect#cite_note-2", Text: "Brown, A. A Book, 2nd printing. ISBN 978-0-306-40615-7", Offline: true},
+		{URL: "https://en.wikipedia.org/wiki/Effect#cite_note-3", Text: "Green, B. Letters. Private collection.", Offline: true},
+	}
+	evidence = NewIdentifierExtractor(model.DefaultConfig().Identifiers).Extract(doc, evidence)
+
+	if len(evidence) != 2 {
+		t.Fatalf("Expected the book once and the letters, got %+v", evidence)
+	}
+	if book := evidence[0]; book.Offline || book.URL != "https://openlibrary.org/isbn/9780306406157" || book.Identifier == nil {
+		t.Errorf("Expected the book to be checkable by its ISBN, got %+v", book)
+	}
+	if letters := evidence[1]; !letters.Offline || letters.Identifier != nil {
+		t.Errorf("Expected the letters to stay offline, got %+v", letters)
+	}
+}
@@ -0,0 +1,330 @@
+package extract
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"golang.org/x/net/html"
+)
+
+// blockElements delimit paragraphs for paragraph-level linkage
+var blockElements = map[string]bool{
+	"p": true, "li": true, "dd": true, "dt": true, "blockquote": true,
+	"td": true, "th": true, "figcaption": true, "caption": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// anchorSpan is a link, footnote marker or cited identifier located in the
+// document text
+type anchorSpan struct {
+	start, end int      // Byte range in the whitespace-free document text
+	block      int      // Innermost enclosing block (-1 if none)
+	marker     bool     // In-page footnote marker (href="#...")
+	urls       []string // Resolved evidence URLs or identifiers (doi:10.1000/xyz)
+}
+
+// documentText is the visible text of a document with whitespace removed,
+// so claim text can be located regardless of how an extractor joined nodes
+type documentText struct {
+	text    strings.Builder
+	anchors []anchorSpan
+	blocks  [][2]int // Byte range of each block element
+
+	raw    strings.Builder // Visible text with whitespace, for identifier matching
+	rawPos []int           // Offset in text of each byte of raw
+}
+
+// LinkClaims anchors each claim to the evidence it cites. A claim is linked
+// to links and footnote markers (e.g., <sup class="reference">) inside its
+// sentence, including markers directly after it; if there are none, to those
+// in the same paragraph. DOIs, arXiv IDs and ISBNs in the text count as
+// links to the evidence carrying that identifier. Only URLs present in
+// evidence are referenced.
+func LinkClaims(doc *html.Node, sourceURL string, claims []model.Claim, evidence []model.Evidence) []model.Claim {
+	if len(claims) == 0 {
+		return claims
+	}
+
+	// Anchors name evidence by URL or by identifier
+	known := make(map[string]string, len(evidence))
+	for _, ev := range evidence {
+		known[ev.URL] = ev.URL
+		if ev.Identifier != nil {
+			if _, ok := known[ev.Identifier.String()]; !ok {
+				known[ev.Identifier.String()] = ev.URL
+			}
+		}
+	}
+
+	index := indexDocument(doc, sourceURL)
+	text := index.text.String()
+
+	linked := make([]model.Claim, len(claims))
+	for i, claim := range claims {
+		claim.EvidenceRefs = nil
+		claim.Support = model.ClaimUnsupported
+
+		needle := stripSpace(claim.Text)
+		if start := strings.Index(text, needle); needle != "" && start >= 0 {
+			claim.EvidenceRefs = index.refsFor(start, start+len(needle), known)
+		}
+
+		if len(claim.EvidenceRefs) > 0 {
+			claim.Support = model.ClaimSupported
+		}
+		linked[i] = claim
+	}
+
+	return linked
+}
+
+// refsFor returns the evidence anchored to the claim at [start, end)
+func (d *documentText) refsFor(start, end int, known map[string]string) []string {
+	text := d.text.String()
+
+	// Markers at the start belong to the previous sentence
+	for moved := true; moved; {
+		moved = false
+		for _, a := range d.anchors {
+			if a.marker && a.start == start && a.end < end {
+				start = a.end
+				moved = true
+			}
+		}
+	}
+
+	// Markers right after the sentence (e.g., "...in 1900.[1][2]") belong to it
+	for moved := true; moved; {
+		moved = false
+		for _, a := range d.anchors {
+			if a.marker && a.start >= end && strings.Trim(text[end:a.start], ".,;:!?)\"'") == "" {
+				end = a.end
+				moved = true
+			}
+		}
+	}
+
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(a anchorSpan) {
+		for _, key := range a.urls {
+			if u, ok := known[key]; ok && !seen[u] {
+				seen[u] = true
+				refs = append(refs, u)
+			}
+		}
+	}
+
+	// Sentence-level anchors
+	for _, a := range d.anchors {
+		if a.start < end && a.end > start {
+			add(a)
+		}
+	}
+	if len(refs) > 0 {
+		return refs
+	}
+
+	// Paragraph-level anchors: the block holding the end of the sentence
+	block := d.blockAt(end - 1)
+	if block < 0 {
+		return nil
+	}
+	for _, a := range d.anchors {
+		if a.block == block {
+			add(a)
+		}
+	}
+	return refs
+}
+
+// blockAt returns the innermost block containing pos, or -1
+func (d *documentText) blockAt(pos int) int {
+	found := -1
+	for i, b := range d.blocks {
+		if pos >= b[0] && pos < b[1] {
+			found = i // Later blocks are nested deeper or start later
+		}
+	}
+	return found
+}
+
+// indexDocument builds the whitespace-free text of doc with the positions
+// of links and cited identifiers
+func indexDocument(doc *html.Node, sourceURL string) *documentText {
+	d := &documentText{}
+	base, _ := url.Parse(sourceURL)
+	ids := make(map[string]*html.Node)
+	collectIDs(doc, ids)
+
+	var walk func(n *html.Node, block int)
+	walk = func(n *html.Node, block int) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script", "style", "noscript", "iframe":
+				return
+			}
+		}
+
+		if n.Type == html.TextNode {
+			d.writeText(n.Data)
+			return
+		}
+
+		if n.Type == html.ElementNode && n.Data == "br" {
+			d.writeBreak()
+		}
+		if breaksText(n) {
+			d.writeBreak()
+			defer d.writeBreak()
+		}
+
+		if n.Type == html.ElementNode && blockElements[n.Data] {
+			block = len(d.blocks)
+			d.blocks = append(d.blocks, [2]int{d.text.Len(), -1})
+			defer func(i int) { d.blocks[i][1] = d.text.Len() }(block)
+		}
+
+		if n.Type == html.ElementNode && n.Data == "a" {
+			if a, ok := anchorFor(n, base, ids); ok {
+				a.start = d.text.Len()
+				a.block = block
+				for c := n.FirstChild; c != nil; c = c.NextSibling {
+					walk(c, block)
+				}
+				a.end = d.text.Len()
+				d.anchors = append(d.anchors, a)
+				return
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, block)
+		}
+	}
+
+	walk(doc, -1)
+	d.indexIdentifiers()
+	return d
+}
+
+// writeText appends a text node to both texts
+func (d *documentText) writeText(s string) {
+	for _, r := range s {
+		pos := d.text.Len()
+		size, _ := d.raw.WriteRune(r)
+		for ; size > 0; size-- {
+			d.rawPos = append(d.rawPos, pos)
+		}
+		if !unicode.IsSpace(r) {
+			d.text.WriteRune(r)
+		}
+	}
+}
+
+// writeBreak separates blocks in the raw text
+func (d *documentText) writeBreak() {
+	d.raw.WriteByte('\n')
+	d.rawPos = append(d.rawPos, d.text.Len())
+}
+
+// indexIdentifiers adds an anchor for each DOI, arXiv ID and ISBN in the text
+func (d *documentText) indexIdentifiers() {
+	d.rawPos = append(d.rawPos, d.text.Len()) // End of text
+	for _, m := range FindIdentifiers(d.raw.String()) {
+		start, end := d.rawPos[m.Start], d.rawPos[m.End]
+		d.anchors = append(d.anchors, anchorSpan{
+			start: start,
+			end:   end,
+			block: d.blockAt(start),
+			urls:  []string{m.Identifier.String()},
+		})
+	}
+}
+
+// anchorFor resolves the evidence URLs an <a> element points to. In-page
+// links are footnote markers whose URLs come from the referenced note.
+func anchorFor(n *html.Node, base *url.URL, ids map[string]*html.Node) (anchorSpan, bool) {
+	href := ""
+	for _, attr := range n.Attr {
+		if attr.Key == "href" {
+			href = strings.TrimSpace(attr.Val)
+		}
+	}
+	if href == "" || base == nil {
+		return anchorSpan{}, false
+	}
+
+	if strings.HasPrefix(href, "#") {
+		note := ids[strings.TrimPrefix(href, "#")]
+		if note == nil {
+			return anchorSpan{}, false
+		}
+		return anchorSpan{marker: true, urls: noteURLs(note, base)}, true
+	}
+
+	resolved := resolveURL(base, href)
+	if resolved == "" {
+		return anchorSpan{}, false
+	}
+	return anchorSpan{urls: []string{resolved}}, true
+}
+
+// noteURLs returns the footnote's own URL (a work cited without a link is
+// evidence there), its external links and cited identifiers
+func noteURLs(note *html.Node, base *url.URL) []string {
+	var urls []string
+	if id := attrValue(note, "id"); id != "" {
+		self := *base
+		self.Fragment = id
+		urls = append(urls, self.String())
+	}
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			for _, attr := range n.Attr {
+				if attr.Key == "href" {
+					if resolved := resolveURL(base, strings.TrimSpace(attr.Val)); resolved != "" {
+						urls = append(urls, resolved)
+					}
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(note)
+
+	var text strings.Builder
+	writeText(note, &text)
+	for _, m := range FindIdentifiers(text.String()) {
+		urls = append(urls, m.Identifier.String())
+	}
+	return urls
+}
+
+func collectIDs(n *html.Node, ids map[string]*html.Node) {
+	if n.Type == html.ElementNode {
+		for _, attr := range n.Attr {
+			if attr.Key == "id" && ids[attr.Val] == nil {
+				ids[attr.Val] = n
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collectIDs(c, ids)
+	}
+}
+
+// stripSpace removes all whitespace from s
+func stripSpace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,105 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"golang.org/x/net/html"
+)
+
+func linkFixture(t *testing.T, htmlContent, sourceURL string) ([]model.Claim, []model.Evidence) {
+	t.Helper()
+
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	claims, err := NewClaimExtractor().Extract(htmlContent)
+	if err != nil {
+		t.Fatalf("extract claims: %v", err)
+	}
+	evidence, err := NewEvidenceExtractor().Extract(htmlContent, sourceURL)
+	if err != nil {
+		t.Fatalf("extract evidence: %v", err)
+	}
+	return LinkClaims(doc, sourceURL, claims, evidence), evidence
+}
+
+func findClaim(t *testing.T, claims []model.Claim, fragment string) model.Claim {
+	t.Helper()
+	for _, claim := range claims {
+		if strings.Contains(claim.Text, fragment) {
+			return claim
+		}
+	}
+	t.Fatalf("no claim containing %q in %+v", fragment, claims)
+	return model.Claim{}
+}
+
+func TestLinkClaims_FootnoteMarkers(t *testing.T) {
+	content := `<html><body>
+	<p>The dish originated in Penang in the early 1900s.<sup class="reference"><a href="#cite_note-1">[1]</a></sup> The recipe was first printed by a local newspaper in 1932.<sup class="reference"><a href="#cite_note-2">[2]</a></sup></p>
+	<ol class="references">
+		<li id="cite_note-1"><a href="#cite_ref-1">^</a> <a class="external text" href="https://history.example.org/penang">Penang history</a></li>
+		<li id="cite_note-2"><a href="#cite_ref-2">^</a> <a class="external text" href="https://archive.example.com/1932">Archive</a></li>
+	</ol>
+	</body></html>`
+
+	claims, _ := linkFixture(t, content, "https://wiki.example.com/Dish")
+
+	origin := findClaim(t, claims, "originated in Penang")
+	if origin.Support != model.ClaimSupported {
+		t.Fatalf("Expected origin claim to be supported, got %q", origin.Support)
+	}
+	if len(origin.EvidenceRefs) != 1 || origin.EvidenceRefs[0] != "https://history.example.org/penang" {
+		t.Errorf("Expected origin claim anchored to its own footnote, got %v", origin.EvidenceRefs)
+	}
+
+	printed := findClaim(t, claims, "first printed")
+	if len(printed.EvidenceRefs) != 1 || printed.EvidenceRefs[0] != "https://archive.example.com/1932" {
+		t.Errorf("Expected second claim anchored to footnote 2 only, got %v", printed.EvidenceRefs)
+	}
+}
+
+func TestLinkClaims_InlineAndParagraphLinks(t *testing.T) {
+	content := `<html><body>
+	<p>According to <a href="https://gov.example.org/report">the official report</a>, the bridge was established in 1887.</p>
+	<p>The station was founded by the railway company in 1901. It was later extended. See <a href="https://rail.example.org/">the railway history</a> for details.</p>
+	<p>The museum was created without any documented source at all.</p>
+	<footer><a href="https://unrelated.example.net/">Footer link</a></footer>
+	</body></html>`
+
+	claims, _ := linkFixture(t, content, "https://site.example.com/page")
+
+	inline := findClaim(t, claims, "bridge was established")
+	if len(inline.EvidenceRefs) != 1 || inline.EvidenceRefs[0] != "https://gov.example.org/report" {
+		t.Errorf("Expected in-sentence link, got %v", inline.EvidenceRefs)
+	}
+
+	paragraph := findClaim(t, claims, "station was founded")
+	if len(paragraph.EvidenceRefs) != 1 || paragraph.EvidenceRefs[0] != "https://rail.example.org/" {
+		t.Errorf("Expected paragraph-level link, got %v", paragraph.EvidenceRefs)
+	}
+
+	unsupported := findClaim(t, claims, "museum was created")
+	if unsupported.Support != model.ClaimUnsupported || len(unsupported.EvidenceRefs) != 0 {
+		t.Errorf("Expected unsupported claim (footer links must not count), got %q %v", unsupported.Support, unsupported.EvidenceRefs)
+	}
+}
+
+func TestLinkClaims_OnlyReferencesKnownEvidence(t *testing.T) {
+	content := `<html><body><p>The society was founded in 1850 according to <a href="https://example.org/society">records</a>.</p></body></html>`
+
+	doc, _ := html.Parse(strings.NewReader(content))
+	claims, _ := NewClaimExtractor().Extract(content)
+
+	// No evidence extracted (e.g., filtered by the adapter)
+	linked := LinkClaims(doc, "https://site.example.com/", claims, nil)
+	if len(linked) != 1 {
+		t.Fatalf("Expected 1 claim, got %d", len(linked))
+	}
+	if linked[0].Support != model.ClaimUnsupported || len(linked[0].EvidenceRefs) != 0 {
+		t.Errorf("Expected refs limited to known evidence, got %v", linked[0].EvidenceRefs)
+	}
+}
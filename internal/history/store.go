@@ -0,0 +1,153 @@
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// sourceFile holds the original URL inside each per-source directory
+const sourceFile = "source.txt"
+
+// Store is a durable, append-only history of reports per scanned URL.
+//
+// Layout:
+//
+//	<dir>/<sha256(url)>/source.txt             original URL
+//	<dir>/<sha256(url)>/<fetched_at>.json      one report per scan
+//
+// Unlike the TTL cache, entries are never expired or overwritten.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a history store rooted at dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the store's root directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Append records a report for the given URL
+func (s *Store) Append(url string, report *model.Report) error {
+	sourceDir := s.sourceDir(url)
+	if err := os.MkdirAll(sourceDir, 0755); err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(sourceDir, sourceFile), []byte(url+"\n"), 0644); err != nil {
+		return fmt.Errorf("write source file: %w", err)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated entry
+	name := entryName(report.FetchedAt)
+	tmp, err := os.CreateTemp(sourceDir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create history entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write history entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("close history entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(sourceDir, name)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("commit history entry: %w", err)
+	}
+
+	return nil
+}
+
+// List returns all recorded reports for a URL, oldest first
+func (s *Store) List(url string) ([]*model.Report, error) {
+	sourceDir := s.sourceDir(url)
+	entries, err := os.ReadDir(sourceDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read history dir: %w", err)
+	}
+
+	var reports []*model.Report
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(sourceDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read history entry: %w", err)
+		}
+
+		var report model.Report
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("parse history entry %s: %w", entry.Name(), err)
+		}
+		reports = append(reports, &report)
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].FetchedAt.Before(reports[j].FetchedAt)
+	})
+
+	return reports, nil
+}
+
+// Sources returns every URL with recorded history, sorted
+func (s *Store) Sources() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read history dir: %w", err)
+	}
+
+	var sources []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name(), sourceFile))
+		if err != nil {
+			continue // Not a history directory
+		}
+		sources = append(sources, strings.TrimSpace(string(data)))
+	}
+
+	sort.Strings(sources)
+	return sources, nil
+}
+
+// sourceDir returns the directory holding a URL's history
+func (s *Store) sourceDir(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return filepath.Join(s.dir, hex.EncodeToString(hash[:]))
+}
+
+// entryName returns a sortable file name for a scan timestamp
+func entryName(fetchedAt time.Time) string {
+	return fetchedAt.UTC().Format("20060102T150405.000000000Z") + ".json"
+}
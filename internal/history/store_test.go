@@ -0,0 +1,100 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+func reportAt(t time.Time, index int, dead int) *model.Report {
+	report := &model.Report{
+		SourceURL: "https://example.com/page",
+		FetchedAt: t,
+		Score: model.Score{
+			Index: index,
+			Signals: []model.Signal{
+				{Type: model.SignalAccessibility, Severity: model.SeverityWarning},
+				{Type: model.SignalFreshness, Severity: model.SeverityInfo},
+			},
+		},
+	}
+	for i := 0; i < 4; i++ {
+		report.Validation = append(report.Validation, model.ValidationResult{IsDead: i < dead})
+	}
+	return report
+}
+
+func TestStore_AppendAndTrend(t *testing.T) {
+	store := NewStore(t.TempDir())
+	url := "https://example.com/page"
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Append out of order; trend must be chronological
+	for _, report := range []*model.Report{
+		reportAt(start.Add(14*24*time.Hour), 60, 2),
+		reportAt(start, 80, 0),
+		reportAt(start.Add(7*24*time.Hour), 70, 1),
+	} {
+		if err := store.Append(url, report); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	points, err := store.Trend(url)
+	if err != nil {
+		t.Fatalf("Trend failed: %v", err)
+	}
+	if len(points) != 3 {
+		t.Fatalf("Expected 3 points, got %d", len(points))
+	}
+
+	wantIndex := []int{80, 70, 60}
+	wantDeadRatio := []float64{0, 0.25, 0.5}
+	for i, point := range points {
+		if point.Index != wantIndex[i] {
+			t.Errorf("Point %d: expected index %d, got %d", i, wantIndex[i], point.Index)
+		}
+		if point.DeadRatio != wantDeadRatio[i] {
+			t.Errorf("Point %d: expected dead ratio %.2f, got %.2f", i, wantDeadRatio[i], point.DeadRatio)
+		}
+		if point.Signals[model.SeverityWarning] != 1 {
+			t.Errorf("Point %d: expected 1 warning signal, got %d", i, point.Signals[model.SeverityWarning])
+		}
+	}
+}
+
+func TestStore_SourcesAndUnknownURL(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	reports, err := store.List("https://never-scanned.example")
+	if err != nil {
+		t.Fatalf("Expected no error for unknown URL, got %v", err)
+	}
+	if len(reports) != 0 {
+		t.Errorf("Expected no reports, got %d", len(reports))
+	}
+
+	now := time.Now()
+	_ = store.Append("https://b.example", reportAt(now, 50, 0))
+	_ = store.Append("https://a.example", reportAt(now, 50, 0))
+	_ = store.Append("https://a.example", reportAt(now.Add(time.Hour), 55, 0))
+
+	sources, err := store.Sources()
+	if err != nil {
+		t.Fatalf("Sources failed: %v", err)
+	}
+	if len(sources) != 2 || sources[0] != "https://a.example" || sources[1] != "https://b.example" {
+		t.Errorf("Unexpected sources: %v", sources)
+	}
+}
+
+func TestPointFromReport_Soft404CountsAsDead(t *testing.T) {
+	report := reportAt(time.Now(), 50, 1)
+	report.Validation[1] = model.ValidationResult{IsAccessible: true, Soft404: model.Soft404NotFoundPage}
+
+	point := PointFromReport(report)
+	if point.DeadLinks != 2 {
+		t.Errorf("Expected the soft 404 counted as dead (2 dead links), got %d", point.DeadLinks)
+	}
+}
@@ -0,0 +1,62 @@
+package history
+
+import (
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// TrendPoint summarizes one recorded scan for trend queries
+type TrendPoint struct {
+	FetchedAt  time.Time                    `json:"fetched_at"`
+	Index      int                          `json:"index"`
+	Confidence string                       `json:"confidence"`
+	Claims     int                          `json:"claims"`
+	Evidence   int                          `json:"evidence"`
+	Validated  int                          `json:"validated"`
+	DeadLinks  int                          `json:"dead_links"`
+	DeadRatio  float64                      `json:"dead_ratio"`
+	Signals    map[model.SignalSeverity]int `json:"signals"` // Signal count by severity
+}
+
+// Trend returns one point per recorded scan of a URL, oldest first
+func (s *Store) Trend(url string) ([]TrendPoint, error) {
+	reports, err := s.List(url)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]TrendPoint, 0, len(reports))
+	for _, report := range reports {
+		points = append(points, PointFromReport(report))
+	}
+	return points, nil
+}
+
+// PointFromReport computes the trend metrics for a single report
+func PointFromReport(report *model.Report) TrendPoint {
+	point := TrendPoint{
+		FetchedAt:  report.FetchedAt,
+		Index:      report.Score.Index,
+		Confidence: report.Score.Confidence,
+		Claims:     len(report.Claims),
+		Evidence:   len(report.Evidence),
+		Validated:  len(report.Validation),
+		Signals:    make(map[model.SignalSeverity]int),
+	}
+
+	for _, v := range report.Validation {
+		if v.Dead() {
+			point.DeadLinks++
+		}
+	}
+	if point.Validated > 0 {
+		point.DeadRatio = float64(point.DeadLinks) / float64(point.Validated)
+	}
+
+	for _, signal := range report.Score.Signals {
+		point.Signals[signal.Severity]++
+	}
+
+	return point
+}
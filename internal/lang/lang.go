@@ -0,0 +1,334 @@
+// Package lang holds the per-language keyword packs and sentence
+// segmentation rules used for claim extraction, and detects a page's
+// language.
+package lang
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLanguage is used for pages whose language is unknown or has no pack
+const DefaultLanguage = "en"
+
+//go:embed packs/*.yaml
+var bundledPacks embed.FS
+
+// Pack is the claim keywords and sentence rules for one language
+type Pack struct {
+	Language  string        `json:"language" yaml:"language"`                       // ISO 639-1 code (en, ru, ja)
+	Claims    []string      `json:"claims" yaml:"claims"`                           // Keywords marking a claim (generic and docs adapters)
+	Wikipedia []string      `json:"wikipedia,omitempty" yaml:"wikipedia,omitempty"` // Wikipedia adapter keywords (default: claims)
+	Sections  []string      `json:"sections,omitempty" yaml:"sections,omitempty"`   // Wikipedia section headings to read past the lead
+	Legal     []string      `json:"legal,omitempty" yaml:"legal,omitempty"`         // Legal adapter keywords (default: claims)
+	Sentences SentenceRules `json:"sentences" yaml:"sentences"`
+	Source    string        `json:"-" yaml:"-"` // "builtin" or the file the pack was loaded from
+}
+
+// SentenceRules controls how text is split into sentences
+type SentenceRules struct {
+	Terminators []string `json:"terminators" yaml:"terminators"` // Characters ending a sentence (".", "。")
+	SpaceAfter  bool     `json:"space_after" yaml:"space_after"` // A terminator ends a sentence only before whitespace (skips "3.5", "e.g")
+	MinLength   int      `json:"min_length" yaml:"min_length"`   // Shorter sentences (in characters) are skipped
+	MaxLength   int      `json:"max_length" yaml:"max_length"`   // Longer sentences (in characters) are skipped
+}
+
+// Packs is a set of keyword packs by language
+type Packs struct {
+	Language string // Language forced for every page; "" = detect per page
+	packs    map[string]*Pack
+}
+
+var (
+	bundledOnce sync.Once
+	bundled     *Packs
+)
+
+// Default returns the bundled packs (en, ru, uk, de, ja)
+func Default() *Packs {
+	bundledOnce.Do(func() {
+		bundled = &Packs{packs: make(map[string]*Pack)}
+		entries, err := bundledPacks.ReadDir("packs")
+		if err != nil {
+			panic(fmt.Sprintf("bundled keyword packs: %v", err))
+		}
+		for _, entry := range entries {
+			data, err := bundledPacks.ReadFile("packs/" + entry.Name())
+			if err != nil {
+				panic(fmt.Sprintf("bundled keyword pack %s: %v", entry.Name(), err))
+			}
+			pack, err := parse(data, false)
+			if err != nil {
+				panic(fmt.Sprintf("bundled keyword pack %s: %v", entry.Name(), err))
+			}
+			pack.Source = "builtin"
+			bundled.packs[pack.Language] = pack
+		}
+	})
+	return bundled
+}
+
+// Load returns the bundled packs plus the packs in files (YAML, or JSON by
+// .json extension); a file pack replaces the bundled pack for its language.
+// A non-empty language forces that pack for every page and must exist.
+func Load(files []string, language string) (*Packs, error) {
+	packs := &Packs{Language: normalize(language), packs: make(map[string]*Pack)}
+	for code, pack := range Default().packs {
+		packs.packs[code] = pack
+	}
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read keyword pack: %w", err)
+		}
+		pack, err := parse(data, strings.EqualFold(filepath.Ext(path), ".json"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid keyword pack %s: %w", path, err)
+		}
+		pack.Source = path
+		packs.packs[pack.Language] = pack
+	}
+
+	if packs.Language != "" && packs.packs[packs.Language] == nil {
+		return nil, fmt.Errorf("no keyword pack for language %q (available: %s)", packs.Language, strings.Join(packs.Languages(), ", "))
+	}
+	return packs, nil
+}
+
+func parse(data []byte, isJSON bool) (*Pack, error) {
+	var pack Pack
+	if isJSON {
+		if err := json.Unmarshal(data, &pack); err != nil {
+			return nil, fmt.Errorf("parse JSON: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("parse YAML: %w", err)
+	}
+	if err := pack.Validate(); err != nil {
+		return nil, err
+	}
+
+	pack.Language = normalize(pack.Language)
+	pack.Claims = lower(pack.Claims)
+	pack.Wikipedia = lower(pack.Wikipedia)
+	pack.Sections = lower(pack.Sections)
+	pack.Legal = lower(pack.Legal)
+	if len(pack.Wikipedia) == 0 {
+		pack.Wikipedia = pack.Claims
+	}
+	if len(pack.Legal) == 0 {
+		pack.Legal = pack.Claims
+	}
+	return &pack, nil
+}
+
+// Validate checks that the pack names its language, has claim keywords and
+// consistent sentence rules. All problems are reported at once.
+func (p *Pack) Validate() error {
+	var errs []error
+	if normalize(p.Language) == "" {
+		errs = append(errs, errors.New("language is required"))
+	}
+	if len(p.Claims) == 0 {
+		errs = append(errs, errors.New("claims must list at least one keyword"))
+	}
+	for _, list := range [][]string{p.Claims, p.Wikipedia, p.Sections, p.Legal} {
+		for _, keyword := range list {
+			if strings.TrimSpace(keyword) == "" {
+				errs = append(errs, errors.New("keywords must not be empty"))
+				break
+			}
+		}
+	}
+
+	rules := p.Sentences
+	if len(rules.Terminators) == 0 {
+		errs = append(errs, errors.New("sentences.terminators must list at least one character"))
+	}
+	for _, terminator := range rules.Terminators {
+		if utf8.RuneCountInString(terminator) != 1 {
+			errs = append(errs, fmt.Errorf("sentence terminator %q must be a single character", terminator))
+		}
+	}
+	if rules.MinLength < 0 || rules.MaxLength <= 0 || rules.MinLength > rules.MaxLength {
+		errs = append(errs, errors.New("sentences must satisfy 0 <= min_length <= max_length, max_length > 0"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// For returns the pack for language, or the default language's pack
+func (s *Packs) For(language string) *Pack {
+	if pack := s.packs[normalize(language)]; pack != nil {
+		return pack
+	}
+	return s.packs[DefaultLanguage]
+}
+
+// Select returns the pack for a page: the forced language, else the
+// language detected from the document or URL, else the default
+func (s *Packs) Select(doc *html.Node, rawURL string) *Pack {
+	if s.Language != "" {
+		return s.For(s.Language)
+	}
+	return s.For(Detect(doc, rawURL))
+}
+
+// Languages returns the languages with a pack, sorted
+func (s *Packs) Languages() []string {
+	languages := make([]string, 0, len(s.packs))
+	for code := range s.packs {
+		languages = append(languages, code)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Detect returns a page's language: the lang attribute of its <html>
+// element, else the language subdomain of a Wikimedia wiki
+// (ru.wikipedia.org), else ""
+func Detect(doc *html.Node, rawURL string) string {
+	if root := htmlElement(doc); root != nil {
+		for _, attr := range root.Attr {
+			if attr.Key == "lang" || attr.Key == "xml:lang" {
+				if code := normalize(attr.Val); code != "" {
+					return code
+				}
+			}
+		}
+	}
+
+	if parsed, err := url.Parse(rawURL); err == nil {
+		labels := strings.Split(strings.ToLower(parsed.Hostname()), ".")
+		if len(labels) >= 3 && isWikiDomain(labels[len(labels)-2]+"."+labels[len(labels)-1]) {
+			return normalize(labels[0])
+		}
+	}
+	return ""
+}
+
+// wikiDomains are the Wikimedia projects with per-language subdomains
+var wikiDomains = []string{
+	"wikipedia.org", "wiktionary.org", "wikiquote.org", "wikisource.org",
+	"wikibooks.org", "wikinews.org", "wikivoyage.org", "wikiversity.org",
+}
+
+func isWikiDomain(domain string) bool {
+	for _, wiki := range wikiDomains {
+		if domain == wiki {
+			return true
+		}
+	}
+	return false
+}
+
+func htmlElement(n *html.Node) *html.Node {
+	if n == nil {
+		return nil
+	}
+	if n.Type == html.ElementNode && n.Data == "html" {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := htmlElement(c); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// normalize reduces a language tag to its primary subtag ("uk-UA" -> "uk")
+func normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	for _, r := range tag {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+	if len(tag) < 2 || len(tag) > 3 {
+		return ""
+	}
+	return tag
+}
+
+func lower(keywords []string) []string {
+	if len(keywords) == 0 {
+		return nil
+	}
+	lowered := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		lowered[i] = strings.ToLower(keyword)
+	}
+	return lowered
+}
+
+// SplitSentences splits text into the sentences the pack's rules accept
+func (p *Pack) SplitSentences(text string) []string {
+	text = strings.ReplaceAll(text, "\n", " ")
+	runes := []rune(text)
+
+	var sentences []string
+	var current strings.Builder
+	for i, r := range runes {
+		current.WriteRune(r)
+		if !p.isTerminator(r) {
+			continue
+		}
+		// Look ahead to avoid splitting on abbreviations and decimals
+		if p.Sentences.SpaceAfter && (i+1 >= len(runes) || (runes[i+1] != ' ' && runes[i+1] != '\t')) {
+			continue
+		}
+		sentences = p.appendSentence(sentences, current.String())
+		current.Reset()
+	}
+
+	// Add remaining text (paragraphs usually end without trailing whitespace)
+	if current.Len() > 0 {
+		sentences = p.appendSentence(sentences, current.String())
+	}
+	return sentences
+}
+
+func (p *Pack) isTerminator(r rune) bool {
+	for _, terminator := range p.Sentences.Terminators {
+		if t, _ := utf8.DecodeRuneInString(terminator); t == r {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Pack) appendSentence(sentences []string, sentence string) []string {
+	sentence = strings.TrimSpace(sentence)
+	if n := utf8.RuneCountInString(sentence); n >= p.Sentences.MinLength && n <= p.Sentences.MaxLength {
+		sentences = append(sentences, sentence)
+	}
+	return sentences
+}
+
+// Match returns the first keyword found in sentence (case-insensitively)
+func Match(sentence string, keywords []string) (string, bool) {
+	lowered := strings.ToLower(sentence)
+	for _, keyword := range keywords {
+		if strings.Contains(lowered, keyword) {
+			return keyword, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,146 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func writePackFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDefault(t *testing.T) {
+	packs := Default()
+	if got := strings.Join(packs.Languages(), ","); got != "de,en,ja,ru,uk" {
+		t.Errorf("Expected the bundled languages, got %s", got)
+	}
+	for _, code := range packs.Languages() {
+		pack := packs.For(code)
+		if pack.Language != code || len(pack.Wikipedia) == 0 || len(pack.Legal) == 0 {
+			t.Errorf("%s: incomplete bundled pack %+v", code, pack)
+		}
+	}
+	if packs.For("fr").Language != DefaultLanguage {
+		t.Error("Expected languages without a pack to fall back to English")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		page, url, want string
+	}{
+		{`<html lang="uk-UA"><body></body></html>`, "https://example.com/", "uk"},
+		{`<html xml:lang="de"><body></body></html>`, "https://example.com/", "de"},
+		{`<html><body></body></html>`, "https://ja.wikipedia.org/wiki/%E5%AF%BF%E5%8F%B8", "ja"},
+		{`<html><body></body></html>`, "https://ru.m.wikipedia.org/wiki/Борщ", "ru"},
+		{`<html lang="ru"><body></body></html>`, "https://en.wikipedia.org/wiki/Borscht", "ru"},
+		{`<html><body></body></html>`, "https://www.example.com/", ""},
+		{`<html lang="x-klingon"><body></body></html>`, "https://example.com/", ""},
+	}
+	for _, tt := range tests {
+		doc, err := html.Parse(strings.NewReader(tt.page))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Detect(doc, tt.url); got != tt.want {
+			t.Errorf("Detect(%s, %s) = %q, want %q", tt.page, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestPack_SplitSentences(t *testing.T) {
+	ja := Default().For("ja")
+	got := ja.SplitSentences("寿司は江戸時代に発祥した料理である。最初の握り寿司は十九世紀に登場した。短い。")
+	want := []string{"寿司は江戸時代に発祥した料理である。", "最初の握り寿司は十九世紀に登場した。"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected CJK sentences split on 。 without spaces, got %q", got)
+	}
+
+	ru := Default().For("ru")
+	got = ru.SplitSentences("Борщ впервые упоминается в источниках XVI века в 1584 г. на территории Руси. Рецепт версии 2.5 описан позже в поваренных книгах.")
+	if len(got) != 2 || !strings.HasSuffix(got[0], "г.") {
+		t.Errorf("Expected two Cyrillic sentences measured in characters, got %q", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	de := Default().For("de")
+	if keyword, ok := Match("Das Gericht wurde ERSTMALS 1584 erwähnt.", de.Wikipedia); !ok || keyword != "erstmals" {
+		t.Errorf("Expected a case-insensitive match on erstmals, got %q, %v", keyword, ok)
+	}
+	if _, ok := Match("Das Wetter war schön.", de.Claims); ok {
+		t.Error("Expected no keyword in a plain sentence")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	frPath := writePackFile(t, "fr.yaml", `
+language: FR
+claims: [Originaire, "selon ", fondé]
+sentences:
+  terminators: [".", "!", "?"]
+  space_after: true
+  min_length: 20
+  max_length: 400
+`)
+	enPath := writePackFile(t, "en.json", `{"language": "en", "claims": ["pioneered"], "sentences": {"terminators": ["."], "space_after": true, "min_length": 1, "max_length": 100}}`)
+
+	packs, err := Load([]string{frPath, enPath}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr := packs.For("fr")
+	if fr.Source != frPath || fr.Claims[0] != "originaire" || fr.Wikipedia[0] != "originaire" || fr.Legal[0] != "originaire" {
+		t.Errorf("Expected lowercased claim keywords reused by every adapter, got %+v", fr)
+	}
+	if en := packs.For("en"); en.Source != enPath || en.Claims[0] != "pioneered" {
+		t.Errorf("Expected the file to replace the bundled English pack, got %+v", en)
+	}
+	if Default().For("en").Claims[0] == "pioneered" {
+		t.Error("Loading packs must not change the bundled packs")
+	}
+
+	forced, err := Load(nil, "ja")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, _ := html.Parse(strings.NewReader(`<html lang="en"></html>`))
+	if forced.Select(doc, "https://example.com/").Language != "ja" {
+		t.Error("Expected the forced language to override detection")
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"no language", "claims: [a]\nsentences: {terminators: ['.'], max_length: 10}", "language is required"},
+		{"no keywords", "language: fr\nsentences: {terminators: ['.'], max_length: 10}", "at least one keyword"},
+		{"terminator", "language: fr\nclaims: [a]\nsentences: {terminators: ['..'], max_length: 10}", "single character"},
+		{"lengths", "language: fr\nclaims: [a]\nsentences: {terminators: ['.'], min_length: 20, max_length: 10}", "min_length <= max_length"},
+		{"syntax", "language: [", "parse YAML"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load([]string{writePackFile(t, "pack.yaml", tt.content)}, "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := Load(nil, "fr"); err == nil || !strings.Contains(err.Error(), "no keyword pack") {
+		t.Errorf("Expected an error forcing a language without a pack, got %v", err)
+	}
+}
@@ -0,0 +1,383 @@
+package markup
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	mdFence       = regexp.MustCompile("^ *(```+|~~~+)")
+	mdHeading     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ ]+(.*?))?(?:[ ]+#+)? *$`)
+	mdSetext      = regexp.MustCompile(`^ {0,3}(=+|-+) *$`)
+	mdBreak       = regexp.MustCompile(`^ {0,3}([-*_])( *[-*_]){2,} *$`)
+	mdLinkDef     = regexp.MustCompile(`^ {0,3}\[([^\]^][^\]]*)\]: *(<[^>]*>|\S+)(?: +(?:"[^"]*"|'[^']*'|\([^)]*\)))? *$`)
+	mdFootnoteDef = regexp.MustCompile(`^ {0,3}\[\^([^\]\s]+)\]: ?(.*)$`)
+	mdQuote       = regexp.MustCompile(`^ {0,3}> ?`)
+	mdListItem    = regexp.MustCompile(`^ *(?:[-*+]|\d{1,9}[.)])(?: +|$)(?:\[[ xX]\] +)?`)
+	mdTableRule   = regexp.MustCompile(`^ *\|? *:?-+:? *(?:\| *:?-+:? *)*\|? *$`)
+	mdHTMLBlock   = regexp.MustCompile(`^ {0,3}<(?:/?[A-Za-z][A-Za-z0-9-]*(?:[ />]|$)|!--)`)
+
+	mdFootnoteRef = regexp.MustCompile(`^\[\^([^\]\s]+)\]`)
+	mdAutolink    = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]{1,31}:[^\s<>]*)>`)
+	mdInlineHTML  = regexp.MustCompile(`^(?:</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>|<!--[\s\S]*?-->)`)
+)
+
+// markdown converts CommonMark with the GitHub extensions docs rely on
+// (tables, autolinked URLs, footnotes)
+type markdown struct {
+	*converter
+	defs map[string]string // Link reference definitions by normalized label
+}
+
+// Markdown converts a Markdown source to HTML. Inline, reference-style and
+// autolinked URLs become anchors (reference-style ones marked with
+// RefAttr), and footnotes are collected into a footnotes section.
+func Markdown(src string, opts Options) Document {
+	m := &markdown{converter: newConverter(opts), defs: make(map[string]string)}
+	lines, title := frontMatter(splitLines(src))
+	m.metaTitle = title
+	m.parse(lines)
+	return m.render(m.inline)
+}
+
+// parse splits lines into blocks, collecting link and footnote definitions
+func (m *markdown) parse(lines []string) {
+	var (
+		cur     *block // Open paragraph, list item, quote or footnote
+		note    string // Label of the footnote being defined
+		inList  bool   // Indented lines continue a list rather than start code
+		fence   string // Closing fence of the open code block
+		comment bool   // Inside a multi-line HTML comment
+		rawHTML bool   // Inside an HTML block (ends at a blank line)
+	)
+	flush := func() {
+		if cur != nil {
+			if note != "" {
+				m.notes.define(note, cur.text)
+			} else {
+				m.add(cur.tag, cur.text)
+			}
+		}
+		cur, note = nil, ""
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		case comment:
+			comment = !strings.Contains(line, "-->")
+			continue
+		case rawHTML:
+			if trimmed == "" {
+				rawHTML = false
+			} else {
+				m.blocks = append(m.blocks, block{tag: "raw", text: line})
+			}
+			continue
+		}
+
+		if trimmed == "" {
+			// A footnote continues past blank lines while indented
+			if note != "" && i+1 < len(lines) && indent(lines[i+1]) >= 4 && !isBlank(lines[i+1]) {
+				cur.text += "\n"
+				continue
+			}
+			flush()
+			continue
+		}
+
+		if f := mdFence.FindStringSubmatch(line); f != nil {
+			flush()
+			fence = f[1]
+			inList = inList && indent(line) > 0
+			continue
+		}
+
+		// Indented continuation of a list item or footnote
+		if cur == nil && indent(line) >= 4 && !inList {
+			continue // Indented code block
+		}
+		if cur != nil && note != "" && indent(line) > 0 {
+			cur.text += " " + trimmed
+			continue
+		}
+
+		if cur != nil && cur.tag == "p" {
+			if s := mdSetext.FindStringSubmatch(line); s != nil {
+				tag := "h1"
+				if s[1][0] == '-' {
+					tag = "h2"
+				}
+				cur.tag = tag
+				flush()
+				inList = false
+				continue
+			}
+		}
+
+		switch {
+		case mdBreak.MatchString(line):
+			flush()
+			inList = false
+		case mdHeading.MatchString(line):
+			flush()
+			h := mdHeading.FindStringSubmatch(line)
+			m.add("h"+string(rune('0'+len(h[1]))), h[2])
+			inList = false
+		case mdFootnoteDef.MatchString(line):
+			flush()
+			f := mdFootnoteDef.FindStringSubmatch(line)
+			cur, note = &block{tag: "li", text: f[2]}, f[1]
+			inList = false
+		case cur == nil && mdLinkDef.MatchString(line):
+			d := mdLinkDef.FindStringSubmatch(line)
+			label := normalizeLabel(d[1])
+			if _, exists := m.defs[label]; !exists {
+				m.defs[label] = strings.Trim(d[2], "<>")
+			}
+		case mdHTMLBlock.MatchString(line) && (cur == nil || cur.tag != "p"):
+			flush()
+			if strings.HasPrefix(trimmed, "<!--") {
+				comment = !strings.Contains(line, "-->")
+				continue
+			}
+			m.blocks = append(m.blocks, block{tag: "raw", text: line})
+			rawHTML = true
+		case mdQuote.MatchString(line):
+			text := line
+			for mdQuote.MatchString(text) {
+				text = mdQuote.ReplaceAllString(text, "")
+			}
+			if strings.TrimSpace(text) == "" {
+				flush()
+				continue
+			}
+			if cur == nil || cur.tag != "quote" {
+				flush()
+				cur = &block{tag: "quote"}
+			}
+			cur.text += " " + strings.TrimSpace(text)
+		case strings.HasPrefix(trimmed, "|"):
+			flush()
+			if !mdTableRule.MatchString(line) {
+				m.blocks = append(m.blocks, block{tag: "tr", cells: tableCells(trimmed)})
+			}
+			inList = false
+		case mdListItem.MatchString(line):
+			flush()
+			cur = &block{tag: "li", text: mdListItem.ReplaceAllString(line, "")}
+			inList = true
+		case cur != nil:
+			cur.text += " " + trimmed // Lazy continuation
+		default:
+			if indent(line) == 0 {
+				inList = false
+			}
+			cur = &block{tag: "p", text: trimmed}
+		}
+	}
+	flush()
+}
+
+// tableCells splits a table row on unescaped pipes
+func tableCells(row string) []string {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	var cells []string
+	start := 0
+	for i := 0; i < len(row); i++ {
+		switch row[i] {
+		case '\\':
+			i++
+		case '|':
+			cells = append(cells, strings.TrimSpace(row[start:i]))
+			start = i + 1
+		}
+	}
+	return append(cells, strings.TrimSpace(row[start:]))
+}
+
+// inline converts inline Markdown to HTML
+func (m *markdown) inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+		case c == '`':
+			n := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+			end := strings.Index(s[i+n:], s[i:i+n])
+			if end < 0 {
+				b.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			b.WriteString("<code>" + html.EscapeString(strings.TrimSpace(s[i+n:i+n+end])) + "</code>")
+			i += 2*n + end
+		case c == '!' && strings.HasPrefix(s[i+1:], "["):
+			// Images are dropped (alt text is not prose)
+			if _, next, ok := m.bracket(s, i+1); ok {
+				i = next
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		case c == '[':
+			if out, next, ok := m.bracket(s, i); ok {
+				b.WriteString(out)
+				i = next
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		case c == '<':
+			if a := mdAutolink.FindStringSubmatch(s[i:]); a != nil {
+				b.WriteString(m.link(a[1], "", html.EscapeString(a[1])))
+				i += len(a[0])
+			} else if raw := mdInlineHTML.FindString(s[i:]); raw != "" {
+				b.WriteString(raw)
+				i += len(raw)
+			} else {
+				b.WriteString("&lt;")
+				i++
+			}
+		case (c == 'h' || c == 'w') && (i == 0 || !isWordByte(s[i-1])):
+			if out, n := m.autolink(s[i:]); n > 0 {
+				b.WriteString(out)
+				i += n
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		case c == '*':
+			i++ // Emphasis delimiter
+		case c == '~' && strings.HasPrefix(s[i:], "~~"):
+			i += 2 // Strikethrough delimiter
+		case c == '_':
+			// Underscores inside words (snake_case) are text
+			if i > 0 && i+1 < len(s) && isWordByte(s[i-1]) && isWordByte(s[i+1]) {
+				b.WriteByte(c)
+			}
+			i++
+		default:
+			next := i + 1
+			for next < len(s) && !strings.ContainsRune("\\`![<hw*~_", rune(s[next])) {
+				next++
+			}
+			b.WriteString(html.EscapeString(s[i:next]))
+			i = next
+		}
+	}
+	return b.String()
+}
+
+// bracket converts a footnote reference, link or image starting at s[i]
+// ('['), returning the markup and the index after it
+func (m *markdown) bracket(s string, i int) (string, int, bool) {
+	if f := mdFootnoteRef.FindStringSubmatch(s[i:]); f != nil {
+		if marker, ok := m.notes.marker(f[1]); ok {
+			return marker, i + len(f[0]), true
+		}
+	}
+
+	end := closingBracket(s, i)
+	if end < 0 {
+		return "", 0, false
+	}
+	text := s[i+1 : end]
+	rest := s[end+1:]
+
+	// Inline link: [text](dest "title")
+	if strings.HasPrefix(rest, "(") {
+		if dest, n, ok := linkDestination(rest); ok {
+			return m.link(dest, "", m.inline(text)), end + 1 + n, true
+		}
+	}
+
+	// Full or collapsed reference: [text][label], [label][]
+	if strings.HasPrefix(rest, "[") {
+		if close := strings.IndexByte(rest, ']'); close > 0 {
+			label := rest[1:close]
+			if label == "" {
+				label = text
+			}
+			if dest, ok := m.defs[normalizeLabel(label)]; ok {
+				return m.link(dest, normalizeLabel(label), m.inline(text)), end + 2 + close, true
+			}
+		}
+	}
+
+	// Shortcut reference: [label]
+	if dest, ok := m.defs[normalizeLabel(text)]; ok {
+		return m.link(dest, normalizeLabel(text), m.inline(text)), end + 1, true
+	}
+	return "", 0, false
+}
+
+// closingBracket returns the index of the ']' matching s[open], skipping
+// escapes and nested brackets
+func closingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// linkDestination parses "(dest "title")" at the start of s, returning
+// the destination and the length consumed
+func linkDestination(s string) (string, int, bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			inner := strings.TrimSpace(s[1:i])
+			if strings.HasPrefix(inner, "<") {
+				if close := strings.IndexByte(inner, '>'); close > 0 {
+					return inner[1:close], i + 1, true
+				}
+			}
+			if fields := strings.Fields(inner); len(fields) > 0 {
+				return fields[0], i + 1, true
+			}
+			return "", i + 1, true
+		}
+	}
+	return "", 0, false
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
@@ -0,0 +1,329 @@
+// Package markup converts Markdown and reStructuredText sources to plain
+// HTML so docs kept in a repository can be scanned like published pages.
+// Only what matters for claims and evidence is kept: headings, paragraphs,
+// lists, quotes, table cells, links and footnotes. Code blocks, directives
+// and images are dropped.
+package markup
+
+import (
+	"mime"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a converted source
+type Document struct {
+	Title string // Front matter title, else the first heading
+	HTML  string // Complete HTML document
+}
+
+// Options tune conversion
+type Options struct {
+	// Link rewrites each link target before it is written (nil keeps
+	// targets as written)
+	Link func(href string) string
+}
+
+// Markup languages recognized by Format
+const (
+	FormatMarkdown = "markdown"
+	FormatRST      = "rst"
+)
+
+// Format names the markup language of a document from its media type, or
+// from its file extension when the media type is generic (text/plain,
+// application/octet-stream or missing). It returns "" for anything else.
+func Format(contentType, name string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/markdown", "text/x-markdown":
+		return FormatMarkdown
+	case "text/x-rst", "text/prs.fallenstein.rst":
+		return FormatRST
+	case "", "text/plain", "application/octet-stream":
+	default:
+		return ""
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown", ".mdown", ".mkd":
+		return FormatMarkdown
+	case ".rst", ".rest":
+		return FormatRST
+	}
+	return ""
+}
+
+// Convert converts a source in the given Format
+func Convert(format, src string, opts Options) Document {
+	if format == FormatRST {
+		return RST(src, opts)
+	}
+	return Markdown(src, opts)
+}
+
+// Reference-style links carry their label in this attribute, and footnote
+// definitions are rendered as <section class="footnotes"><ol><li id="fn-…">
+// with markers <sup><a href="#fn-…">N</a></sup> (GitHub's layout)
+const (
+	RefAttr       = "data-ref"
+	FootnoteClass = "footnotes"
+)
+
+// bareURL matches URLs written as plain text
+var bareURL = regexp.MustCompile(`^(?:https?://|www\.)[^\s<>"]+`)
+
+// block is one converted block element
+type block struct {
+	tag   string   // p, h1-h6, li, quote, tr or raw
+	text  string   // Source text, converted inline when rendered
+	cells []string // Table cells (tr)
+}
+
+// converter holds state shared by the Markdown and RST converters
+type converter struct {
+	opts      Options
+	metaTitle string // From front matter
+	title     string // First heading, unconverted
+	blocks    []block
+	notes     footnotes
+}
+
+func newConverter(opts Options) *converter {
+	return &converter{opts: opts, notes: footnotes{defs: make(map[string]string)}}
+}
+
+// add appends a block, skipping empty text
+func (c *converter) add(tag, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	if c.title == "" && isHeading(tag) {
+		c.title = text
+	}
+	c.blocks = append(c.blocks, block{tag: tag, text: text})
+}
+
+// render assembles the HTML document, converting text with inline
+func (c *converter) render(inline func(string) string) Document {
+	var body strings.Builder
+	open := ""
+	for _, b := range c.blocks {
+		group := map[string]string{"li": "ul", "tr": "table", "quote": "blockquote"}[b.tag]
+		if group != open {
+			if open != "" {
+				body.WriteString("</" + open + ">\n")
+			}
+			if group != "" {
+				body.WriteString("<" + group + ">\n")
+			}
+			open = group
+		}
+
+		switch b.tag {
+		case "raw":
+			body.WriteString(b.text)
+		case "tr":
+			body.WriteString("<tr>")
+			for _, cell := range b.cells {
+				body.WriteString("<td>" + inline(cell) + "</td>")
+			}
+			body.WriteString("</tr>")
+		case "quote":
+			body.WriteString("<p>" + inline(b.text) + "</p>")
+		default:
+			body.WriteString("<" + b.tag + ">" + inline(b.text) + "</" + b.tag + ">")
+		}
+		body.WriteString("\n")
+	}
+	if open != "" {
+		body.WriteString("</" + open + ">\n")
+	}
+	body.WriteString(c.notes.render(inline))
+
+	title := c.metaTitle
+	if title == "" {
+		title = plainText(inline(c.title))
+	}
+	return Document{
+		Title: title,
+		HTML: "<html><head><title>" + html.EscapeString(title) + "</title></head><body><main>\n" +
+			body.String() + "</main></body></html>\n",
+	}
+}
+
+// link writes an anchor; ref is the reference label of reference-style links
+func (c *converter) link(href, ref, text string) string {
+	if c.opts.Link != nil {
+		href = c.opts.Link(href)
+	}
+	attrs := `href="` + html.EscapeString(href) + `"`
+	if ref != "" {
+		attrs += " " + RefAttr + `="` + html.EscapeString(ref) + `"`
+	}
+	return "<a " + attrs + ">" + text + "</a>"
+}
+
+// autolink links a plain-text URL at the start of s, returning the markup
+// and the number of bytes consumed
+func (c *converter) autolink(s string) (string, int) {
+	match := trimURL(bareURL.FindString(s))
+	if match == "" || match == "www." {
+		return "", 0
+	}
+	href := match
+	if strings.HasPrefix(href, "www.") {
+		href = "https://" + href
+	}
+	return c.link(href, "", html.EscapeString(match)), len(match)
+}
+
+// trimURL drops sentence punctuation and unbalanced closing brackets from
+// the end of a plain-text URL
+func trimURL(s string) string {
+	for len(s) > 0 {
+		switch last := s[len(s)-1]; {
+		case strings.IndexByte(".,;:!?'\"*_", last) >= 0:
+			s = s[:len(s)-1]
+		case last == ')' && strings.Count(s, "(") < strings.Count(s, ")"),
+			last == ']' && strings.Count(s, "[") < strings.Count(s, "]"):
+			s = s[:len(s)-1]
+		default:
+			return s
+		}
+	}
+	return s
+}
+
+// footnotes collects footnote definitions and numbers them in the order
+// they are first referenced
+type footnotes struct {
+	defs   map[string]string // label → source text
+	order  []string          // Labels in definition order
+	refs   []string          // Labels in first-reference order
+	number map[string]int
+}
+
+func (f *footnotes) define(label, text string) {
+	if _, exists := f.defs[label]; exists {
+		return
+	}
+	f.defs[label] = strings.TrimSpace(text)
+	f.order = append(f.order, label)
+}
+
+// marker returns the reference marker for a defined label
+func (f *footnotes) marker(label string) (string, bool) {
+	if _, ok := f.defs[label]; !ok {
+		return "", false
+	}
+	if f.number == nil {
+		f.number = make(map[string]int)
+	}
+	n, seen := f.number[label]
+	if !seen {
+		f.refs = append(f.refs, label)
+		n = len(f.refs)
+		f.number[label] = n
+	}
+	return `<sup><a href="#fn-` + noteID(label) + `">` + strconv.Itoa(n) + "</a></sup>", true
+}
+
+// render writes the footnotes section: referenced notes first, then
+// unreferenced ones in definition order
+func (f *footnotes) render(inline func(string) string) string {
+	if len(f.order) == 0 {
+		return ""
+	}
+	labels := append([]string(nil), f.refs...)
+	for _, label := range f.order {
+		if _, referenced := f.number[label]; !referenced {
+			labels = append(labels, label)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(`<section class="` + FootnoteClass + `"><ol>` + "\n")
+	for _, label := range labels {
+		b.WriteString(`<li id="fn-` + noteID(label) + `">` + inline(f.defs[label]) + "</li>\n")
+	}
+	b.WriteString("</ol></section>\n")
+	return b.String()
+}
+
+// noteID makes a footnote label safe for an id attribute
+func noteID(label string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '-'
+	}, label)
+}
+
+// normalizeLabel folds a link label for lookup
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+func isHeading(tag string) bool {
+	return len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6'
+}
+
+// frontMatter strips a YAML (---) or TOML (+++) front matter block and
+// returns the YAML title, if any
+func frontMatter(lines []string) ([]string, string) {
+	if len(lines) == 0 || (lines[0] != "---" && lines[0] != "+++") {
+		return lines, ""
+	}
+	fence := lines[0]
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != fence && !(fence == "---" && lines[i] == "...") {
+			continue
+		}
+		var meta struct {
+			Title string `yaml:"title"`
+		}
+		if fence == "---" {
+			_ = yaml.Unmarshal([]byte(strings.Join(lines[1:i], "\n")), &meta)
+		}
+		return lines[i+1:], meta.Title
+	}
+	return lines, ""
+}
+
+// splitLines normalizes line endings and tabs
+func splitLines(src string) []string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	src = strings.ReplaceAll(src, "\t", "    ")
+	return strings.Split(src, "\n")
+}
+
+// indent returns the number of leading spaces
+func indent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// plainText strips tags and entities from converted inline markup
+func plainText(markup string) string {
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(markup))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return strings.TrimSpace(b.String())
+		case html.TextToken:
+			b.Write(z.Text())
+		}
+	}
+}
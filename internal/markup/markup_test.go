@@ -0,0 +1,151 @@
+package markup
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	src := `---
+title: Install Guide
+---
+# Installing *Entropia*
+
+Entropia was first released in 2024 according to the [changelog](../CHANGELOG.md "Changes").
+See the [API reference][api], the [Guide] and snake_case names[^src].
+
+- Introduced by the core team, see <https://example.com/blog>.
+- Bare link https://example.org/page.
+
+` + "```go\nfmt.Println(\"[hidden](https://example.com/code)\")\n```" + `
+
+    indented https://example.com/indented
+
+| Feature | Since |
+|---------|-------|
+| Scan    | [v1](https://example.com/v1) |
+
+[api]: ./api.md
+[guide]: https://example.com/guide "Guide"
+[^src]: Source: [the announcement](https://example.com/announce),
+    page 2.
+`
+	doc := Markdown(src, Options{})
+
+	if doc.Title != "Install Guide" {
+		t.Errorf("Title = %q, want the front matter title", doc.Title)
+	}
+	for _, want := range []string{
+		`<h1>Installing Entropia</h1>`,
+		`according to the <a href="../CHANGELOG.md">changelog</a>.`,
+		`<a href="./api.md" data-ref="api">API reference</a>`,
+		`<a href="https://example.com/guide" data-ref="guide">Guide</a>`,
+		`snake_case names<sup><a href="#fn-src">1</a></sup>`,
+		`<li>Introduced by the core team, see <a href="https://example.com/blog">`,
+		`<a href="https://example.org/page">https://example.org/page</a>.</li>`,
+		`<td><a href="https://example.com/v1">v1</a></td>`,
+		`<li id="fn-src">Source: <a href="https://example.com/announce">the announcement</a>, page 2.</li>`,
+	} {
+		if !strings.Contains(doc.HTML, want) {
+			t.Errorf("HTML missing %q:\n%s", want, doc.HTML)
+		}
+	}
+	for _, hidden := range []string{"example.com/code", "example.com/indented", "title:", "[api]:"} {
+		if strings.Contains(doc.HTML, hidden) {
+			t.Errorf("HTML should not contain %q:\n%s", hidden, doc.HTML)
+		}
+	}
+}
+
+func TestMarkdown_LinkOption(t *testing.T) {
+	doc := Markdown("# Home\n\nSee [the readme](/README.md) and [docs](guide.md).\n", Options{
+		Link: func(href string) string { return strings.Replace(href, "/", "file:///repo/", 1) },
+	})
+	if doc.Title != "Home" {
+		t.Errorf("Title = %q, want the first heading", doc.Title)
+	}
+	if !strings.Contains(doc.HTML, `href="file:///repo/README.md"`) || !strings.Contains(doc.HTML, `href="guide.md"`) {
+		t.Errorf("Links not rewritten:\n%s", doc.HTML)
+	}
+}
+
+func TestRST(t *testing.T) {
+	src := `.. _intro:
+
+=========
+Overview
+=========
+
+Entropia was first released in 2024, according to ` + "`the changelog <../CHANGELOG.md>`_" + `.
+See the ` + "`API reference`_" + `, Python_ and the :doc:` + "`install guide <install>`" + ` [1]_ [#]_.
+
+Details
+-------
+
+* It was introduced by the team, see https://example.com/blog.
+* Anonymous ` + "`link`__" + ` here.
+
+.. note::
+   This note was first written according to policy.
+
+.. code-block:: python
+
+   print("https://example.com/code")
+
+Example::
+
+    https://example.com/literal
+
+.. _API reference: https://example.com/api
+.. _Python: https://python.org
+__ https://example.com/anon
+
+.. [1] Source: https://example.com/announce
+.. [#] Second note, see :rfc:` + "`2616`" + `.
+`
+	doc := RST(src, Options{})
+
+	if doc.Title != "Overview" {
+		t.Errorf("Title = %q, want Overview", doc.Title)
+	}
+	for _, want := range []string{
+		`<h1>Overview</h1>`,
+		`<h2>Details</h2>`,
+		`according to <a href="../CHANGELOG.md">the changelog</a>.`,
+		`<a href="https://example.com/api" data-ref="api reference">API reference</a>`,
+		`<a href="https://python.org" data-ref="python">Python</a>`,
+		`<a href="install.rst">install guide</a> <sup><a href="#fn-1">1</a></sup> <sup><a href="#fn--1">2</a></sup>.`,
+		`<li>Anonymous <a href="https://example.com/anon">link</a> here.</li>`,
+		`<p>This note was first written according to policy.</p>`,
+		`<p>Example:</p>`,
+		`<li id="fn-1">Source: <a href="https://example.com/announce">`,
+		`see <a href="https://datatracker.ietf.org/doc/html/rfc2616">RFC 2616</a>.</li>`,
+	} {
+		if !strings.Contains(doc.HTML, want) {
+			t.Errorf("HTML missing %q:\n%s", want, doc.HTML)
+		}
+	}
+	for _, hidden := range []string{"example.com/code", "example.com/literal", "_intro"} {
+		if strings.Contains(doc.HTML, hidden) {
+			t.Errorf("HTML should not contain %q:\n%s", hidden, doc.HTML)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		contentType, name, want string
+	}{
+		{"text/markdown; charset=utf-8", "", FormatMarkdown},
+		{"text/x-rst", "page", FormatRST},
+		{"text/plain; charset=utf-8", "/docs/README.md", FormatMarkdown},
+		{"", "guide.rst", FormatRST},
+		{"text/html", "/docs/page.md", ""},
+		{"text/plain", "notes.txt", ""},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.contentType, tt.name); got != tt.want {
+			t.Errorf("Format(%q, %q) = %q, want %q", tt.contentType, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,437 @@
+package markup
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	rstDirective = regexp.MustCompile(`^ *\.\. +([A-Za-z][\w:.+-]*)::(.*)$`)
+	rstTarget    = regexp.MustCompile("^ *\\.\\. +_(`[^`]+`|[^:]+): *(.*)$")
+	rstAnonymous = regexp.MustCompile(`^ *(?:\.\. +__:|__) +(.*)$`)
+	rstNote      = regexp.MustCompile(`^ *\.\. +\[([^\]\s]+)\](?: +(.*))?$`)
+	rstComment   = regexp.MustCompile(`^ *\.\.(?: |$)`)
+	rstField     = regexp.MustCompile("^ *:[^:`\\s][^:`]*:(?: |$)")
+	rstBullet    = regexp.MustCompile(`^ *(?:[-*+•‣⁃]|\d+[.)]|#[.)]|\((?:\d+|#|[A-Za-z])\)) +`)
+	rstGridRule  = regexp.MustCompile(`^ *\+[-=+]+\+ *$`)
+	rstTableRule = regexp.MustCompile(`^ *=+(?: +=+)+ *$`)
+	rstEmbedded  = regexp.MustCompile("`([^`<]*[^`<\\s])\\s*<([^<>`]+)>`_(?:[^_]|$)")
+	rstTitled    = regexp.MustCompile(`^(?s)(.*?)\s*<([^<>]+)>$`)
+
+	rstRole    = regexp.MustCompile("^:([A-Za-z][\\w.+:-]*):`([^`]+)`")
+	rstFootRef = regexp.MustCompile(`^\[([^\]\s]+)\]_`)
+	rstWordRef = regexp.MustCompile(`^([A-Za-z0-9]+(?:[-._+:][A-Za-z0-9]+)*)(__?)(?:[^\w]|$)`)
+	rstWord    = regexp.MustCompile(`^[A-Za-z0-9]+`)
+)
+
+// rstProse lists directives whose bodies are prose (admonitions, version
+// notes, sidebars); the bodies of all other directives are skipped
+var rstProse = map[string]bool{
+	"admonition": true, "attention": true, "caution": true, "container": true,
+	"danger": true, "deprecated": true, "epigraph": true, "error": true,
+	"highlights": true, "hint": true, "important": true, "note": true,
+	"pull-quote": true, "seealso": true, "sidebar": true, "tip": true,
+	"topic": true, "versionadded": true, "versionchanged": true, "warning": true,
+}
+
+// rst converts reStructuredText, including the Sphinx roles docs link with
+type rst struct {
+	*converter
+	targets   map[string]string // Named hyperlink targets by normalized name
+	anonymous []string          // Anonymous targets in document order
+	styles    []string          // Section adornment styles in order of appearance
+	nextAnon  int               // Anonymous references consumed
+	auto      map[string]int    // Auto-numbered footnotes (#, *) defined/referenced
+}
+
+// RST converts a reStructuredText source to HTML. Hyperlink references,
+// standalone URLs and :doc: roles become anchors (references to named
+// targets marked with RefAttr), and footnotes and citations are collected
+// into a footnotes section.
+func RST(src string, opts Options) Document {
+	r := &rst{
+		converter: newConverter(opts),
+		targets:   make(map[string]string),
+		auto:      make(map[string]int),
+	}
+	lines := splitLines(src)
+
+	// Embedded targets (`text <url>`_) may be referenced before they appear
+	for _, m := range rstEmbedded.FindAllStringSubmatch(strings.Join(lines, "\n"), -1) {
+		r.defineTarget(m[1], m[2])
+	}
+	r.parse(lines)
+	r.auto = make(map[string]int) // Definitions are numbered; count references afresh
+	return r.render(r.inline)
+}
+
+func (r *rst) defineTarget(name, url string) {
+	name = normalizeLabel(strings.Trim(name, "`"))
+	if strings.TrimSpace(url) == "" {
+		return // Internal target (a section anchor)
+	}
+	if _, exists := r.targets[name]; !exists {
+		r.targets[name] = strings.Join(strings.Fields(url), "")
+	}
+}
+
+// parse converts a run of lines (the document or a directive body)
+func (r *rst) parse(lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlank(line) {
+			i++
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		base := indent(line)
+
+		// Section title, with or without an overline
+		if tag, next, ok := r.section(lines, i); ok {
+			r.add(tag, strings.TrimSpace(lines[next-2]))
+			i = next
+			continue
+		}
+
+		// Explicit markup: targets, footnotes, directives, comments
+		if rstComment.MatchString(line) {
+			body, next := indented(lines, i+1, base)
+			switch {
+			case rstAnonymous.MatchString(line):
+				r.anonymous = append(r.anonymous, strings.Join(append([]string{rstAnonymous.FindStringSubmatch(line)[1]}, body...), ""))
+			case rstTarget.MatchString(line):
+				t := rstTarget.FindStringSubmatch(line)
+				r.defineTarget(t[1], t[2]+strings.Join(body, " "))
+			case rstNote.MatchString(line):
+				n := rstNote.FindStringSubmatch(line)
+				r.notes.define(r.noteLabel(n[1]), n[2]+" "+strings.Join(body, " "))
+			case rstDirective.MatchString(line):
+				d := rstDirective.FindStringSubmatch(line)
+				if rstProse[strings.ToLower(d[1])] {
+					r.add("p", d[2])
+					r.parse(dedent(withoutOptions(body)))
+				}
+			}
+			i = next
+			continue
+		}
+
+		switch {
+		case rstAnonymous.MatchString(line):
+			r.anonymous = append(r.anonymous, rstAnonymous.FindStringSubmatch(line)[1])
+			i++
+			continue
+		case rstGridRule.MatchString(line), rstTableRule.MatchString(line), isAdornment(line):
+			i++ // Table borders and transitions
+			continue
+		case rstField.MatchString(line):
+			_, i = indented(lines, i+1, base)
+			continue
+		case strings.HasPrefix(trimmed, "|") && strings.HasSuffix(trimmed, "|") && len(trimmed) > 1:
+			r.blocks = append(r.blocks, block{tag: "tr", cells: tableCells(trimmed)})
+			i++
+			continue
+		}
+
+		// Paragraph or list item: lines at the same indentation
+		tag := "p"
+		if rstBullet.MatchString(line) {
+			tag = "li"
+			trimmed = strings.TrimSpace(rstBullet.ReplaceAllString(line, ""))
+		}
+		text := []string{strings.TrimPrefix(trimmed, "| ")}
+		i++
+		for i < len(lines) && !isBlank(lines[i]) {
+			next := lines[i]
+			if tag == "li" && rstBullet.MatchString(next) && indent(next) == base {
+				break
+			}
+			if tag == "p" && indent(next) != base {
+				break
+			}
+			if _, _, heading := r.section(lines, i); heading || rstComment.MatchString(next) {
+				break
+			}
+			text = append(text, strings.TrimPrefix(strings.TrimSpace(next), "| "))
+			i++
+		}
+
+		// A paragraph ending in "::" introduces a literal block
+		joined := strings.Join(text, " ")
+		if strings.HasSuffix(joined, "::") {
+			switch {
+			case joined == "::":
+				joined = ""
+			case strings.HasSuffix(joined, " ::"):
+				joined = strings.TrimSuffix(joined, " ::")
+			default:
+				joined = strings.TrimSuffix(joined, ":")
+			}
+			r.add(tag, joined)
+			_, i = indented(lines, i, base)
+			continue
+		}
+		r.add(tag, joined)
+	}
+}
+
+// section reports whether a section title starts at lines[i], returning
+// its heading tag and the index after its underline
+func (r *rst) section(lines []string, i int) (string, int, bool) {
+	style := ""
+	title := i
+	if isAdornment(lines[i]) {
+		// Overline, title, underline
+		if i+2 >= len(lines) || strings.TrimSpace(lines[i+2]) != strings.TrimSpace(lines[i]) || isBlank(lines[i+1]) {
+			return "", 0, false
+		}
+		style = "over" + lines[i][:1]
+		title = i + 1
+	} else {
+		if i+1 >= len(lines) || indent(lines[i]) > 0 || !isAdornment(lines[i+1]) {
+			return "", 0, false
+		}
+		underline := strings.TrimSpace(lines[i+1])
+		if utf8.RuneCountInString(underline) < utf8.RuneCountInString(strings.TrimSpace(lines[i])) || len(underline) < 2 {
+			return "", 0, false
+		}
+		style = underline[:1]
+	}
+
+	level := 0
+	for level < len(r.styles) && r.styles[level] != style {
+		level++
+	}
+	if level == len(r.styles) {
+		r.styles = append(r.styles, style)
+	}
+	if level > 5 {
+		level = 5
+	}
+	return "h" + strconv.Itoa(level+1), title + 2, true
+}
+
+// noteLabel keys a footnote definition: auto-numbered labels (#, *) are
+// numbered in order, everything else keeps its label
+func (r *rst) noteLabel(label string) string {
+	if label != "#" && label != "*" {
+		return label
+	}
+	r.auto[label]++
+	return fmt.Sprintf("%s%d", label, r.auto[label])
+}
+
+// inline converts inline reStructuredText to HTML
+func (r *rst) inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		rest := s[i:]
+		wordStart := i == 0 || !isWordByte(s[i-1])
+		switch {
+		case c == '\\' && i+1 < len(s):
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+		case strings.HasPrefix(rest, "``"):
+			end := strings.Index(rest[2:], "``")
+			if end < 0 {
+				b.WriteString("``")
+				i += 2
+				continue
+			}
+			b.WriteString("<code>" + html.EscapeString(rest[2:2+end]) + "</code>")
+			i += end + 4
+		case c == ':' && rstRole.MatchString(rest):
+			m := rstRole.FindStringSubmatch(rest)
+			b.WriteString(r.role(m[1], m[2]))
+			i += len(m[0])
+		case c == '`':
+			end := strings.IndexByte(rest[1:], '`')
+			if end < 0 {
+				b.WriteByte(c)
+				i++
+				continue
+			}
+			text := rest[1 : 1+end]
+			i += end + 2
+			suffix := ""
+			switch {
+			case strings.HasPrefix(s[i:], "__"):
+				suffix = "__"
+			case strings.HasPrefix(s[i:], "_"):
+				suffix = "_"
+			}
+			i += len(suffix)
+			if suffix == "" {
+				b.WriteString(html.EscapeString(text)) // Default role: plain text
+				continue
+			}
+			b.WriteString(r.reference(text, suffix == "__"))
+		case c == '[' && rstFootRef.MatchString(rest):
+			m := rstFootRef.FindStringSubmatch(rest)
+			label := m[1]
+			if label == "#" || label == "*" {
+				r.auto[label]++
+				label = fmt.Sprintf("%s%d", label, r.auto[label])
+			}
+			if marker, ok := r.notes.marker(label); ok {
+				b.WriteString(marker)
+			}
+			i += len(m[0])
+		case wordStart && (c == 'h' || c == 'w'):
+			if out, n := r.autolink(rest); n > 0 {
+				b.WriteString(out)
+				i += n
+				continue
+			}
+			fallthrough
+		case wordStart && isWordByte(c) && c < 0x80:
+			if m := rstWordRef.FindStringSubmatch(rest); m != nil {
+				if out := r.reference(m[1], m[2] == "__"); strings.HasPrefix(out, "<a") {
+					b.WriteString(out)
+					i += len(m[1]) + len(m[2])
+					continue
+				}
+			}
+			word := rstWord.FindString(rest)
+			b.WriteString(word)
+			i += len(word)
+		case c == '*':
+			i++ // Emphasis delimiter
+		default:
+			b.WriteString(html.EscapeString(s[i : i+1]))
+			i++
+		}
+	}
+	return b.String()
+}
+
+// reference converts a hyperlink reference (`text`_, `text <url>`_, word_;
+// anonymous with __); unresolved references are left as text
+func (r *rst) reference(text string, anonymous bool) string {
+	title, target := text, ""
+	if m := rstTitled.FindStringSubmatch(text); m != nil {
+		title, target = m[1], m[2]
+		if title == "" {
+			title = target
+		}
+	}
+
+	name := ""
+	switch {
+	case target != "" && strings.HasSuffix(target, "_"):
+		name = normalizeLabel(strings.TrimSuffix(target, "_")) // Alias for a named target
+	case target != "":
+		return r.link(strings.Join(strings.Fields(target), ""), "", html.EscapeString(title))
+	case anonymous:
+		if r.nextAnon < len(r.anonymous) {
+			r.nextAnon++
+			return r.link(r.anonymous[r.nextAnon-1], "", html.EscapeString(title))
+		}
+		return html.EscapeString(title)
+	default:
+		name = normalizeLabel(text)
+	}
+
+	// Follow indirect targets (.. _a: b_)
+	url, ok := r.targets[name]
+	for hops := 0; ok && strings.HasSuffix(url, "_") && hops < 10; hops++ {
+		name = normalizeLabel(strings.Trim(strings.TrimSuffix(url, "_"), "`"))
+		url, ok = r.targets[name]
+	}
+	if !ok {
+		return html.EscapeString(title)
+	}
+	return r.link(url, name, html.EscapeString(title))
+}
+
+// role converts interpreted text with a role; :doc:, :download:, :rfc:
+// and :pep: become links, other roles keep their text
+func (r *rst) role(name, text string) string {
+	title, target := text, text
+	if m := rstTitled.FindStringSubmatch(text); m != nil {
+		title, target = m[1], m[2]
+	}
+	target = strings.TrimPrefix(target, "~")
+
+	switch strings.TrimPrefix(name, "std:") {
+	case "doc":
+		href := target
+		if !strings.HasSuffix(href, ".rst") {
+			href += ".rst"
+		}
+		return r.link(href, "", html.EscapeString(title))
+	case "download":
+		return r.link(target, "", html.EscapeString(title))
+	case "rfc":
+		if n, err := strconv.Atoi(strings.TrimSpace(target)); err == nil {
+			return r.link(fmt.Sprintf("https://datatracker.ietf.org/doc/html/rfc%d", n), "", "RFC "+strconv.Itoa(n))
+		}
+	case "pep":
+		if n, err := strconv.Atoi(strings.TrimSpace(target)); err == nil {
+			return r.link(fmt.Sprintf("https://peps.python.org/pep-%04d/", n), "", "PEP "+strconv.Itoa(n))
+		}
+	}
+	return html.EscapeString(title)
+}
+
+// isAdornment reports whether a line is a section underline, overline or
+// transition: one punctuation character repeated
+func isAdornment(line string) bool {
+	line = strings.TrimRight(line, " ")
+	if len(line) < 2 || !isPunct(line[0]) || line[0] == '\\' {
+		return false
+	}
+	return strings.Trim(line, line[:1]) == ""
+}
+
+// indented returns the lines after start indented deeper than base (blank
+// lines included while more indented lines follow), and the index after them
+func indented(lines []string, start, base int) ([]string, int) {
+	end := start
+	for i := start; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			continue
+		}
+		if indent(lines[i]) <= base {
+			break
+		}
+		end = i + 1
+	}
+	var body []string
+	for _, line := range lines[start:end] {
+		body = append(body, strings.TrimRight(line, " "))
+	}
+	return body, end
+}
+
+// dedent removes the common leading indentation
+func dedent(lines []string) []string {
+	common := -1
+	for _, line := range lines {
+		if !isBlank(line) && (common < 0 || indent(line) < common) {
+			common = indent(line)
+		}
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= common && common > 0 {
+			line = line[common:]
+		}
+		out[i] = line
+	}
+	return out
+}
+
+// withoutOptions drops a directive's leading :option: lines
+func withoutOptions(body []string) []string {
+	for len(body) > 0 && rstField.MatchString(body[0]) {
+		body = body[1:]
+	}
+	return body
+}
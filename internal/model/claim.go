@@ -2,18 +2,39 @@ package model
 
 // Claim represents a factual assertion extracted from the source
 type Claim struct {
-	Text      string `json:"text"`                // The claim text itself
-	Heuristic string `json:"heuristic,omitempty"` // Which extraction rule matched (e.g., "keyword:originated")
-	Sentence  int    `json:"sentence,omitempty"`  // Sentence index in source (0-based)
+	Text         string       `json:"text"`                    // The claim text itself
+	Heuristic    string       `json:"heuristic,omitempty"`     // Which extraction rule matched (e.g., "keyword:originated")
+	Sentence     int          `json:"sentence,omitempty"`      // Sentence index in source (0-based)
+	Language     string       `json:"language,omitempty"`      // Language of the keyword pack that matched (e.g., "en")
+	EvidenceRefs []string     `json:"evidence_refs,omitempty"` // URLs of Report.Evidence entries anchored to this claim
+	Support      ClaimSupport `json:"support,omitempty"`       // Empty when claim linkage was not computed
+	Dates        []ClaimDate  `json:"dates,omitempty"`         // Temporal expressions in the claim, in order of appearance
 }
 
+// ClaimDate is a temporal expression in a claim ("in 1887", "the 1800s",
+// "the 13th century") and the span of years it covers. Years before the
+// common era are negative.
+type ClaimDate struct {
+	Text string `json:"text"` // The expression as written
+	From int    `json:"from"` // First year covered
+	To   int    `json:"to"`   // Last year covered
+}
+
+// ClaimSupport records whether a claim is anchored to any evidence
+type ClaimSupport string
+
+const (
+	ClaimSupported   ClaimSupport = "supported"   // At least one evidence link in the claim's sentence or paragraph
+	ClaimUnsupported ClaimSupport = "unsupported" // No citation marker or link near the claim
+)
+
 // ClaimType categorizes the nature of the claim
 type ClaimType string
 
 const (
-	ClaimTypeOrigin      ClaimType = "origin"       // Claims about origin/first occurrence
-	ClaimTypeAttribution ClaimType = "attribution"  // Claims about who did/created something
-	ClaimTypeAuthority   ClaimType = "authority"    // Claims about legal/official status
-	ClaimTypeExistence   ClaimType = "existence"    // Claims about something existing
-	ClaimTypeDefinition  ClaimType = "definition"   // Definitional claims
+	ClaimTypeOrigin      ClaimType = "origin"      // Claims about origin/first occurrence
+	ClaimTypeAttribution ClaimType = "attribution" // Claims about who did/created something
+	ClaimTypeAuthority   ClaimType = "authority"   // Claims about legal/official status
+	ClaimTypeExistence   ClaimType = "existence"   // Claims about something existing
+	ClaimTypeDefinition  ClaimType = "definition"  // Definitional claims
 )
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"net/url"
+	"strings"
+	"time"
+)
 
 // Config holds all configuration for Entropia
 type Config struct {
@@ -16,9 +20,33 @@ type Config struct {
 	// Cache Settings
 	Cache CacheConfig `json:"cache" yaml:"cache"`
 
+	// History Settings
+	History HistoryConfig `json:"history" yaml:"history"`
+
 	// LLM Settings
 	LLM LLMConfig `json:"llm" yaml:"llm"`
 
+	// Evidence Validation Settings
+	Validation ValidationConfig `json:"validation" yaml:"validation"`
+
+	// Identifier (DOI, arXiv, ISBN) Resolution Settings
+	Identifiers IdentifierConfig `json:"identifiers" yaml:"identifiers"`
+
+	// Web Archive Settings
+	Archive ArchiveConfig `json:"archive" yaml:"archive"`
+
+	// Wikipedia Revision History Settings
+	Wikipedia WikipediaConfig `json:"wikipedia" yaml:"wikipedia"`
+
+	// Historical Entity Catalog Settings
+	Entities EntitiesConfig `json:"entities" yaml:"entities"`
+
+	// Crawl / Sitemap Discovery Settings
+	Crawl CrawlConfig `json:"crawl" yaml:"crawl"`
+
+	// Extraction Settings
+	Extraction ExtractionConfig `json:"extraction" yaml:"extraction"`
+
 	// Scoring Settings
 	Scoring ScoringConfig `json:"scoring" yaml:"scoring"`
 
@@ -62,6 +90,12 @@ type CacheConfig struct {
 	Dir     string        `json:"dir" yaml:"dir"`         // Cache directory
 }
 
+// HistoryConfig contains scan history settings
+type HistoryConfig struct {
+	Enabled bool   `json:"enabled" yaml:"enabled"` // Record every fresh scan
+	Dir     string `json:"dir" yaml:"dir"`         // History directory
+}
+
 // LLMConfig contains LLM provider settings
 type LLMConfig struct {
 	Provider       string `json:"provider" yaml:"provider"`               // openai, anthropic, ollama, ""
@@ -76,6 +110,82 @@ type LLMConfig struct {
 	NoProxy        string `json:"no_proxy" yaml:"no_proxy"`               // Comma-separated hosts to bypass proxy
 }
 
+// ValidationConfig contains evidence validation settings
+type ValidationConfig struct {
+	ContentDates bool  `json:"content_dates" yaml:"content_dates"`   // GET evidence pages and read publication dates from their HTML
+	MaxBodyBytes int64 `json:"max_body_bytes" yaml:"max_body_bytes"` // Max bytes read per evidence page (content dates, soft-404 checks)
+	Soft404      bool  `json:"soft_404" yaml:"soft_404"`             // GET evidence pages and check for "not found" and parking templates
+	Soft404Probe bool  `json:"soft_404_probe" yaml:"soft_404_probe"` // Also compare against a random path on each host
+}
+
+// IdentifierConfig contains settings for DOIs, arXiv IDs and ISBNs cited in
+// page text. Each resolver is a URL prefix: identifier evidence links to
+// <resolver>/<identifier> and is validated there (DOIs through the
+// resolver's handle API, <doi_resolver>/api/handles/<doi>).
+type IdentifierConfig struct {
+	Enabled       bool   `json:"enabled" yaml:"enabled"`               // Extract identifiers as evidence
+	DOIResolver   string `json:"doi_resolver" yaml:"doi_resolver"`     // doi.org-compatible handle resolver
+	ArXivResolver string `json:"arxiv_resolver" yaml:"arxiv_resolver"` // arXiv abstract page prefix
+	ISBNResolver  string `json:"isbn_resolver" yaml:"isbn_resolver"`   // Book lookup prefix
+}
+
+// URL returns the resolver link for an identifier, or "" when its resolver
+// is not configured
+func (c IdentifierConfig) URL(id Identifier) string {
+	var base string
+	switch id.Type {
+	case IdentifierDOI:
+		base = c.DOIResolver
+	case IdentifierArXiv:
+		base = c.ArXivResolver
+	case IdentifierISBN:
+		base = c.ISBNResolver
+	}
+	if base == "" {
+		return ""
+	}
+	path := (&url.URL{Path: id.Value}).EscapedPath()
+	return strings.TrimSuffix(base, "/") + "/" + path
+}
+
+// ArchiveConfig contains web archive lookup settings for dead evidence
+type ArchiveConfig struct {
+	Enabled     bool          `json:"enabled" yaml:"enabled"`           // Look up snapshots of dead evidence
+	CDXURL      string        `json:"cdx_url" yaml:"cdx_url"`           // Wayback CDX-compatible search endpoint
+	SnapshotURL string        `json:"snapshot_url" yaml:"snapshot_url"` // Snapshot prefix: <snapshot_url>/<timestamp>/<url>
+	Timeout     time.Duration `json:"timeout" yaml:"timeout"`           // Per-lookup timeout
+}
+
+// WikipediaConfig contains revision history settings for Wikipedia edit-war
+// analysis. "{lang}" in APIURL is replaced by the page's language subdomain.
+type WikipediaConfig struct {
+	APIURL       string        `json:"api_url" yaml:"api_url"`             // MediaWiki action API endpoint
+	EditWindow   time.Duration `json:"edit_window" yaml:"edit_window"`     // Revision history analyzed, back from now
+	MaxRevisions int           `json:"max_revisions" yaml:"max_revisions"` // Stop paging after this many revisions per page (0 = no limit)
+}
+
+// EntitiesConfig selects the historical entity catalog used to flag
+// anachronistic origin claims
+type EntitiesConfig struct {
+	CatalogFile string `json:"catalog_file" yaml:"catalog_file"` // YAML or JSON catalog; "" = bundled catalog
+}
+
+// CrawlConfig contains URL discovery settings for crawl and batch --sitemap
+type CrawlConfig struct {
+	MaxDepth int      `json:"max_depth" yaml:"max_depth"` // Link hops from the root URL (0 = root only)
+	MaxPages int      `json:"max_pages" yaml:"max_pages"` // Stop after this many pages (0 = no limit)
+	Include  []string `json:"include" yaml:"include"`     // Path patterns a page must match (empty = all)
+	Exclude  []string `json:"exclude" yaml:"exclude"`     // Path patterns that skip a page
+	Sitemaps bool     `json:"sitemaps" yaml:"sitemaps"`   // Seed crawls from robots.txt Sitemap lines or /sitemap.xml
+}
+
+// ExtractionConfig contains claim/evidence extraction settings
+type ExtractionConfig struct {
+	Adapter      string   `json:"adapter" yaml:"adapter"`             // Force a domain adapter (docs, wikipedia, legal, generic); "" = auto-detect
+	Language     string   `json:"language" yaml:"language"`           // Force a keyword pack language (en, ru, uk, de, ja); "" = detect per page
+	KeywordPacks []string `json:"keyword_packs" yaml:"keyword_packs"` // Keyword pack files (YAML or JSON) added to or replacing the bundled packs
+}
+
 // ScoringConfig contains scoring engine settings
 type ScoringConfig struct {
 	RulesFile string `json:"rules_file" yaml:"rules_file"` // Path to custom scoring rules JSON
@@ -127,6 +237,10 @@ func DefaultConfig() *Config {
 			TTL:     24 * time.Hour,
 			Dir:     "~/.entropia/cache",
 		},
+		History: HistoryConfig{
+			Enabled: true,
+			Dir:     "~/.entropia/history",
+		},
 		LLM: LLMConfig{
 			Provider:       "", // Disabled by default
 			Model:          "gpt-4o-mini",
@@ -134,6 +248,42 @@ func DefaultConfig() *Config {
 			Timeout:        20,
 			MaxTokens:      500,
 		},
+		Validation: ValidationConfig{
+			ContentDates: false,   // HEAD only (GET fallback when HEAD is rejected)
+			MaxBodyBytes: 256_000, // Publication metadata lives in <head>
+			Soft404:      false,   // Redirect-to-root detection only
+			Soft404Probe: false,
+		},
+		Identifiers: IdentifierConfig{
+			Enabled:       true,
+			DOIResolver:   "https://doi.org",
+			ArXivResolver: "https://arxiv.org/abs",
+			ISBNResolver:  "https://openlibrary.org/isbn",
+		},
+		Archive: ArchiveConfig{
+			Enabled:     true,
+			CDXURL:      "https://web.archive.org/cdx/search/cdx",
+			SnapshotURL: "https://web.archive.org/web",
+			Timeout:     15 * time.Second,
+		},
+		Wikipedia: WikipediaConfig{
+			APIURL:       "https://{lang}.wikipedia.org/w/api.php",
+			EditWindow:   30 * 24 * time.Hour,
+			MaxRevisions: 1000,
+		},
+		Entities: EntitiesConfig{
+			CatalogFile: "", // Use the bundled catalog
+		},
+		Crawl: CrawlConfig{
+			MaxDepth: 3,
+			MaxPages: 500,
+			Sitemaps: true,
+		},
+		Extraction: ExtractionConfig{
+			Adapter:      "",  // Auto-detect per page
+			Language:     "",  // Detect from <html lang> or the wiki subdomain
+			KeywordPacks: nil, // Bundled packs only
+		},
 		Scoring: ScoringConfig{
 			RulesFile: "", // Use built-in rules
 		},
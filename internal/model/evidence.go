@@ -4,12 +4,17 @@ import "time"
 
 // Evidence represents a cited source or outbound reference
 type Evidence struct {
-	URL        string        `json:"url"`                  // Full URL
-	Kind       EvidenceKind  `json:"kind"`                 // citation, external_link, reference
-	Host       string        `json:"host,omitempty"`       // Domain name
-	IsSameHost bool          `json:"is_same_host"`         // Whether it's same domain as source
-	Authority  AuthorityTier `json:"authority,omitempty"`  // Source authority classification
-	Text       string        `json:"text,omitempty"`       // Link anchor text
+	URL        string        `json:"url"`                   // Full URL
+	Kind       EvidenceKind  `json:"kind"`                  // citation, external_link, reference, identifier
+	Host       string        `json:"host,omitempty"`        // Domain name
+	IsSameHost bool          `json:"is_same_host"`          // Whether it's same domain as source
+	Authority  AuthorityTier `json:"authority,omitempty"`   // Source authority classification
+	Text       string        `json:"text,omitempty"`        // Link anchor text
+	Identifier *Identifier   `json:"identifier,omitempty"`  // DOI, arXiv ID or ISBN the evidence cites
+	Citation   *Citation     `json:"citation,omitempty"`    // Bibliographic fields of the cited work
+	ArchiveURL string        `json:"archive_url,omitempty"` // Archived copy the citing page links next to the source
+	MarkedDead bool          `json:"marked_dead,omitempty"` // Citing page's editors tagged the link as dead
+	Offline    bool          `json:"offline,omitempty"`     // Cited work has no link (e.g., a print book); URL points at the reference
 }
 
 // EvidenceKind classifies the type of evidence
@@ -19,8 +24,79 @@ const (
 	EvidenceKindCitation     EvidenceKind = "citation"      // Formal citation (e.g., Wikipedia references)
 	EvidenceKindExternalLink EvidenceKind = "external_link" // Outbound link
 	EvidenceKindReference    EvidenceKind = "reference"     // Named reference
+	EvidenceKindIdentifier   EvidenceKind = "identifier"    // DOI, arXiv ID or ISBN cited without a link
 )
 
+// Identifier is a persistent identifier for a cited work
+type Identifier struct {
+	Type  IdentifierType `json:"type"`  // doi, arxiv, isbn
+	Value string         `json:"value"` // Normalized: lowercase DOI, arXiv ID, ISBN-13 digits
+}
+
+// Citation holds bibliographic fields of a cited work, read from the citing
+// page's reference list or COinS spans, or from citation_* meta tags and JSON-LD on the cited page
+type Citation struct {
+	Title       string           `json:"title,omitempty"`
+	Authors     []string         `json:"authors,omitempty"`
+	Publisher   string           `json:"publisher,omitempty"`    // Publisher, journal or website
+	PublishedAt *time.Time       `json:"published_at,omitempty"` // Publication date of the work
+	AccessedAt  *time.Time       `json:"accessed_at,omitempty"`  // When the citing author retrieved it
+	Sources     []CitationSource `json:"sources"`                // Where the fields were read, in order
+}
+
+// CitationSource identifies where bibliographic fields were read from
+type CitationSource string
+
+const (
+	CitationSourceCOinS     CitationSource = "coins"         // <span class="Z3988"> in the citing page
+	CitationSourceMeta      CitationSource = "citation_meta" // <meta name="citation_*"> on the cited page
+	CitationSourceJSONLD    CitationSource = "json_ld"       // schema.org JSON-LD on the cited page
+	CitationSourceReference CitationSource = "reference"     // Text of the citing page's reference list entry
+)
+
+// Merge fills fields missing from c with those of other, recording its
+// sources; either may be nil
+func (c *Citation) Merge(other *Citation) *Citation {
+	if c == nil {
+		return other
+	}
+	if other == nil {
+		return c
+	}
+	merged := *c
+	if merged.Title == "" {
+		merged.Title = other.Title
+	}
+	if len(merged.Authors) == 0 {
+		merged.Authors = other.Authors
+	}
+	if merged.Publisher == "" {
+		merged.Publisher = other.Publisher
+	}
+	if merged.PublishedAt == nil {
+		merged.PublishedAt = other.PublishedAt
+	}
+	if merged.AccessedAt == nil {
+		merged.AccessedAt = other.AccessedAt
+	}
+	merged.Sources = append(append([]CitationSource(nil), c.Sources...), other.Sources...)
+	return &merged
+}
+
+// IdentifierType names an identifier scheme
+type IdentifierType string
+
+const (
+	IdentifierDOI   IdentifierType = "doi"   // Digital Object Identifier (10.1000/xyz)
+	IdentifierArXiv IdentifierType = "arxiv" // arXiv preprint ID (2101.00001, hep-th/9901001)
+	IdentifierISBN  IdentifierType = "isbn"  // International Standard Book Number
+)
+
+// String returns the identifier as "type:value" (e.g., doi:10.1000/xyz)
+func (id Identifier) String() string {
+	return string(id.Type) + ":" + id.Value
+}
+
 // AuthorityTier represents the classification of source authority
 type AuthorityTier int
 
@@ -46,15 +122,63 @@ func (t AuthorityTier) String() string {
 
 // ValidationResult contains the result of evidence validation
 type ValidationResult struct {
-	URL          string        `json:"url"`
-	IsAccessible bool          `json:"is_accessible"`
-	StatusCode   int           `json:"status_code,omitempty"`
-	LastModified *time.Time    `json:"last_modified,omitempty"`
-	Age          *int          `json:"age_days,omitempty"`    // Days since last modified
-	IsStale      bool          `json:"is_stale"`              // > 1 year old
-	IsVeryStale  bool          `json:"is_very_stale"`         // > 3 years old
-	IsDead       bool          `json:"is_dead"`               // 404, 410, or timeout
-	RedirectURL  string        `json:"redirect_url,omitempty"` // If redirected
-	Authority    AuthorityTier `json:"authority"`
-	Error        string        `json:"error,omitempty"`
+	URL            string           `json:"url"`
+	Status         ValidationStatus `json:"status,omitempty"`
+	IsAccessible   bool             `json:"is_accessible"`
+	StatusCode     int              `json:"status_code,omitempty"`
+	LastModified   *time.Time       `json:"last_modified,omitempty"`
+	PublishedAt    *time.Time       `json:"published_at,omitempty"`    // Publication date found in page content
+	Age            *int             `json:"age_days,omitempty"`        // Days since PublishedAt, else since LastModified
+	DateSource     DateSource       `json:"date_source,omitempty"`     // Where the date behind Age came from
+	IsStale        bool             `json:"is_stale"`                  // > 1 year old
+	IsVeryStale    bool             `json:"is_very_stale"`             // > 3 years old
+	IsDead         bool             `json:"is_dead"`                   // 404, 410, or timeout
+	Soft404        Soft404Kind      `json:"soft_404,omitempty"`        // 2xx/3xx that is really a missing page
+	ArchiveChecked bool             `json:"archive_checked,omitempty"` // Web archive was queried for this dead link
+	ArchivedURL    string           `json:"archived_url,omitempty"`    // Closest archived snapshot (dead links only)
+	ArchivedAt     *time.Time       `json:"archived_at,omitempty"`     // Snapshot capture time
+	RedirectURL    string           `json:"redirect_url,omitempty"`    // If redirected
+	Authority      AuthorityTier    `json:"authority"`
+	Error          string           `json:"error,omitempty"`
+	Citation       *Citation        `json:"-"` // Bibliographic fields read from the page (the pipeline moves them to Evidence)
 }
+
+// Dead reports whether the evidence is gone: a failed or 404/410 request, or
+// a soft 404 (error or parking page served as a success). Scoring, diffs and
+// history trends all count dead links with it.
+func (r ValidationResult) Dead() bool {
+	return r.IsDead || (r.IsAccessible && r.Soft404 != "")
+}
+
+// ValidationStatus is the overall outcome of validating one evidence link
+type ValidationStatus string
+
+const (
+	ValidationAccessible   ValidationStatus = "accessible"   // 2xx/3xx response
+	ValidationDead         ValidationStatus = "dead"         // 404, 410, or request failed
+	ValidationInaccessible ValidationStatus = "inaccessible" // Other error status (e.g., 403, 429, 5xx)
+	ValidationDisallowed   ValidationStatus = "disallowed"   // robots.txt forbids fetching; not checked, not dead
+	ValidationOffline      ValidationStatus = "offline"      // Cited work has no link; not checked, not dead
+)
+
+// Soft404Kind classifies an accessible response that does not serve the cited content
+type Soft404Kind string
+
+const (
+	Soft404RedirectToRoot Soft404Kind = "redirect_to_root" // Deep link redirected to a site's homepage
+	Soft404NotFoundPage   Soft404Kind = "not_found_page"   // 200 with a "page not found" template
+	Soft404ParkedDomain   Soft404Kind = "parked_domain"    // Domain-parking or for-sale page
+	Soft404ProbeMatch     Soft404Kind = "probe_match"      // Same response as a random path on the host
+)
+
+// DateSource identifies where an evidence date was read from
+type DateSource string
+
+const (
+	DateSourceLastModified     DateSource = "last_modified"          // Last-Modified response header
+	DateSourceArticlePublished DateSource = "article:published_time" // <meta property="article:published_time">
+	DateSourceCitationDate     DateSource = "citation_date"          // <meta name="citation_date"> (or citation_publication_date)
+	DateSourceJSONLD           DateSource = "json_ld"                // JSON-LD datePublished
+	DateSourceTimeElement      DateSource = "time_element"           // <time datetime="...">
+	DateSourceCitedWork        DateSource = "cited_work"             // Publication date in the citing page's citation metadata (COinS)
+)
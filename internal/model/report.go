@@ -9,6 +9,7 @@ type Report struct {
 	SourceURL string    `json:"source_url"`          // URL that was scanned
 	FetchedAt time.Time `json:"fetched_at"`          // When the scan occurred
 	FetchMeta FetchMeta `json:"fetch_meta"`          // HTTP metadata
+	Adapter   string    `json:"adapter,omitempty"` // Domain adapter used for extraction (e.g., "wikipedia")
 
 	Claims   []Claim    `json:"claims"`              // Extracted claims
 	Evidence []Evidence `json:"evidence"`            // Extracted evidence links
@@ -29,6 +30,9 @@ type FetchMeta struct {
 	ETag         string            `json:"etag,omitempty"`
 	Headers      map[string]string `json:"headers,omitempty"`
 	TLS          *TLSInfo          `json:"tls,omitempty"` // TLS/certificate information
+	Origin       string            `json:"origin,omitempty"`        // Local copy the page was read from (file URL, "stdin"); empty when fetched over HTTP
+	Title        string            `json:"title,omitempty"`         // Document title from PDF metadata
+	CreationDate string            `json:"creation_date,omitempty"` // Document creation date (RFC 3339) from PDF metadata
 }
 
 // TLSInfo contains TLS/SSL certificate information
@@ -50,6 +54,7 @@ type Score struct {
 	Index      int      `json:"index"`       // Overall support index (0-100)
 	Confidence string   `json:"confidence"`  // "low", "medium", "high"
 	Conflict   bool     `json:"conflict"`    // Whether conflicting claims detected
+	Rules      string   `json:"rules,omitempty"` // Name of the scoring rules applied
 	Signals    []Signal `json:"signals"`     // Diagnostic signals with transparent data
 }
 
@@ -65,7 +70,7 @@ type Signal struct {
 type SignalType string
 
 const (
-	SignalEvidenceCoverage      SignalType = "evidence_coverage"       // Claims-to-evidence ratio
+	SignalEvidenceCoverage      SignalType = "evidence_coverage"       // Share of claims anchored to evidence
 	SignalAuthorityDistribution SignalType = "authority_distribution"  // Authority tier balance
 	SignalFreshness             SignalType = "freshness"               // Age of sources
 	SignalAccessibility         SignalType = "accessibility"           // Dead link ratio
@@ -81,8 +86,44 @@ const (
 	SignalSelfSignedCertificate SignalType = "self_signed_certificate" // Self-signed TLS certificate
 	SignalCertificateMismatch   SignalType = "certificate_mismatch"    // Certificate domain doesn't match URL
 	SignalFreshnessAnomaly      SignalType = "freshness_anomaly"       // Suspiciously recent sources for historical topic
+	SignalDeadEvidence          SignalType = "dead_evidence"           // Dead links split into archived vs lost
+	SignalCleanupTags           SignalType = "cleanup_tags"            // Wikipedia [citation needed], [dubious], [unreliable source?] tags
+	SignalAnachronism           SignalType = "anachronism"             // Claim dates a historical entity to years it did not exist
 )
 
+// AllSignalTypes lists every signal type Entropia can emit
+var AllSignalTypes = []SignalType{
+	SignalEvidenceCoverage,
+	SignalAuthorityDistribution,
+	SignalFreshness,
+	SignalAccessibility,
+	SignalConflict,
+	SignalStaleSources,
+	SignalSecondarySourceBias,
+	SignalHighEntropy,
+	SignalCitationChurn,
+	SignalEditWar,
+	SignalHistoricalEntity,
+	SignalNoTLS,
+	SignalExpiredCertificate,
+	SignalSelfSignedCertificate,
+	SignalCertificateMismatch,
+	SignalFreshnessAnomaly,
+	SignalDeadEvidence,
+	SignalCleanupTags,
+	SignalAnachronism,
+}
+
+// IsKnownSignalType reports whether t is one of AllSignalTypes
+func IsKnownSignalType(t SignalType) bool {
+	for _, known := range AllSignalTypes {
+		if known == t {
+			return true
+}
+}
+	return false
+}
+
 // SignalSeverity indicates the importance of the signal
 type SignalSeverity string
 
@@ -105,7 +146,7 @@ func DefaultPrinciples() Principles {
 		NonNormative: true,
 		Transparent:  true,
 		Symmetric:    true,
-	}
+}
 }
 
 // LLMSummary contains optional LLM-generated summary
@@ -0,0 +1,111 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxStreamBytes caps a decoded stream so a compression bomb cannot
+// exhaust memory
+const maxStreamBytes = 64 << 20
+
+// decode applies a stream's filters and returns the decoded bytes
+func (r *reader) decode(s *stream) ([]byte, error) {
+	var filters []interface{}
+	switch f := r.resolve(s.dict["Filter"]).(type) {
+	case name:
+		filters = []interface{}{f}
+	case array:
+		filters = f
+	}
+
+	data := s.data
+	for i, f := range filters {
+		filter, _ := r.resolve(f).(name)
+		if predictor := r.predictor(s.dict["DecodeParms"], i); predictor > 1 {
+			return nil, fmt.Errorf("unsupported predictor %d", predictor)
+		}
+
+		var err error
+		switch filter {
+		case "FlateDecode", "Fl":
+			data, err = inflate(data)
+		case "ASCIIHexDecode", "AHx":
+			data, err = asciiHex(data)
+		case "ASCII85Decode", "A85":
+			data, err = ascii85Decode(data)
+		default:
+			return nil, fmt.Errorf("unsupported filter %s", filter)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filter, err)
+		}
+	}
+
+	return data, nil
+}
+
+// predictor returns the /Predictor of the i-th filter's decode parameters
+func (r *reader) predictor(parms interface{}, i int) int {
+	parms = r.resolve(parms)
+	if list, ok := parms.(array); ok {
+		if i >= len(list) {
+			return 0
+		}
+		parms = r.resolve(list[i])
+	}
+	d, ok := parms.(dict)
+	if !ok {
+		return 0
+	}
+	return r.integer(d["Predictor"])
+}
+
+// inflate decompresses zlib data, keeping what was recovered from a
+// truncated stream
+func inflate(data []byte) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+
+	out, err := io.ReadAll(io.LimitReader(zr, maxStreamBytes))
+	if err != nil && len(out) > 0 && (errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, zlib.ErrChecksum)) {
+		return out, nil
+	}
+	return out, err
+}
+
+func asciiHex(data []byte) ([]byte, error) {
+	var digits []byte
+	for _, c := range data {
+		if c == '>' {
+			break
+		}
+		if !isSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	_, err := hex.Decode(out, digits)
+	return out, err
+}
+
+func ascii85Decode(data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+	if end := bytes.Index(data, []byte("~>")); end >= 0 {
+		data = data[:end]
+	}
+	out := make([]byte, 4*len(data)+4) // "z" expands one byte to four
+	n, _, err := ascii85.Decode(out, data, true)
+	return out[:n], err
+}
@@ -0,0 +1,326 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Object values are nil, bool, float64, string (raw string bytes), name,
+// array, dict, ref, keyword (content stream operators) or *stream
+type (
+	name    string
+	keyword string
+	array   []interface{}
+	dict    map[name]interface{}
+	ref     struct{ num, gen int }
+	stream  struct {
+		dict dict
+		data []byte // Raw (still encoded) bytes
+	}
+)
+
+// delimiter closes an array or dictionary
+type delimiter string
+
+// lexer parses PDF objects from a byte slice
+type lexer struct {
+	data []byte
+	pos  int
+	refs bool // Parse "N G R" as references (off in content streams)
+}
+
+func newLexer(data []byte, refs bool) *lexer {
+	return &lexer{data: data, refs: refs}
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// skipSpace skips whitespace and comments
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		if !isSpace(c) {
+			return
+		}
+		l.pos++
+	}
+}
+
+// object parses the next object; io.EOF marks the end of input
+func (l *lexer) object() (interface{}, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, io.EOF
+	}
+
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		return l.name(), nil
+	case c == '(':
+		return l.literal(), nil
+	case c == '<' && l.peek(1) == '<':
+		l.pos += 2
+		return l.dict()
+	case c == '<':
+		return l.hex(), nil
+	case c == '>' && l.peek(1) == '>':
+		l.pos += 2
+		return delimiter(">>"), nil
+	case c == '[':
+		l.pos++
+		return l.array()
+	case c == ']':
+		l.pos++
+		return delimiter("]"), nil
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return l.number(), nil
+	case isDelimiter(c):
+		l.pos++ // Stray delimiter (e.g. PostScript braces); skip it
+		return l.object()
+	default:
+		return l.keyword(), nil
+	}
+}
+
+func (l *lexer) peek(offset int) byte {
+	if l.pos+offset < len(l.data) {
+		return l.data[l.pos+offset]
+	}
+	return 0
+}
+
+// regular reads a run of regular (non-space, non-delimiter) characters
+func (l *lexer) regular() []byte {
+	start := l.pos
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return l.data[start:l.pos]
+}
+
+func (l *lexer) name() name {
+	l.pos++ // '/'
+	raw := l.regular()
+	if !bytes.Contains(raw, []byte("#")) {
+		return name(raw)
+	}
+	var buf []byte
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '#' && i+2 < len(raw) {
+			if b, err := strconv.ParseUint(string(raw[i+1:i+3]), 16, 8); err == nil {
+				buf = append(buf, byte(b))
+				i += 2
+				continue
+			}
+		}
+		buf = append(buf, raw[i])
+	}
+	return name(buf)
+}
+
+func (l *lexer) keyword() interface{} {
+	word := l.regular()
+	if len(word) == 0 {
+		l.pos++ // Unknown byte; never loop on it
+		return keyword("")
+	}
+	switch string(word) {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	return keyword(word)
+}
+
+func (l *lexer) number() interface{} {
+	raw := l.regular()
+	value, err := strconv.ParseFloat(string(raw), 64)
+	if err != nil {
+		return keyword(raw)
+	}
+	if !l.refs || bytes.ContainsAny(raw, ".+-") {
+		return value
+	}
+
+	// "N G R" is an indirect reference
+	save := l.pos
+	l.skipSpace()
+	gen := l.regular()
+	l.skipSpace()
+	if len(gen) > 0 && isDigits(gen) && l.pos < len(l.data) && l.data[l.pos] == 'R' &&
+		(l.pos+1 == len(l.data) || isSpace(l.data[l.pos+1]) || isDelimiter(l.data[l.pos+1])) {
+		l.pos++
+		g, _ := strconv.Atoi(string(gen))
+		return ref{num: int(value), gen: g}
+	}
+	l.pos = save
+	return value
+}
+
+func isDigits(b []byte) bool {
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// literal parses a (string) with escapes and balanced parentheses
+func (l *lexer) literal() string {
+	l.pos++ // '('
+	var buf []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return string(buf)
+			}
+		case '\r':
+			if l.peek(0) == '\n' {
+				l.pos++
+			}
+			c = '\n'
+		case '\\':
+			if l.pos >= len(l.data) {
+				return string(buf)
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.peek(0) == '\n' {
+					l.pos++
+				}
+				continue // Line continuation
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					value := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						value = value*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(value)
+				} else {
+					c = e // \( \) \\ and unknown escapes
+				}
+			}
+		}
+		buf = append(buf, c)
+	}
+	return string(buf)
+}
+
+// hex parses a <hex string>
+func (l *lexer) hex() string {
+	l.pos++ // '<'
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isSpace(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++ // '>'
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	buf := make([]byte, 0, len(digits)/2)
+	for i := 0; i+1 < len(digits); i += 2 {
+		b, err := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		if err != nil {
+			continue
+		}
+		buf = append(buf, byte(b))
+	}
+	return string(buf)
+}
+
+func (l *lexer) array() (array, error) {
+	var items array
+	for {
+		item, err := l.object()
+		if err != nil {
+			return items, err
+		}
+		if d, ok := item.(delimiter); ok {
+			if d == "]" {
+				return items, nil
+			}
+			return items, fmt.Errorf("unexpected %s in array", d)
+		}
+		items = append(items, item)
+	}
+}
+
+func (l *lexer) dict() (dict, error) {
+	d := dict{}
+	for {
+		key, err := l.object()
+		if err != nil {
+			return d, err
+		}
+		if delim, ok := key.(delimiter); ok && delim == ">>" {
+			return d, nil
+		}
+		k, ok := key.(name)
+		if !ok {
+			continue // Malformed key; skip it
+		}
+		value, err := l.object()
+		if err != nil {
+			return d, err
+		}
+		if delim, ok := value.(delimiter); ok {
+			if delim == ">>" {
+				return d, nil
+			}
+			return d, fmt.Errorf("unexpected %s in dictionary", delim)
+		}
+		d[k] = value
+	}
+}
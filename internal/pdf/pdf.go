@@ -0,0 +1,449 @@
+// Package pdf extracts text, link annotations and metadata from PDF
+// documents so they can be scanned like HTML pages. It reads objects
+// directly rather than through the cross-reference table, which tolerates
+// damaged and truncated files, and supports the Flate, ASCIIHex and ASCII85
+// filters, object streams and ToUnicode font maps. Encrypted documents are
+// not supported.
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrEncrypted is returned for password-protected or encrypted documents
+var ErrEncrypted = errors.New("encrypted PDFs are not supported")
+
+// Document is the text and metadata of a PDF
+type Document struct {
+	Title   string    // Info dictionary /Title
+	Author  string    // Info dictionary /Author
+	Created time.Time // Info dictionary /CreationDate (zero when absent)
+	Pages   []Page
+}
+
+// Page is the extracted content of one page
+type Page struct {
+	Paragraphs []string // Text in reading order, one entry per paragraph
+	Links      []string // http(s) URIs of link annotations
+}
+
+var (
+	objHeader  = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+	rootRef    = regexp.MustCompile(`/Root\s+(\d+)\s+\d+\s+R`)
+	infoRef    = regexp.MustCompile(`/Info\s+(\d+)\s+\d+\s+R`)
+	encryptKey = regexp.MustCompile(`/Encrypt\s*(\d+\s+\d+\s+R|<<)`)
+)
+
+// IsPDF reports whether data carries the %PDF- signature (readers accept
+// it anywhere in the first 1024 bytes)
+func IsPDF(data []byte) bool {
+	if len(data) > 1024 {
+		data = data[:1024]
+	}
+	return bytes.Contains(data, []byte("%PDF-"))
+}
+
+// Parse extracts the pages and metadata of a PDF
+func Parse(data []byte) (*Document, error) {
+	if !IsPDF(data) {
+		return nil, errors.New("not a PDF document")
+	}
+	if encryptKey.Match(data) {
+		return nil, ErrEncrypted
+	}
+
+	r := &reader{data: data, objects: make(map[int]interface{}), fonts: make(map[int]*font)}
+	r.scan()
+	r.expandObjectStreams()
+
+	pages := r.pages()
+	if len(pages) == 0 {
+		return nil, errors.New("no pages found")
+	}
+
+	doc := &Document{}
+	if num, ok := lastRef(infoRef, data); ok {
+		if info := r.dictOf(r.objects[num]); info != nil {
+			doc.Title = strings.TrimSpace(r.text(info["Title"]))
+			doc.Author = strings.TrimSpace(r.text(info["Author"]))
+			doc.Created = parseDate(r.text(info["CreationDate"]))
+		}
+	}
+
+	for _, pg := range pages {
+		ex := newExtractor(r)
+		ex.run(r.contents(pg.page["Contents"]), pg.resources, 0)
+		doc.Pages = append(doc.Pages, Page{
+			Paragraphs: ex.paragraphs(),
+			Links:      r.links(pg.page),
+		})
+	}
+
+	return doc, nil
+}
+
+// reader holds the objects of one document
+type reader struct {
+	data    []byte
+	objects map[int]interface{}
+	fonts   map[int]*font // Parsed fonts by object number
+}
+
+// scan reads every "N G obj" in file order; later definitions (incremental
+// updates) replace earlier ones
+func (r *reader) scan() {
+	pos := 0
+	for pos < len(r.data) {
+		loc := objHeader.FindSubmatchIndex(r.data[pos:])
+		if loc == nil {
+			return
+		}
+		start := pos + loc[0]
+		if start > 0 && !isSpace(r.data[start-1]) && !isDelimiter(r.data[start-1]) {
+			pos = start + 1
+			continue
+		}
+		num, _ := strconv.Atoi(string(r.data[pos+loc[2] : pos+loc[3]]))
+
+		l := newLexer(r.data, true)
+		l.pos = pos + loc[1]
+		obj, err := l.object()
+		if err == nil {
+			if d, ok := obj.(dict); ok {
+				if s, end := r.streamAt(d, l.pos); s != nil {
+					obj = s
+					l.pos = end
+				}
+			}
+			r.objects[num] = obj
+		}
+		pos = max(l.pos, start+1)
+	}
+}
+
+// streamAt reads the stream that follows a dictionary at pos, if any
+func (r *reader) streamAt(d dict, pos int) (*stream, int) {
+	l := newLexer(r.data, false)
+	l.pos = pos
+	l.skipSpace()
+	if !bytes.HasPrefix(r.data[l.pos:], []byte("stream")) {
+		return nil, pos
+	}
+	start := l.pos + len("stream")
+	if start < len(r.data) && r.data[start] == '\r' {
+		start++
+	}
+	if start < len(r.data) && r.data[start] == '\n' {
+		start++
+	}
+
+	// Trust a direct /Length only when "endstream" follows it
+	if length, ok := d["Length"].(float64); ok && length >= 0 {
+		end := start + int(length)
+		if end <= len(r.data) {
+			tail := bytes.TrimLeft(r.data[end:min(end+16, len(r.data))], "\r\n ")
+			if bytes.HasPrefix(tail, []byte("endstream")) {
+				return &stream{dict: d, data: r.data[start:end]}, end + bytes.Index(r.data[end:], []byte("endstream")) + len("endstream")
+			}
+		}
+	}
+
+	idx := bytes.Index(r.data[start:], []byte("endstream"))
+	if idx < 0 {
+		return &stream{dict: d, data: r.data[start:]}, len(r.data) // Truncated file
+	}
+	end := start + idx
+	if end > start && r.data[end-1] == '\n' {
+		end--
+	}
+	if end > start && r.data[end-1] == '\r' {
+		end--
+	}
+	return &stream{dict: d, data: r.data[start:end]}, start + idx + len("endstream")
+}
+
+// expandObjectStreams loads objects stored in compressed object streams
+// (/Type /ObjStm); objects defined directly in the file take precedence
+func (r *reader) expandObjectStreams() {
+	var containers []*stream
+	for _, num := range r.numbers() {
+		if s, ok := r.objects[num].(*stream); ok && r.nameOf(s.dict["Type"]) == "ObjStm" {
+			containers = append(containers, s)
+		}
+	}
+
+	for _, s := range containers {
+		data, err := r.decode(s)
+		if err != nil {
+			continue
+		}
+		n, first := r.integer(s.dict["N"]), r.integer(s.dict["First"])
+		if first <= 0 || first > len(data) {
+			continue
+		}
+
+		header := newLexer(data[:first], false)
+		for i := 0; i < n; i++ {
+			numObj, err1 := header.object()
+			offObj, err2 := header.object()
+			num, ok1 := numObj.(float64)
+			off, ok2 := offObj.(float64)
+			if err1 != nil || err2 != nil || !ok1 || !ok2 {
+				break
+			}
+			if _, exists := r.objects[int(num)]; exists || first+int(off) >= len(data) {
+				continue
+			}
+			l := newLexer(data, true)
+			l.pos = first + int(off)
+			if obj, err := l.object(); err == nil {
+				r.objects[int(num)] = obj
+			}
+		}
+	}
+}
+
+// numbers returns the object numbers in ascending order
+func (r *reader) numbers() []int {
+	nums := make([]int, 0, len(r.objects))
+	for num := range r.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// resolve follows indirect references
+func (r *reader) resolve(v interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		rf, ok := v.(ref)
+		if !ok {
+			return v
+		}
+		v = r.objects[rf.num]
+	}
+	return nil
+}
+
+func (r *reader) dictOf(v interface{}) dict {
+	switch d := r.resolve(v).(type) {
+	case dict:
+		return d
+	case *stream:
+		return d.dict
+	}
+	return nil
+}
+
+func (r *reader) nameOf(v interface{}) name {
+	n, _ := r.resolve(v).(name)
+	return n
+}
+
+func (r *reader) integer(v interface{}) int {
+	n, _ := r.resolve(v).(float64)
+	return int(n)
+}
+
+// text decodes a text string (UTF-16BE with BOM, UTF-8 or PDFDocEncoding)
+func (r *reader) text(v interface{}) string {
+	s, _ := r.resolve(v).(string)
+	return decodeTextString(s)
+}
+
+// lastRef returns the object number of the last match of re (the newest
+// trailer wins after incremental updates)
+func lastRef(re *regexp.Regexp, data []byte) (int, bool) {
+	matches := re.FindAllSubmatch(data, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	num, err := strconv.Atoi(string(matches[len(matches)-1][1]))
+	return num, err == nil
+}
+
+// pageInfo is a page dictionary with its (possibly inherited) resources
+type pageInfo struct {
+	page      dict
+	resources dict
+}
+
+// pages walks the page tree from the catalog; without one (a truncated
+// file) it falls back to every /Type /Page object in number order
+func (r *reader) pages() []pageInfo {
+	var catalog dict
+	if num, ok := lastRef(rootRef, r.data); ok {
+		catalog = r.dictOf(r.objects[num])
+	}
+	if catalog == nil {
+		for _, num := range r.numbers() {
+			if d := r.dictOf(r.objects[num]); d != nil && r.nameOf(d["Type"]) == "Catalog" {
+				catalog = d
+				break
+			}
+		}
+	}
+
+	var pages []pageInfo
+	visited := make(map[int]bool)
+	var walk func(node interface{}, resources dict, depth int)
+	walk = func(node interface{}, resources dict, depth int) {
+		if rf, ok := node.(ref); ok {
+			if visited[rf.num] {
+				return
+			}
+			visited[rf.num] = true
+		}
+		d := r.dictOf(node)
+		if d == nil || depth > 64 {
+			return
+		}
+		if own := r.dictOf(d["Resources"]); own != nil {
+			resources = own
+		}
+		if kids, ok := r.resolve(d["Kids"]).(array); ok {
+			for _, kid := range kids {
+				walk(kid, resources, depth+1)
+			}
+			return
+		}
+		if r.nameOf(d["Type"]) == "Page" || d["Contents"] != nil {
+			pages = append(pages, pageInfo{page: d, resources: resources})
+		}
+	}
+	if catalog != nil {
+		walk(catalog["Pages"], nil, 0)
+	}
+
+	if len(pages) == 0 {
+		for _, num := range r.numbers() {
+			if d := r.dictOf(r.objects[num]); d != nil && r.nameOf(d["Type"]) == "Page" {
+				pages = append(pages, pageInfo{page: d, resources: r.dictOf(d["Resources"])})
+			}
+		}
+	}
+	return pages
+}
+
+// contents returns a page's decoded content streams, concatenated
+func (r *reader) contents(v interface{}) []byte {
+	var parts []interface{}
+	switch c := r.resolve(v).(type) {
+	case *stream:
+		parts = []interface{}{c}
+	case array:
+		parts = c
+	}
+
+	var buf bytes.Buffer
+	for _, part := range parts {
+		s, ok := r.resolve(part).(*stream)
+		if !ok {
+			continue
+		}
+		data, err := r.decode(s)
+		if err != nil {
+			continue
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// links returns the http(s) URIs of a page's link annotations
+func (r *reader) links(page dict) []string {
+	annots, _ := r.resolve(page["Annots"]).(array)
+
+	var links []string
+	seen := make(map[string]bool)
+	for _, a := range annots {
+		annot := r.dictOf(a)
+		if annot == nil || r.nameOf(annot["Subtype"]) != "Link" {
+			continue
+		}
+		action := r.dictOf(annot["A"])
+		if action == nil || r.nameOf(action["S"]) != "URI" {
+			continue
+		}
+		uri, _ := r.resolve(action["URI"]).(string)
+		uri = strings.TrimSpace(uri)
+		lower := strings.ToLower(uri)
+		if (strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")) && !seen[uri] {
+			seen[uri] = true
+			links = append(links, uri)
+		}
+	}
+	return links
+}
+
+// decodeTextString decodes a PDF text string
+func decodeTextString(s string) string {
+	switch {
+	case strings.HasPrefix(s, "\xfe\xff"):
+		return decodeUTF16([]byte(s[2:]))
+	case strings.HasPrefix(s, "\xef\xbb\xbf"):
+		return s[3:]
+	case utf8.ValidString(s):
+		return s
+	}
+	// PDFDocEncoding matches Latin-1 for printable characters
+	runes := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		runes[i] = rune(s[i])
+	}
+	return string(runes)
+}
+
+// decodeUTF16 decodes big-endian UTF-16
+func decodeUTF16(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// parseDate parses a PDF date (D:YYYYMMDDHHmmSSOHH'mm'); missing fields
+// default to the start of the period and a missing offset to UTC
+func parseDate(s string) time.Time {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "D:")
+	digits := 0
+	for digits < len(s) && digits < 14 && s[digits] >= '0' && s[digits] <= '9' {
+		digits++
+	}
+	if digits < 4 {
+		return time.Time{}
+	}
+
+	const defaults = "00000101000000"
+	t, err := time.Parse("20060102150405", s[:digits]+defaults[digits:])
+	if err != nil {
+		return time.Time{}
+	}
+
+	rest := strings.ReplaceAll(s[digits:], "'", "")
+	if len(rest) >= 3 && (rest[0] == '+' || rest[0] == '-') {
+		hours, err1 := strconv.Atoi(rest[1:3])
+		minutes := 0
+		if len(rest) >= 5 {
+			minutes, _ = strconv.Atoi(rest[3:5])
+		}
+		if err1 == nil {
+			offset := hours*3600 + minutes*60
+			if rest[0] == '-' {
+				offset = -offset
+			}
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.FixedZone("", offset))
+		}
+	}
+	return t
+}
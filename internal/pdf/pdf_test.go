@@ -0,0 +1,192 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// buildPDF assembles numbered objects (1-based; "" leaves a number free)
+// into a PDF with a cross-reference table
+func buildPDF(objects []string, trailer string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		if obj == "" {
+			continue
+		}
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		if off == 0 {
+			buf.WriteString("0000000000 00000 f \n")
+			continue
+		}
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d %s >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, trailer, xref)
+	return buf.Bytes()
+}
+
+// streamObject formats a stream, Flate-compressed when compress is set
+func streamObject(content string, compress bool, extra string) string {
+	data := []byte(content)
+	filter := ""
+	if compress {
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		_, _ = w.Write(data)
+		_ = w.Close()
+		data = buf.Bytes()
+		filter = "/Filter /FlateDecode "
+	}
+	return fmt.Sprintf("<< %s%s/Length %d >>\nstream\n%s\nendstream", filter, extra, len(data), data)
+}
+
+const toUnicodeCMap = `/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+1 begincodespacerange
+<0000> <FFFF>
+endcodespacerange
+1 beginbfchar
+<0003> <0020>
+endbfchar
+2 beginbfrange
+<0041> <005A> <0041>
+<0061> <007A> <0061>
+endbfrange
+endcmap
+CMapName currentdict /CMap defineresource pop
+end
+end`
+
+func samplePDF() []byte {
+	page1 := `BT /F1 12 Tf 72 720 Td (The act was introduced in 1998.) Tj
+0 -14 Td (It must be applied accord-) Tj
+0 -14 Td (ing to the rules \(see doi:10.1000/xyz123\).) Tj
+0 -40 Td [(See)-250(https://example.org/report)] TJ ET`
+	page2 := `BT /F2 10 Tf 1 0 0 1 72 700 Tm <0048006900030074006800650072006500> Tj ET
+BI /W 2 /H 2 /BPC 8 /CS /G ID ` + "\x00\xffEIx\x01" + ` EI
+BT /F1 12 Tf 72 600 Td (After image) Tj ET`
+
+	return buildPDF([]string{
+		`<< /Type /Catalog /Pages 2 0 R >>`,
+		`<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> >>`,
+		`<< /Type /Page /Parent 2 0 R /Contents 7 0 R /Annots [8 0 R] >>`,
+		`<< /Type /Page /Parent 2 0 R /Contents 9 0 R >>`,
+		`<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>`,
+		`<< /Type /Font /Subtype /Type0 /BaseFont /Custom /Encoding /Identity-H /ToUnicode 10 0 R >>`,
+		streamObject(page1, true, ""),
+		`<< /Type /Annot /Subtype /Link /Rect [0 0 10 10] /A << /S /URI /URI (https://www.legislation.gov.uk/ukpga/1998/42) >> >>`,
+		streamObject(page2, false, ""),
+		streamObject(toUnicodeCMap, true, ""),
+		`<< /Title <FEFF0048005200200041006300740020> /Author (Parliament) /CreationDate (D:19981109120000+01'00') >>`,
+	}, "/Root 1 0 R /Info 11 0 R")
+}
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(samplePDF())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if doc.Title != "HR Act" || doc.Author != "Parliament" {
+		t.Errorf("Unexpected metadata: title %q, author %q", doc.Title, doc.Author)
+	}
+	wantCreated := time.Date(1998, 11, 9, 11, 0, 0, 0, time.UTC)
+	if !doc.Created.Equal(wantCreated) {
+		t.Errorf("Created = %v, want %v", doc.Created, wantCreated)
+	}
+
+	if len(doc.Pages) != 2 {
+		t.Fatalf("Expected 2 pages, got %d", len(doc.Pages))
+	}
+	wantPage1 := []string{
+		"The act was introduced in 1998. It must be applied according to the rules (see doi:10.1000/xyz123).",
+		"See https://example.org/report",
+	}
+	if !reflect.DeepEqual(doc.Pages[0].Paragraphs, wantPage1) {
+		t.Errorf("Page 1 paragraphs = %q, want %q", doc.Pages[0].Paragraphs, wantPage1)
+	}
+	if want := []string{"https://www.legislation.gov.uk/ukpga/1998/42"}; !reflect.DeepEqual(doc.Pages[0].Links, want) {
+		t.Errorf("Page 1 links = %v, want %v", doc.Pages[0].Links, want)
+	}
+
+	// ToUnicode-mapped composite font, and text after an inline image
+	wantPage2 := []string{"Hi there", "After image"}
+	if !reflect.DeepEqual(doc.Pages[1].Paragraphs, wantPage2) {
+		t.Errorf("Page 2 paragraphs = %q, want %q", doc.Pages[1].Paragraphs, wantPage2)
+	}
+}
+
+func TestParse_ObjectStream(t *testing.T) {
+	// Catalog and page tree stored in a compressed object stream, as
+	// written by PDF 1.5+ producers
+	catalog := "<< /Type /Catalog /Pages 2 0 R >> "
+	header := fmt.Sprintf("1 0 2 %d ", len(catalog))
+	body := catalog + "<< /Type /Pages /Kids [4 0 R] /Count 1 >>"
+
+	data := buildPDF([]string{
+		"", // 1 and 2 live in the object stream
+		"",
+		streamObject(header+body, true, fmt.Sprintf("/Type /ObjStm /N 2 /First %d ", len(header))),
+		`<< /Type /Page /Parent 2 0 R /Contents 5 0 R /Resources << >> >>`,
+		streamObject("BT /F1 12 Tf 14 TL (Line one) ' (Line two) ' ET", true, ""),
+	}, "/Root 1 0 R")
+
+	doc, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Pages) != 1 || !reflect.DeepEqual(doc.Pages[0].Paragraphs, []string{"Line one Line two"}) {
+		t.Errorf("Unexpected pages: %+v", doc.Pages)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	if _, err := Parse([]byte("<html>not a pdf</html>")); err == nil {
+		t.Error("Expected an error for non-PDF input")
+	}
+
+	encrypted := buildPDF([]string{`<< /Type /Catalog /Pages 2 0 R >>`, `<< /Filter /Standard /V 2 >>`}, "/Root 1 0 R /Encrypt 2 0 R")
+	if _, err := Parse(encrypted); err != ErrEncrypted {
+		t.Errorf("Expected ErrEncrypted, got %v", err)
+	}
+
+	// Truncated after the first page's objects: no catalog, pages found by type
+	full := samplePDF()
+	cut := bytes.Index(full, []byte("9 0 obj"))
+	doc, err := Parse(full[:cut])
+	if err != nil {
+		t.Fatalf("Parse(truncated) failed: %v", err)
+	}
+	if len(doc.Pages) == 0 || len(doc.Pages[0].Paragraphs) == 0 {
+		t.Errorf("Expected text from the surviving page, got %+v", doc.Pages)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := map[string]time.Time{
+		"D:20230415093000Z":         time.Date(2023, 4, 15, 9, 30, 0, 0, time.UTC),
+		"D:20230415093000-05'00'":   time.Date(2023, 4, 15, 14, 30, 0, 0, time.UTC),
+		"D:2021":                    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		"20190702":                  time.Date(2019, 7, 2, 0, 0, 0, 0, time.UTC),
+		"not a date":                {},
+		"D:19981109120000+01'00'":   time.Date(1998, 11, 9, 11, 0, 0, 0, time.UTC),
+		"D:20200101000000+0530":     time.Date(2019, 12, 31, 18, 30, 0, 0, time.UTC),
+		"D:20200230000000Z (bogus)": {},
+	}
+	for in, want := range tests {
+		if got := parseDate(in); !got.Equal(want) {
+			t.Errorf("parseDate(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
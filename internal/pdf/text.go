@@ -0,0 +1,441 @@
+package pdf
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// maxFormDepth bounds nested form XObjects
+const maxFormDepth = 4
+
+// extractor interprets content streams and collects the shown text. Line
+// and paragraph breaks are inferred from vertical movement relative to the
+// font size; the graphics matrix (cm) is ignored.
+type extractor struct {
+	r       *reader
+	buf     strings.Builder
+	font    *font
+	size    float64 // Tf font size
+	scale   float64 // Vertical scale of the text matrix
+	leading float64 // TL text leading
+	lineY   float64 // Current line position
+	lastY   float64 // Line of the last shown text
+	wrote   bool
+	space   bool // Word break pending before the next text
+}
+
+func newExtractor(r *reader) *extractor {
+	return &extractor{r: r, font: &font{}, size: 1, scale: 1}
+}
+
+// run interprets a content stream with the given resources
+func (e *extractor) run(content []byte, resources dict, depth int) {
+	l := newLexer(content, false)
+	var operands []interface{}
+	for {
+		obj, err := l.object()
+		if err != nil {
+			return
+		}
+		op, ok := obj.(keyword)
+		if !ok {
+			operands = append(operands, obj)
+			continue
+		}
+
+		switch op {
+		case "BT":
+			e.lineY, e.scale = 0, 1
+		case "Tf":
+			if len(operands) >= 2 {
+				fontName, _ := operands[len(operands)-2].(name)
+				e.font = e.r.font(resources, fontName)
+				e.size = number(operands[len(operands)-1])
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				tx, ty := number(operands[len(operands)-2]), number(operands[len(operands)-1])
+				e.lineY += ty * e.scale
+				if op == "TD" {
+					e.leading = -ty
+				}
+				if ty == 0 && tx != 0 {
+					e.space = true
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				m := operands[len(operands)-6:]
+				e.scale = math.Abs(number(m[3]))
+				if e.scale == 0 {
+					e.scale = math.Abs(number(m[0]))
+				}
+				e.lineY = number(m[5])
+				e.space = true
+			}
+		case "TL":
+			if len(operands) >= 1 {
+				e.leading = number(operands[len(operands)-1])
+			}
+		case "T*":
+			e.newline()
+		case "Tj":
+			if len(operands) >= 1 {
+				e.show(operands[len(operands)-1])
+			}
+		case "'", "\"":
+			e.newline()
+			if len(operands) >= 1 {
+				e.show(operands[len(operands)-1])
+			}
+		case "TJ":
+			if len(operands) >= 1 {
+				items, _ := operands[len(operands)-1].(array)
+				for _, item := range items {
+					if adjust, ok := item.(float64); ok {
+						if adjust < -180 { // Wide negative kerning separates words
+							e.space = true
+						}
+						continue
+					}
+					e.show(item)
+				}
+			}
+		case "Do":
+			if len(operands) >= 1 && depth < maxFormDepth {
+				xName, _ := operands[len(operands)-1].(name)
+				e.form(resources, xName, depth)
+			}
+		case "ID":
+			l.pos = skipInlineImage(content, l.pos)
+		}
+		operands = operands[:0]
+	}
+}
+
+// form runs a form XObject's content stream
+func (e *extractor) form(resources dict, xName name, depth int) {
+	xobjects := e.r.dictOf(resources["XObject"])
+	if xobjects == nil {
+		return
+	}
+	s, ok := e.r.resolve(xobjects[xName]).(*stream)
+	if !ok || e.r.nameOf(s.dict["Subtype"]) != "Form" {
+		return
+	}
+	data, err := e.r.decode(s)
+	if err != nil {
+		return
+	}
+	if own := e.r.dictOf(s.dict["Resources"]); own != nil {
+		resources = own
+	}
+	e.run(data, resources, depth+1)
+}
+
+// skipInlineImage returns the position after an inline image's EI operator
+func skipInlineImage(content []byte, pos int) int {
+	for pos < len(content) {
+		idx := bytes.Index(content[pos:], []byte("EI"))
+		if idx < 0 {
+			return len(content)
+		}
+		at := pos + idx
+		before := at == 0 || isSpace(content[at-1])
+		after := at+2 == len(content) || isSpace(content[at+2])
+		if before && after {
+			return at + 2
+		}
+		pos = at + 2
+	}
+	return pos
+}
+
+func (e *extractor) newline() {
+	e.lineY -= e.leading * e.scale
+}
+
+// show appends a shown string, inserting the line, paragraph or word break
+// implied by the movement since the previous string
+func (e *extractor) show(v interface{}) {
+	raw, ok := v.(string)
+	if !ok {
+		return
+	}
+	text := strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return ' '
+		}
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return -1
+		}
+		return r
+	}, e.font.decode(raw))
+	if strings.TrimSpace(text) == "" {
+		if text != "" {
+			e.space = true
+		}
+		return
+	}
+
+	if e.wrote {
+		fontSize := math.Max(math.Abs(e.size*e.scale), 1)
+		dy := e.lastY - e.lineY
+		switch {
+		case dy > 1.6*fontSize || dy < -2*fontSize:
+			e.buf.WriteString("\n\n")
+		case math.Abs(dy) > 0.4*fontSize:
+			e.buf.WriteString("\n")
+		case e.space:
+			e.buf.WriteString(" ")
+		}
+	}
+	e.buf.WriteString(text)
+	e.wrote, e.space, e.lastY = true, false, e.lineY
+}
+
+// paragraphs joins the collected lines into paragraphs, rejoining words
+// hyphenated across line breaks
+func (e *extractor) paragraphs() []string {
+	var paragraphs []string
+	for _, block := range strings.Split(e.buf.String(), "\n\n") {
+		var text string
+		for _, line := range strings.Split(block, "\n") {
+			line = strings.Join(strings.Fields(line), " ")
+			switch {
+			case line == "":
+				continue
+			case text == "":
+				text = line
+			case hyphenated(text, line):
+				text = text[:len(text)-1] + line
+			default:
+				text += " " + line
+			}
+		}
+		if text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+	return paragraphs
+}
+
+// hyphenated reports whether text ends in a word broken with a hyphen that
+// continues on next
+func hyphenated(text, next string) bool {
+	if len(text) < 2 || !strings.HasSuffix(text, "-") {
+		return false
+	}
+	before := []rune(text[:len(text)-1])
+	first := []rune(next)[0]
+	return unicode.IsLetter(before[len(before)-1]) && unicode.IsLower(first)
+}
+
+func number(v interface{}) float64 {
+	n, _ := v.(float64)
+	return n
+}
+
+// font decodes the character codes of shown strings
+type font struct {
+	toUnicode *cmap
+	composite bool // Type0 font: multi-byte codes
+}
+
+// font returns the named font of a resource dictionary
+func (r *reader) font(resources dict, fontName name) *font {
+	fonts := r.dictOf(resources["Font"])
+	if fonts == nil {
+		return &font{}
+	}
+	v := fonts[fontName]
+	rf, shared := v.(ref)
+	if cached, ok := r.fonts[rf.num]; shared && ok {
+		return cached
+	}
+
+	f := &font{}
+	if fd := r.dictOf(v); fd != nil {
+		f.composite = r.nameOf(fd["Subtype"]) == "Type0"
+		if s, ok := r.resolve(fd["ToUnicode"]).(*stream); ok {
+			if data, err := r.decode(s); err == nil {
+				f.toUnicode = parseCMap(data)
+			}
+		}
+	}
+	if shared {
+		r.fonts[rf.num] = f
+	}
+	return f
+}
+
+// decode converts shown bytes to text
+func (f *font) decode(s string) string {
+	if f.toUnicode != nil {
+		return f.toUnicode.decode([]byte(s), f.composite)
+	}
+	if f.composite {
+		return "" // Glyph IDs cannot be read without a ToUnicode map
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		sb.WriteRune(winAnsi(s[i]))
+	}
+	return sb.String()
+}
+
+// winAnsiHigh maps WinAnsiEncoding 0x80-0x9F; other bytes match Latin-1
+var winAnsiHigh = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8a: 'Š', 0x8b: '‹', 0x8c: 'Œ', 0x8e: 'Ž', 0x91: '‘',
+	0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x98: '˜',
+	0x99: '™', 0x9a: 'š', 0x9b: '›', 0x9c: 'œ', 0x9e: 'ž', 0x9f: 'Ÿ',
+}
+
+func winAnsi(c byte) rune {
+	if r, ok := winAnsiHigh[c]; ok {
+		return r
+	}
+	return rune(c)
+}
+
+// cmap is a parsed ToUnicode CMap
+type cmap struct {
+	codespaces []codespace
+	chars      map[uint32]string
+}
+
+type codespace struct {
+	lo, hi []byte
+}
+
+// maxRangeSize bounds a single bfrange entry
+const maxRangeSize = 1 << 16
+
+// parseCMap reads the codespace ranges and bfchar/bfrange mappings of a
+// ToUnicode CMap
+func parseCMap(data []byte) *cmap {
+	c := &cmap{chars: make(map[uint32]string)}
+	l := newLexer(data, false)
+	var operands []interface{}
+	for {
+		obj, err := l.object()
+		if err != nil {
+			return c
+		}
+		op, ok := obj.(keyword)
+		if !ok {
+			operands = append(operands, obj)
+			continue
+		}
+
+		switch op {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				lo, ok1 := operands[i].(string)
+				hi, ok2 := operands[i+1].(string)
+				if ok1 && ok2 && len(lo) == len(hi) && len(lo) > 0 {
+					c.codespaces = append(c.codespaces, codespace{lo: []byte(lo), hi: []byte(hi)})
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(string)
+				dst, ok2 := operands[i+1].(string)
+				if ok1 && ok2 {
+					c.chars[codeOf([]byte(src))] = decodeUTF16([]byte(dst))
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].(string)
+				hi, ok2 := operands[i+1].(string)
+				if !ok1 || !ok2 {
+					continue
+				}
+				c.addRange(codeOf([]byte(lo)), codeOf([]byte(hi)), operands[i+2])
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+// addRange maps lo..hi to consecutive characters starting at dst, or to
+// the entries of a destination array
+func (c *cmap) addRange(lo, hi uint32, dst interface{}) {
+	if hi < lo || hi-lo >= maxRangeSize {
+		return
+	}
+	switch d := dst.(type) {
+	case string:
+		units := []byte(d)
+		if len(units) < 2 {
+			return
+		}
+		for code := lo; code <= hi; code++ {
+			next := append([]byte(nil), units...)
+			last := uint16(next[len(next)-2])<<8 | uint16(next[len(next)-1])
+			last += uint16(code - lo)
+			next[len(next)-2], next[len(next)-1] = byte(last>>8), byte(last)
+			c.chars[code] = decodeUTF16(next)
+		}
+	case array:
+		for i, item := range d {
+			if s, ok := item.(string); ok && lo+uint32(i) <= hi {
+				c.chars[lo+uint32(i)] = decodeUTF16([]byte(s))
+			}
+		}
+	}
+}
+
+// decode converts shown bytes using the map; unmapped single-byte codes
+// fall back to WinAnsi
+func (c *cmap) decode(b []byte, composite bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(b); {
+		n := c.codeLength(b[i:], composite)
+		code := codeOf(b[i : i+n])
+		if s, ok := c.chars[code]; ok {
+			sb.WriteString(s)
+		} else if n == 1 {
+			sb.WriteRune(winAnsi(b[i]))
+		}
+		i += n
+	}
+	return sb.String()
+}
+
+// codeLength returns the byte length of the code at the start of b
+func (c *cmap) codeLength(b []byte, composite bool) int {
+	for _, cs := range c.codespaces {
+		n := len(cs.lo)
+		if n > len(b) {
+			continue
+		}
+		match := true
+		for i := 0; i < n; i++ {
+			if b[i] < cs.lo[i] || b[i] > cs.hi[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return n
+		}
+	}
+	if composite && len(b) >= 2 {
+		return 2
+	}
+	return 1
+}
+
+// codeOf reads a big-endian character code
+func codeOf(b []byte) uint32 {
+	var code uint32
+	for _, c := range b {
+		code = code<<8 | uint32(c)
+	}
+	return code
+}
@@ -27,6 +27,7 @@ type Fetcher struct {
 	httpClient *http.Client
 	userAgent  string
 	maxBytes   int64
+	robots     *util.RobotsChecker // Optional robots.txt enforcement (nil = disabled)
 }
 
 // NewFetcher creates a new Fetcher with the given configuration
@@ -56,6 +57,11 @@ func NewFetcher(timeout time.Duration, userAgent string, maxBytes int64, insecur
 	}
 }
 
+// SetRobotsChecker enables robots.txt enforcement for subsequent fetches
+func (f *Fetcher) SetRobotsChecker(robots *util.RobotsChecker) {
+	f.robots = robots
+}
+
 // FetchResult contains the fetched HTML and metadata
 type FetchResult struct {
 	HTML     string
@@ -66,6 +72,16 @@ type FetchResult struct {
 
 // Fetch retrieves HTML content from the given URL
 func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*FetchResult, error) {
+	if f.robots != nil {
+		allowed, _, err := f.robots.CanFetch(ctx, rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("check robots.txt: %w", err)
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w %s", util.ErrRobotsDisallowed, rawURL)
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
@@ -2,12 +2,15 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/ppiankov/entropia/internal/util"
 )
 
 func TestFetchWithRetry_Success(t *testing.T) {
@@ -165,3 +168,31 @@ func TestIsRetryableFetchError_Nil(t *testing.T) {
 		t.Error("Expected nil error to not be retryable")
 	}
 }
+
+func TestFetch_RobotsDisallowed(t *testing.T) {
+	var pageHits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			_, _ = fmt.Fprint(w, "User-agent: *\nDisallow: /private/\n")
+			return
+		}
+		pageHits.Add(1)
+		_, _ = fmt.Fprint(w, "<html>OK</html>")
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(5*time.Second, "test-agent", 1<<20, false, "", "", "")
+	fetcher.SetRobotsChecker(util.NewRobotsChecker("test-agent", 5*time.Second))
+
+	_, err := fetcher.FetchWithRetry(context.Background(), server.URL+"/private/page")
+	if !errors.Is(err, util.ErrRobotsDisallowed) {
+		t.Fatalf("Expected ErrRobotsDisallowed, got %v", err)
+	}
+	if pageHits.Load() != 0 {
+		t.Errorf("Expected disallowed page not to be requested, got %d hits", pageHits.Load())
+	}
+
+	if _, err := fetcher.FetchWithRetry(context.Background(), server.URL+"/public/page"); err != nil {
+		t.Errorf("Expected allowed page to be fetched, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// Gate decides whether a report fails a CI run
+type Gate struct {
+	FailUnder      int  // Fail when the support index is below this (0 = off)
+	FailOnCritical bool // Fail when any signal is critical
+}
+
+// Enabled reports whether the gate can fail a report
+func (g Gate) Enabled() bool {
+	return g.FailUnder > 0 || g.FailOnCritical
+}
+
+// Check returns the reasons a report fails the gate (empty = pass)
+func (g Gate) Check(report *model.Report) []string {
+	var failures []string
+
+	if g.FailUnder > 0 && report.Score.Index < g.FailUnder {
+		failures = append(failures, fmt.Sprintf("support index %d is below %d", report.Score.Index, g.FailUnder))
+	}
+
+	if g.FailOnCritical {
+		for _, signal := range report.Score.Signals {
+			if signal.Severity == model.SeverityCritical {
+				failures = append(failures, fmt.Sprintf("critical signal %s: %s", signal.Type, signal.Description))
+			}
+		}
+	}
+
+	return failures
+}
+
+// CheckError returns the gate failure for a URL that could not be scanned:
+// an enabled gate fails it, so unreachable pages never pass CI
+func (g Gate) CheckError(err error) []string {
+	if !g.Enabled() || err == nil {
+		return nil
+	}
+	return []string{fmt.Sprintf("scan failed: %v", err)}
+}
+
+// CIResult is one scanned URL in a SARIF or JUnit report
+type CIResult struct {
+	URL      string
+	Report   *model.Report // nil when the scan failed
+	Error    error         // Scan error, if any
+	Failures []string      // Gate failures (see Gate.Check)
+}
@@ -0,0 +1,151 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+func ciReport(index int, severities ...model.SignalSeverity) *model.Report {
+	report := &model.Report{SourceURL: "https://docs.example.com/guide", Score: model.Score{Index: index, Confidence: "medium"}}
+	for _, severity := range severities {
+		report.Score.Signals = append(report.Score.Signals, model.Signal{
+			Type:        model.SignalAccessibility,
+			Severity:    severity,
+			Description: "Accessibility: 1/4 (25%)",
+		})
+	}
+	return report
+}
+
+func TestGate_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		gate     Gate
+		report   *model.Report
+		failures int
+	}{
+		{"disabled", Gate{}, ciReport(10, model.SeverityCritical), 0},
+		{"above threshold", Gate{FailUnder: 60}, ciReport(75), 0},
+		{"below threshold", Gate{FailUnder: 60}, ciReport(59), 1},
+		{"critical signal", Gate{FailOnCritical: true}, ciReport(90, model.SeverityWarning, model.SeverityCritical), 1},
+		{"both", Gate{FailUnder: 60, FailOnCritical: true}, ciReport(20, model.SeverityCritical), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.gate.Check(tt.report); len(got) != tt.failures {
+				t.Errorf("Expected %d failures, got %v", tt.failures, got)
+			}
+		})
+	}
+}
+
+func TestGate_CheckError(t *testing.T) {
+	err := errors.New("dial tcp: connection refused")
+
+	if got := (Gate{}).CheckError(err); len(got) != 0 {
+		t.Errorf("Expected a disabled gate to ignore scan errors, got %v", got)
+	}
+	if got := (Gate{FailUnder: 60}).CheckError(nil); len(got) != 0 {
+		t.Errorf("Expected no failure without an error, got %v", got)
+	}
+
+	got := Gate{FailUnder: 60}.CheckError(err)
+	if len(got) != 1 || got[0] != "scan failed: dial tcp: connection refused" {
+		t.Errorf("Expected the scan error to fail the gate, got %v", got)
+	}
+	if got := (Gate{FailOnCritical: true}).CheckError(err); len(got) != 1 {
+		t.Errorf("Expected --fail-on-critical to fail on scan errors too, got %v", got)
+	}
+}
+
+func ciFixture() []CIResult {
+	return []CIResult{
+		{URL: "https://docs.example.com/guide", Report: ciReport(40, model.SeverityInfo, model.SeverityCritical), Failures: []string{"support index 40 is below 60"}},
+		{URL: "https://docs.example.com/ok", Report: ciReport(80, model.SeverityWarning)},
+		{URL: "https://docs.example.com/broken", Error: errors.New("unexpected status: 500")},
+	}
+}
+
+func TestRenderSARIF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.sarif")
+	if err := NewRenderer(false).RenderSARIF(ciFixture(), "1.2.3", path); err != nil {
+		t.Fatalf("RenderSARIF failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Version != "1.2.3" {
+		t.Fatalf("Unexpected SARIF envelope: %+v", log)
+	}
+
+	levels := map[string]int{}
+	for _, result := range log.Runs[0].Results {
+		levels[result.RuleID+":"+result.Level]++
+		if len(result.Locations) != 1 || result.Locations[0].PhysicalLocation.ArtifactLocation.URI == "" {
+			t.Errorf("Expected result location, got %+v", result.Locations)
+		}
+	}
+	want := map[string]int{
+		"accessibility:note":    1,
+		"accessibility:error":   1,
+		"accessibility:warning": 1,
+		"quality_gate:error":    1,
+		"scan_error:error":      1,
+	}
+	for key, count := range want {
+		if levels[key] != count {
+			t.Errorf("Expected %d %s results, got %d (all: %v)", count, key, levels[key], levels)
+		}
+	}
+	if len(log.Runs[0].Tool.Driver.Rules) != 3 {
+		t.Errorf("Expected 3 rules, got %+v", log.Runs[0].Tool.Driver.Rules)
+	}
+}
+
+func TestRenderJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := NewRenderer(false).RenderJUnit(ciFixture(), path); err != nil {
+		t.Fatalf("RenderJUnit failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+
+	if suites.Tests != 3 || suites.Failures != 1 || suites.Errors != 1 {
+		t.Errorf("Expected 3 tests, 1 failure, 1 error; got %d/%d/%d", suites.Tests, suites.Failures, suites.Errors)
+	}
+	cases := suites.Suites[0].TestCases
+	if cases[0].Failure == nil || !strings.Contains(cases[0].Failure.Message, "below 60") {
+		t.Errorf("Expected gate failure on first case, got %+v", cases[0].Failure)
+	}
+	if cases[1].Failure != nil || cases[1].Error != nil {
+		t.Error("Expected passing second case")
+	}
+	if cases[2].Error == nil {
+		t.Error("Expected scan error on third case")
+	}
+	if cases[0].ClassName != "entropia.docs_example_com" {
+		t.Errorf("Unexpected classname %q", cases[0].ClassName)
+	}
+}
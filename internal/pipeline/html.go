@@ -0,0 +1,243 @@
+package pipeline
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+//go:embed templates/*.html
+var htmlTemplateFS embed.FS
+
+// htmlTemplates holds the report and batch index pages; CSS and JS are
+// inlined from templates/common.html so each file works offline
+var htmlTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"deref": func(v *int) int { return *v },
+}).ParseFS(htmlTemplateFS, "templates/*.html"))
+
+// htmlEvidenceRow is one row of the evidence table
+type htmlEvidenceRow struct {
+	URL         string
+	Text        string
+	Host        string
+	Tier        string
+	TierRank    int // 1 primary ... 4 unknown, for sorting
+	Status      string
+	StatusClass string
+	StatusCode  int
+	Age         *int
+	AgeSort     int // -1 when unknown
+	ArchivedURL string
+	ArchivedAt  string
+}
+
+// htmlSignalGroup lists signals of one severity
+type htmlSignalGroup struct {
+	Severity model.SignalSeverity
+	Title    string
+	Signals  []model.Signal
+}
+
+type htmlReportView struct {
+	Report       *model.Report
+	Rows         []htmlEvidenceRow
+	Statuses     []string
+	SignalGroups []htmlSignalGroup
+	Supported    int
+	Linked       bool
+	Footer       bool
+}
+
+// HTMLIndexEntry is one URL on the batch index page
+type HTMLIndexEntry struct {
+	URL        string
+	File       string // Report file, relative to the index page
+	Subject    string
+	Index      int
+	Confidence string
+	Adapter    string
+	Evidence   int
+	Dead       int
+	Critical   int
+	Warnings   int
+	Error      string // Scan error (no report)
+}
+
+// NewHTMLIndexEntry summarizes a report for the batch index page
+func NewHTMLIndexEntry(report *model.Report, file string) HTMLIndexEntry {
+	entry := HTMLIndexEntry{
+		URL:        report.SourceURL,
+		File:       file,
+		Subject:    report.Subject,
+		Index:      report.Score.Index,
+		Confidence: report.Score.Confidence,
+		Adapter:    report.Adapter,
+		Evidence:   len(report.Evidence),
+	}
+	for _, v := range report.Validation {
+		if v.Dead() {
+			entry.Dead++
+		}
+	}
+	for _, signal := range report.Score.Signals {
+		switch signal.Severity {
+		case model.SeverityCritical:
+			entry.Critical++
+		case model.SeverityWarning:
+			entry.Warnings++
+		}
+	}
+	return entry
+}
+
+// RenderHTML writes the report as a single self-contained HTML file with a
+// sortable, filterable evidence table
+func (r *Renderer) RenderHTML(report *model.Report, path string) error {
+	supported, linked := countSupported(report.Claims)
+	view := htmlReportView{
+		Report:       report,
+		Rows:         htmlEvidenceRows(report),
+		SignalGroups: htmlSignalGroups(report.Score.Signals),
+		Supported:    supported,
+		Linked:       linked,
+		Footer:       r.IncludeFooter,
+	}
+
+	seen := map[string]bool{}
+	for _, row := range view.Rows {
+		if !seen[row.Status] {
+			seen[row.Status] = true
+			view.Statuses = append(view.Statuses, row.Status)
+		}
+	}
+	sort.Strings(view.Statuses)
+
+	return writeHTML(path, "report.html", view)
+}
+
+// RenderHTMLIndex writes a batch index page linking per-URL HTML reports
+func (r *Renderer) RenderHTMLIndex(entries []HTMLIndexEntry, path string) error {
+	view := struct {
+		Entries   []HTMLIndexEntry
+		Generated string
+		Footer    bool
+	}{
+		Entries:   entries,
+		Generated: time.Now().UTC().Format("2006-01-02 15:04:05 UTC"),
+		Footer:    r.IncludeFooter,
+	}
+	return writeHTML(path, "index.html", view)
+}
+
+func writeHTML(path, name string, view interface{}) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close file: %w", closeErr)
+		}
+	}()
+
+	if err := htmlTemplates.ExecuteTemplate(f, name, view); err != nil {
+		return fmt.Errorf("render HTML: %w", err)
+	}
+	return nil
+}
+
+// htmlEvidenceRows joins evidence with its validation result
+func htmlEvidenceRows(report *model.Report) []htmlEvidenceRow {
+	validation := make(map[string]model.ValidationResult, len(report.Validation))
+	for _, v := range report.Validation {
+		validation[v.URL] = v
+	}
+
+	rows := make([]htmlEvidenceRow, 0, len(report.Evidence))
+	for _, ev := range report.Evidence {
+		tier := ev.Authority
+		row := htmlEvidenceRow{
+			URL:     ev.URL,
+			Text:    ev.Text,
+			Host:    ev.Host,
+			Status:  "unchecked",
+			AgeSort: -1,
+		}
+
+		if v, ok := validation[ev.URL]; ok {
+			if tier == model.TierUnknown {
+				tier = v.Authority
+			}
+			row.StatusCode = v.StatusCode
+			switch {
+			case v.Soft404 != "":
+				row.Status = "soft 404"
+			case v.Status != "":
+				row.Status = string(v.Status)
+			case v.IsDead:
+				row.Status = string(model.ValidationDead)
+			case v.IsAccessible:
+				row.Status = string(model.ValidationAccessible)
+			default:
+				row.Status = string(model.ValidationInaccessible)
+			}
+			if v.Age != nil {
+				row.Age = v.Age
+				row.AgeSort = *v.Age
+			}
+			if v.ArchivedURL != "" {
+				row.ArchivedURL = v.ArchivedURL
+				row.ArchivedAt = "archived"
+				if v.ArchivedAt != nil {
+					row.ArchivedAt = v.ArchivedAt.Format("2006-01-02")
+				}
+			}
+		}
+
+		row.Tier = tier.String()
+		row.TierRank = int(tier)
+		if tier == model.TierUnknown {
+			row.TierRank = 4
+		}
+		row.StatusClass = htmlStatusClass(row.Status)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// htmlStatusClass maps a status label to a CSS class
+func htmlStatusClass(status string) string {
+	if status == "soft 404" {
+		return "soft-404"
+	}
+	return status
+}
+
+// htmlSignalGroups groups signals by severity, most severe first
+func htmlSignalGroups(signals []model.Signal) []htmlSignalGroup {
+	groups := []htmlSignalGroup{
+		{Severity: model.SeverityCritical, Title: "Critical"},
+		{Severity: model.SeverityWarning, Title: "Warnings"},
+		{Severity: model.SeverityInfo, Title: "Info"},
+	}
+	for _, signal := range signals {
+		for i := range groups {
+			if groups[i].Severity == signal.Severity {
+				groups[i].Signals = append(groups[i].Signals, signal)
+			}
+		}
+	}
+
+	nonEmpty := groups[:0]
+	for _, group := range groups {
+		if len(group.Signals) > 0 {
+			nonEmpty = append(nonEmpty, group)
+		}
+	}
+	return nonEmpty
+}
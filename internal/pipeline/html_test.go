@@ -0,0 +1,103 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+func htmlFixture() *model.Report {
+	age := 800
+	archivedAt := time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC)
+	return &model.Report{
+		Subject:   "Laksa <script>",
+		SourceURL: "https://en.wikipedia.org/wiki/Laksa",
+		FetchedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Adapter:   "wikipedia",
+		Claims: []model.Claim{
+			{Text: "Laksa originated in Peranakan cuisine.", Support: model.ClaimSupported, EvidenceRefs: []string{"https://gov.example.org/food"}},
+			{Text: "It was first sold in 1890.", Support: model.ClaimUnsupported},
+		},
+		Evidence: []model.Evidence{
+			{URL: "https://gov.example.org/food", Host: "gov.example.org", Authority: model.TierPrimary},
+			{URL: "https://blog.example.com/laksa", Host: "blog.example.com", Authority: model.TierTertiary},
+		},
+		Validation: []model.ValidationResult{
+			{URL: "https://gov.example.org/food", Status: model.ValidationAccessible, IsAccessible: true, StatusCode: 200, Age: &age},
+			{URL: "https://blog.example.com/laksa", Status: model.ValidationDead, IsDead: true, StatusCode: 404, ArchivedURL: "https://web.archive.org/web/20190304000000/https://blog.example.com/laksa", ArchivedAt: &archivedAt},
+		},
+		Score: model.Score{
+			Index:      55,
+			Confidence: "medium",
+			Signals: []model.Signal{
+				{Type: model.SignalFreshness, Severity: model.SeverityInfo, Description: "Median source age 2.2 years"},
+				{Type: model.SignalAccessibility, Severity: model.SeverityCritical, Description: "Accessibility: 1/2 (50%)"},
+			},
+		},
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html")
+	if err := NewRenderer(true).RenderHTML(htmlFixture(), path); err != nil {
+		t.Fatalf("RenderHTML failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+
+	for _, want := range []string{
+		"<style>", "<script>", // Self-contained
+		"Laksa &lt;script&gt;", // Escaped subject
+		`data-tier="primary" data-status="accessible"`,
+		`data-tier="tertiary" data-status="dead"`,
+		`<td class="num" data-value="800">800</td>`,
+		`>2019-03-04</a>`,
+		`<h3 class="critical">Critical (1)</h3>`,
+		"unsupported",
+		"Generated by Entropia",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected HTML to contain %q", want)
+		}
+	}
+	if strings.Contains(page, "<link") || strings.Contains(page, "src=") {
+		t.Error("Expected no external resources")
+	}
+	if strings.Index(page, "Critical (1)") > strings.Index(page, "Info (1)") {
+		t.Error("Expected critical signals before info signals")
+	}
+}
+
+func TestRenderHTMLIndex(t *testing.T) {
+	dir := t.TempDir()
+	entries := []HTMLIndexEntry{
+		NewHTMLIndexEntry(htmlFixture(), "Laksa.html"),
+		{URL: "https://broken.example.com/", Error: "unexpected status: 500"},
+	}
+	if err := NewRenderer(false).RenderHTMLIndex(entries, filepath.Join(dir, "index.html")); err != nil {
+		t.Fatalf("RenderHTMLIndex failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+
+	if entries[0].Dead != 1 || entries[0].Critical != 1 {
+		t.Errorf("Unexpected index entry: %+v", entries[0])
+	}
+	for _, want := range []string{`href="Laksa.html"`, `data-confidence="failed"`, "unexpected status: 500"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected index to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package pipeline
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// JUnit XML as understood by common CI systems
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// RenderJUnit writes a JUnit XML report with one test case per scanned URL.
+// Gate failures become <failure>, scan errors become <error>.
+func (r *Renderer) RenderJUnit(results []CIResult, path string) error {
+	suite := junitTestSuite{Name: "entropia"}
+
+	for _, result := range results {
+		testCase := junitTestCase{
+			ClassName: "entropia." + junitHost(result.URL),
+			Name:      result.URL,
+		}
+
+		switch {
+		case result.Error != nil:
+			suite.Errors++
+			testCase.Error = &junitProblem{
+				Message: result.Error.Error(),
+				Type:    "scan_error",
+			}
+		case result.Report != nil:
+			testCase.SystemOut = junitSummary(result.Report)
+			if len(result.Failures) > 0 {
+				suite.Failures++
+				testCase.Failure = &junitProblem{
+					Message: result.Failures[0],
+					Type:    "quality_gate",
+					Text:    strings.Join(result.Failures, "\n"),
+				}
+			}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+	}
+
+	suites := junitTestSuites{
+		Name:     "entropia",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode JUnit: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write JUnit: %w", err)
+	}
+	return nil
+}
+
+// junitHost groups test cases by host (dots and ports replaced so CI tools
+// do not split it into packages)
+func junitHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return "unknown"
+	}
+	return strings.NewReplacer(".", "_", ":", "_").Replace(parsed.Host)
+}
+
+// junitSummary renders the support index and signals as test output
+func junitSummary(report *model.Report) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Support index: %d/100 (%s confidence)\n", report.Score.Index, report.Score.Confidence)
+	for _, signal := range report.Score.Signals {
+		fmt.Fprintf(&b, "[%s] %s: %s\n", signal.Severity, signal.Type, signal.Description)
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package pipeline
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/markup"
+)
+
+// markupFormat returns the markup language of a page (markup.FormatMarkdown
+// or markup.FormatRST), or "" for HTML
+func markupFormat(result *FetchResult) string {
+	name := result.FinalURL
+	if parsed, err := url.Parse(result.FinalURL); err == nil {
+		name = parsed.Path
+	}
+	return markup.Format(result.Meta.ContentType, name)
+}
+
+// markupResult converts a Markdown or reStructuredText page into HTML for
+// the docs adapter. Relative links resolve against the page URL as usual;
+// in files read from disk, root-relative links (/docs/x.md) resolve against
+// the repository root, as they do when the repository is browsed.
+func markupResult(result *FetchResult, format string) *FetchResult {
+	var opts markup.Options
+	if root := repositoryRoot(result.FinalURL); root != "" {
+		opts.Link = func(href string) string {
+			if strings.HasPrefix(href, "/") && !strings.HasPrefix(href, "//") {
+				return FileURL(filepath.Join(root, filepath.FromSlash(href)))
+			}
+			return href
+		}
+	}
+	doc := markup.Convert(format, result.HTML, opts)
+
+	meta := result.Meta
+	meta.Title = doc.Title
+	subject := result.Subject
+	if doc.Title != "" {
+		subject = doc.Title
+	}
+
+	return &FetchResult{
+		HTML:     doc.HTML,
+		Meta:     meta,
+		Subject:  subject,
+		FinalURL: result.FinalURL,
+	}
+}
+
+// repositoryRoot returns the nearest directory above a file:// page that
+// contains .git, or "" for other pages and files outside a repository
+func repositoryRoot(pageURL string) string {
+	if !strings.HasPrefix(pageURL, "file://") {
+		return ""
+	}
+	dir, err := filepath.Abs(filepath.Dir(FilePath(pageURL)))
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+func TestScanURL_MarkdownFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(repo, "README.md"), "# Readme\n")
+	writeFile(t, filepath.Join(repo, "docs", "guide", "install.md"), "# Install\n")
+	page := filepath.Join(repo, "docs", "index.md")
+	writeFile(t, page, `# Project docs
+
+The scanner was first introduced in 2024, according to the [install guide](guide/install.md#setup).
+The report format is defined as in the [missing spec](spec.md) and the [readme](/README.md).
+It was originally developed at the lab[^history].
+
+[^history]: See `+server.URL+`/history for details.
+`)
+
+	// No --adapter: the docs adapter is picked by the .md extension
+	result, err := newTestPipeline("").ScanURL(context.Background(), FileURL(page))
+	if err != nil {
+		t.Fatalf("ScanURL failed: %v", err)
+	}
+	report := result.Report
+
+	if report.Adapter != "docs" || report.Subject != "Project docs" {
+		t.Errorf("Adapter = %q, subject = %q; want docs, Project docs", report.Adapter, report.Subject)
+	}
+	if len(report.Claims) != 3 {
+		t.Errorf("Expected 3 claims, got %+v", report.Claims)
+	}
+
+	want := map[string]struct {
+		kind   model.EvidenceKind
+		status model.ValidationStatus
+	}{
+		FileURL(filepath.Join(repo, "docs", "guide", "install.md")) + "#setup": {model.EvidenceKindExternalLink, model.ValidationAccessible},
+		FileURL(filepath.Join(repo, "docs", "spec.md")):                        {model.EvidenceKindExternalLink, model.ValidationDead},
+		FileURL(filepath.Join(repo, "README.md")):                              {model.EvidenceKindExternalLink, model.ValidationAccessible},
+		server.URL + "/history":                                                {model.EvidenceKindCitation, model.ValidationAccessible},
+	}
+	if len(report.Evidence) != len(want) {
+		t.Fatalf("Expected %d evidence links, got %+v", len(want), report.Evidence)
+	}
+	for i, ev := range report.Evidence {
+		expected, ok := want[ev.URL]
+		if !ok {
+			t.Errorf("Unexpected evidence %s", ev.URL)
+			continue
+		}
+		if ev.Kind != expected.kind || report.Validation[i].Status != expected.status {
+			t.Errorf("%s: kind %s, status %s; want %s, %s", ev.URL, ev.Kind, report.Validation[i].Status, expected.kind, expected.status)
+		}
+	}
+
+	// Inline links and the footnote marker anchor each claim to its evidence
+	for _, claim := range report.Claims {
+		if claim.Support != model.ClaimSupported {
+			t.Errorf("Expected claim to be supported: %+v", claim)
+		}
+	}
+}
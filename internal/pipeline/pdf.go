@@ -0,0 +1,115 @@
+package pipeline
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/pdf"
+	"golang.org/x/net/html"
+)
+
+// pdfReference matches URLs and DOIs (bare or "doi:"-prefixed) in PDF text
+var pdfReference = regexp.MustCompile(`https?://[^\s<>"]+|\b(?:doi:\s*)?10\.\d{4,9}/[^\s<>"]+`)
+
+// isPDF reports whether a fetched page is a PDF, by Content-Type or by the
+// %PDF- signature (servers often send application/octet-stream)
+func isPDF(result *FetchResult) bool {
+	mediaType, _, _ := strings.Cut(result.Meta.ContentType, ";")
+	switch strings.ToLower(strings.TrimSpace(mediaType)) {
+	case "application/pdf", "application/x-pdf":
+		return true
+	}
+	head := result.HTML
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	return pdf.IsPDF([]byte(head))
+}
+
+// pdfResult converts a fetched PDF into plain HTML for the adapters: one
+// paragraph per text block with URLs and DOIs linked, followed by each
+// page's link annotations. Title and creation date go into FetchMeta.
+func pdfResult(result *FetchResult) (*FetchResult, error) {
+	doc, err := pdf.Parse([]byte(result.HTML))
+	if err != nil {
+		return nil, err
+	}
+
+	meta := result.Meta
+	meta.Title = doc.Title
+	if !doc.Created.IsZero() {
+		meta.CreationDate = doc.Created.Format(time.RFC3339)
+	}
+	subject := result.Subject
+	if doc.Title != "" {
+		subject = doc.Title
+	}
+
+	var b strings.Builder
+	b.WriteString("<html><head><title>")
+	b.WriteString(html.EscapeString(doc.Title))
+	b.WriteString("</title></head><body><main>\n")
+	for _, page := range doc.Pages {
+		for _, paragraph := range page.Paragraphs {
+			b.WriteString("<p>")
+			b.WriteString(linkReferences(paragraph))
+			b.WriteString("</p>\n")
+		}
+		if len(page.Links) > 0 {
+			b.WriteString("<p>")
+			for _, link := range page.Links {
+				b.WriteString(`<a href="` + html.EscapeString(link) + `">` + html.EscapeString(link) + "</a> ")
+			}
+			b.WriteString("</p>\n")
+		}
+	}
+	b.WriteString("</main></body></html>\n")
+
+	return &FetchResult{
+		HTML:     b.String(),
+		Meta:     meta,
+		Subject:  subject,
+		FinalURL: result.FinalURL,
+	}, nil
+}
+
+// linkReferences escapes text and wraps URLs and DOIs in links (DOIs
+// resolve through doi.org)
+func linkReferences(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range pdfReference.FindAllStringIndex(text, -1) {
+		match := trimReference(text[loc[0]:loc[1]])
+		end := loc[0] + len(match)
+
+		href := match
+		if !strings.HasPrefix(match, "http") {
+			doi := strings.TrimSpace(strings.TrimPrefix(match, "doi:"))
+			href = "https://doi.org/" + doi
+		}
+
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		b.WriteString(`<a href="` + html.EscapeString(href) + `">` + html.EscapeString(match) + "</a>")
+		last = end
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// trimReference drops sentence punctuation and unbalanced closing brackets
+// from the end of a URL or DOI
+func trimReference(s string) string {
+	for len(s) > 0 {
+		switch last := s[len(s)-1]; {
+		case strings.IndexByte(".,;:'\"", last) >= 0:
+			s = s[:len(s)-1]
+		case last == ')' && strings.Count(s, "(") < strings.Count(s, ")"),
+			last == ']' && strings.Count(s, "[") < strings.Count(s, "]"):
+			s = s[:len(s)-1]
+		default:
+			return s
+		}
+	}
+	return s
+}
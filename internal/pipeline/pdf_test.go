@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// statutePDF is a one-page uncompressed PDF with metadata, a link
+// annotation and a DOI in its text
+func statutePDF(evidenceURL string) string {
+	content := `BT /F1 11 Tf 72 720 Td (Under this act, every employer must keep records.) Tj
+0 -13 Td (Records shall be kept for six years, see doi:10.5555/12345678.) Tj ET`
+	objects := []string{
+		`<< /Type /Catalog /Pages 2 0 R >>`,
+		`<< /Type /Pages /Kids [3 0 R] /Count 1 >>`,
+		`<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R /Annots [6 0 R] >>`,
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		`<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>`,
+		fmt.Sprintf(`<< /Type /Annot /Subtype /Link /A << /S /URI /URI (%s) >> >>`, evidenceURL),
+		`<< /Title (Employment Records Act 2019) /CreationDate (D:20190702101500Z) >>`,
+	}
+
+	var b strings.Builder
+	b.WriteString("%PDF-1.4\n")
+	for i, obj := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R /Info 7 0 R >>\n%%EOF\n")
+	return b.String()
+}
+
+func TestScanURL_PDF(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/statute/records.pdf" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		// Detected by signature even with a generic content type
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = fmt.Fprint(w, statutePDF(server.URL+"/guidance"))
+	}))
+	defer server.Close()
+
+	result, err := newTestPipeline("").ScanURL(context.Background(), server.URL+"/statute/records.pdf")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report := result.Report
+	if report.Adapter != "legal" {
+		t.Errorf("Expected legal adapter for a statute PDF, got %q", report.Adapter)
+	}
+	if report.Subject != "Employment Records Act 2019" || report.FetchMeta.Title != report.Subject {
+		t.Errorf("Expected subject from PDF title, got %q (meta title %q)", report.Subject, report.FetchMeta.Title)
+	}
+	if report.FetchMeta.CreationDate != "2019-07-02T10:15:00Z" {
+		t.Errorf("Unexpected creation date %q", report.FetchMeta.CreationDate)
+	}
+	if len(report.Claims) != 2 {
+		t.Errorf("Expected 2 claims from PDF text, got %+v", report.Claims)
+	}
+
+	urls := make(map[string]bool)
+	for _, ev := range report.Evidence {
+		urls[ev.URL] = true
+	}
+	for _, want := range []string{server.URL + "/guidance", "https://doi.org/10.5555/12345678"} {
+		if !urls[want] {
+			t.Errorf("Expected evidence %s, got %v", want, urls)
+		}
+	}
+}
+
+func TestLinkReferences(t *testing.T) {
+	tests := map[string]string{
+		"See https://example.com/a_(b) today.":  `See <a href="https://example.com/a_(b)">https://example.com/a_(b)</a> today.`,
+		"(https://example.com/x).":              `(<a href="https://example.com/x">https://example.com/x</a>).`,
+		"Cited as doi: 10.1000/182, p. 4":       `Cited as <a href="https://doi.org/10.1000/182">doi: 10.1000/182</a>, p. 4`,
+		"Plain <text> & no links":               `Plain &lt;text&gt; &amp; no links`,
+		"Version 10.2/3 is not a DOI":           `Version 10.2/3 is not a DOI`,
+		"Ref 10.1038/nature12373; also 10.1126": `Ref <a href="https://doi.org/10.1038/nature12373">10.1038/nature12373</a>; also 10.1126`,
+	}
+	for in, want := range tests {
+		if got := linkReferences(in); got != want {
+			t.Errorf("linkReferences(%q) =\n  %s\nwant\n  %s", in, got, want)
+		}
+	}
+}
@@ -4,31 +4,40 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
+	"github.com/ppiankov/entropia/internal/archive"
 	"github.com/ppiankov/entropia/internal/cache"
+	"github.com/ppiankov/entropia/internal/entities"
 	"github.com/ppiankov/entropia/internal/extract"
 	"github.com/ppiankov/entropia/internal/extract/adapters"
+	"github.com/ppiankov/entropia/internal/history"
+	"github.com/ppiankov/entropia/internal/lang"
 	"github.com/ppiankov/entropia/internal/llm"
 	"github.com/ppiankov/entropia/internal/model"
 	"github.com/ppiankov/entropia/internal/score"
+	"github.com/ppiankov/entropia/internal/util"
 	"github.com/ppiankov/entropia/internal/validate"
 	"golang.org/x/net/html"
 )
 
 // Pipeline orchestrates the complete scan process
 type Pipeline struct {
-	fetcher        *Fetcher
-	claimExtractor *extract.ClaimExtractor
-	evidExtractor  *extract.EvidenceExtractor
-	validator      *validate.Validator
-	scorer         *score.Scorer
-	renderer       *Renderer
-	summarizer     *llm.Summarizer // Optional LLM summarizer (nil if disabled)
-	cache          *cache.LayeredCache
-	config         *model.Config
+	fetcher     *Fetcher
+	adapters    *adapters.Registry
+	validator   *validate.Validator
+	scorer      *score.Scorer
+	renderer    *Renderer
+	summarizer  *llm.Summarizer // Optional LLM summarizer (nil if disabled)
+	cache       *cache.LayeredCache
+	history     *history.Store               // Optional scan history (nil if disabled)
+	robots      *util.RobotsChecker          // Optional robots.txt enforcement (nil if disabled)
+	archive     *archive.Client              // Optional archive lookup for dead evidence (nil if disabled)
+	identifiers *extract.IdentifierExtractor // Optional DOI/arXiv/ISBN extraction (nil if disabled)
+	documents   *DocumentStore               // Optional local copies served instead of fetching (nil = network only)
+	entities    *entities.Catalog            // Historical entities flagged in Wikipedia articles
+	config      *model.Config
 }
 
 // NewPipeline creates a new pipeline with the given configuration
@@ -54,40 +63,130 @@ func NewPipeline(cfg *model.Config) *Pipeline {
 	// Initialize cache if enabled
 	var lc *cache.LayeredCache
 	if cfg.Cache.Enabled {
-		cacheDir := cfg.Cache.Dir
-		if strings.HasPrefix(cacheDir, "~/") {
-			home, err := os.UserHomeDir()
-			if err == nil {
-				cacheDir = home + cacheDir[1:]
-			}
+		lc = cache.NewLayeredCache(cfg.Cache.TTL, util.ExpandHome(cfg.Cache.Dir), cfg.Cache.TTL)
+	}
+
+	// Initialize scan history if enabled
+	var hs *history.Store
+	if cfg.History.Enabled {
+		hs = history.NewStore(util.ExpandHome(cfg.History.Dir))
+	}
+
+	// Load custom scoring rules if configured (fall back to built-in rules)
+	scorer := score.NewScorer()
+	if cfg.Scoring.RulesFile != "" {
+		rules, err := score.LoadRules(cfg.Scoring.RulesFile)
+		if err != nil {
+			fmt.Printf("Warning: Failed to load scoring rules, using built-in rules: %v\n", err)
+		} else {
+			scorer = score.NewScorerWithRules(rules)
+		}
+	}
+
+	// Load a custom entity catalog if configured (fall back to the bundled one)
+	catalog := entities.Default()
+	if cfg.Entities.CatalogFile != "" {
+		loaded, err := entities.Load(cfg.Entities.CatalogFile)
+		if err != nil {
+			fmt.Printf("Warning: Failed to load entity catalog, using bundled catalog: %v\n", err)
+		} else {
+			catalog = loaded
+		}
+	}
+
+	// Load keyword packs if configured (fall back to the bundled packs)
+	registry := adapters.NewRegistry()
+	if cfg.Extraction.Language != "" || len(cfg.Extraction.KeywordPacks) > 0 {
+		packs, err := lang.Load(cfg.Extraction.KeywordPacks, cfg.Extraction.Language)
+		if err != nil {
+			fmt.Printf("Warning: Failed to load keyword packs, using bundled packs: %v\n", err)
+		} else {
+			registry.SetLanguagePacks(packs)
 		}
-		lc = cache.NewLayeredCache(cfg.Cache.TTL, cacheDir, cfg.Cache.TTL)
+	}
+
+	fetcher := NewFetcher(cfg.HTTP.Timeout, cfg.HTTP.UserAgent, cfg.HTTP.MaxBodyBytes, cfg.HTTP.InsecureTLS, cfg.HTTP.HTTPProxy, cfg.HTTP.HTTPSProxy, cfg.HTTP.NoProxy)
+	validator := validate.NewValidator(10*time.Second, cfg.Concurrency.ValidationWorkers, &cfg.Authority, cfg.HTTP.HTTPProxy, cfg.HTTP.HTTPSProxy, cfg.HTTP.NoProxy)
+	validator.SetUserAgent(cfg.HTTP.UserAgent)
+	if cfg.Validation.ContentDates {
+		validator.SetContentDates(cfg.Validation.MaxBodyBytes)
+	}
+	if cfg.Validation.Soft404 {
+		validator.SetSoft404Detection(cfg.Validation.MaxBodyBytes, cfg.Validation.Soft404Probe)
+	}
+
+	// Resolve cited DOIs, arXiv IDs and ISBNs at their registries
+	var identifiers *extract.IdentifierExtractor
+	if cfg.Identifiers.Enabled {
+		identifiers = extract.NewIdentifierExtractor(cfg.Identifiers)
+		validator.SetIdentifierResolvers(cfg.Identifiers)
+	}
+
+	// Share one robots.txt cache between page fetches and evidence validation
+	var robots *util.RobotsChecker
+	if cfg.RateLimiting.RespectRobotsTxt {
+		robots = util.NewRobotsChecker(cfg.HTTP.UserAgent, 10*time.Second)
+		robots.SetProxy(util.NewProxyFunc(cfg.HTTP.HTTPProxy, cfg.HTTP.HTTPSProxy, cfg.HTTP.NoProxy))
+		fetcher.SetRobotsChecker(robots)
+		validator.SetRobotsChecker(robots)
+	}
+
+	// Look up archived copies of dead evidence
+	var ac *archive.Client
+	if cfg.Archive.Enabled && cfg.Archive.CDXURL != "" {
+		ac = archive.NewClient(cfg.Archive.CDXURL, cfg.Archive.SnapshotURL, cfg.Archive.Timeout, cfg.HTTP.UserAgent, cfg.HTTP.HTTPProxy, cfg.HTTP.HTTPSProxy, cfg.HTTP.NoProxy)
 	}
 
 	return &Pipeline{
-		fetcher:        NewFetcher(cfg.HTTP.Timeout, cfg.HTTP.UserAgent, cfg.HTTP.MaxBodyBytes, cfg.HTTP.InsecureTLS, cfg.HTTP.HTTPProxy, cfg.HTTP.HTTPSProxy, cfg.HTTP.NoProxy),
-		claimExtractor: extract.NewClaimExtractor(),
-		evidExtractor:  extract.NewEvidenceExtractor(),
-		validator:      validate.NewValidator(10*time.Second, cfg.Concurrency.ValidationWorkers, &cfg.Authority, cfg.HTTP.HTTPProxy, cfg.HTTP.HTTPSProxy, cfg.HTTP.NoProxy),
-		scorer:         score.NewScorer(),
-		renderer:       NewRenderer(cfg.Output.IncludeFooter),
-		summarizer:     summarizer,
-		cache:          lc,
-		config:         cfg,
+		fetcher:     fetcher,
+		adapters:    registry,
+		validator:   validator,
+		scorer:      scorer,
+		renderer:    NewRenderer(cfg.Output.IncludeFooter),
+		summarizer:  summarizer,
+		cache:       lc,
+		history:     hs,
+		robots:      robots,
+		archive:     ac,
+		identifiers: identifiers,
+		entities:    catalog,
+		config:      cfg,
 	}
 }
 
+// Robots returns the pipeline's robots.txt checker, or nil when
+// rate_limiting.respect_robots_txt is off. Batch callers pass it to
+// worker.Limiter so Crawl-delay is honored.
+func (p *Pipeline) Robots() *util.RobotsChecker {
+	return p.robots
+}
+
 // ScanResult contains the complete scan result
 type ScanResult struct {
 	Report *model.Report
 	Error  error
 }
 
-// ScanURL scans a single URL and generates a complete report
+// SetDocumentStore makes ScanURL read the URLs in store from their local
+// copies (WARC records, saved HTML) instead of fetching them
+func (p *Pipeline) SetDocumentStore(store *DocumentStore) {
+	p.documents = store
+}
+
+// ScanURL scans a single URL and generates a complete report. file:// URLs
+// and URLs in the document store are read locally and never cached.
 func (p *Pipeline) ScanURL(ctx context.Context, url string) (*ScanResult, error) {
+	// Resolve a forced adapter up front so a typo fails before any network I/O
+	forced, err := p.forcedAdapter()
+	if err != nil {
+		return nil, err
+	}
+
+	local := p.isLocal(url)
+
 	// Check cache first
-	if p.cache != nil {
-		key := cache.CacheKey(url)
+	if p.cache != nil && !local {
+		key := p.cacheKey(url)
 		if data, found := p.cache.Get(key); found {
 			var report model.Report
 			if err := json.Unmarshal(data, &report); err == nil {
@@ -96,61 +195,172 @@ func (p *Pipeline) ScanURL(ctx context.Context, url string) (*ScanResult, error)
 		}
 	}
 
-	// 1. Fetch HTML
-	fetchResult, err := p.fetcher.FetchWithRetry(ctx, url)
-	if err != nil {
+	// 1. Fetch HTML (or read the local copy)
+	var fetchResult *FetchResult
+	if local {
+		if fetchResult, err = p.loadLocal(url); err != nil {
+			return nil, fmt.Errorf("load: %w", err)
+		}
+	} else if fetchResult, err = p.fetcher.FetchWithRetry(ctx, url); err != nil {
 		return nil, fmt.Errorf("fetch: %w", err)
 	}
 
+	return p.analyze(ctx, url, fetchResult, forced, !local)
+}
+
+// ScanDocument scans a page that was loaded without HTTP (see LoadSource);
+// the report is recorded in history under the page URL but never cached
+func (p *Pipeline) ScanDocument(ctx context.Context, doc *FetchResult) (*ScanResult, error) {
+	forced, err := p.forcedAdapter()
+	if err != nil {
+		return nil, err
+	}
+	return p.analyze(ctx, doc.FinalURL, doc, forced, false)
+}
+
+// forcedAdapter returns the adapter named in config (nil = auto-detect)
+func (p *Pipeline) forcedAdapter() (adapters.Adapter, error) {
+	name := p.config.Extraction.Adapter
+	if name == "" {
+		return nil, nil
+	}
+	adapter, ok := p.adapters.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown adapter %q (available: %s)", name, strings.Join(p.adapters.Names(), ", "))
+	}
+	return adapter, nil
+}
+
+// isLocal reports whether url is read from disk rather than fetched
+func (p *Pipeline) isLocal(url string) bool {
+	if strings.HasPrefix(url, "file://") {
+		return true
+	}
+	if p.documents != nil {
+		_, ok := p.documents.pages[url]
+		return ok
+	}
+	return false
+}
+
+// loadLocal reads a stored copy or a file:// URL
+func (p *Pipeline) loadLocal(url string) (*FetchResult, error) {
+	if p.documents != nil {
+		if doc, ok, err := p.documents.Load(url); ok {
+			return doc, err
+		}
+	}
+	return LoadFile(FilePath(url), "", p.config.HTTP.MaxBodyBytes)
+}
+
+// analyze extracts, validates and scores a fetched page. key names the page
+// in the cache and history.
+func (p *Pipeline) analyze(ctx context.Context, key string, fetchResult *FetchResult, forced adapters.Adapter, cacheable bool) (*ScanResult, error) {
+	// PDFs are converted to plain HTML so every adapter can extract from them
+	if isPDF(fetchResult) {
+		converted, err := pdfResult(fetchResult)
+		if err != nil {
+			return nil, fmt.Errorf("parse PDF: %w", err)
+		}
+		fetchResult = converted
+	}
+
+	// Markdown and reStructuredText docs likewise become HTML
+	if format := markupFormat(fetchResult); format != "" {
+		fetchResult = markupResult(fetchResult, format)
+	}
+
 	// Generate TLS-related signals
 	tlsSignals := p.generateTLSSignals(fetchResult.FinalURL, fetchResult.Meta.TLS)
 
+	doc, err := html.Parse(strings.NewReader(fetchResult.HTML))
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML: %w", err)
+	}
+
+	// Select the domain adapter for this page (forced via config, or by URL/content type)
+	adapter := forced
+	if adapter == nil {
+		adapter = p.adapters.FindAdapter(fetchResult.FinalURL, fetchResult.Meta.ContentType)
+	}
+
 	// 2. Extract claims
-	claims, err := p.claimExtractor.Extract(fetchResult.HTML)
+	claims, err := adapter.ExtractClaims(doc, fetchResult.FinalURL)
 	if err != nil {
-		return nil, fmt.Errorf("extract claims: %w", err)
+		return nil, fmt.Errorf("extract claims (%s adapter): %w", adapter.Name(), err)
 	}
+	claims = extract.AnnotateDates(claims)
 
 	// 3. Extract evidence
-	evidence, err := p.evidExtractor.Extract(fetchResult.HTML, fetchResult.FinalURL)
+	evidence, err := adapter.ExtractEvidence(doc, fetchResult.FinalURL)
 	if err != nil {
-		return nil, fmt.Errorf("extract evidence: %w", err)
+		return nil, fmt.Errorf("extract evidence (%s adapter): %w", adapter.Name(), err)
 	}
 
+	// Cited DOIs, arXiv IDs and ISBNs are evidence even without a link
+	if p.identifiers != nil {
+		evidence = p.identifiers.Extract(doc, evidence)
+	}
+
+	// Bibliographic fields from COinS spans next to the citations
+	evidence = extract.AttachCitations(doc, fetchResult.FinalURL, evidence)
+
+	// Anchor claims to the evidence cited in their sentence or paragraph
+	claims = extract.LinkClaims(doc, fetchResult.FinalURL, claims, evidence)
+
 	// 4. Validate evidence concurrently
 	validation, err := p.validator.Validate(ctx, evidence)
 	if err != nil {
 		return nil, fmt.Errorf("validate evidence: %w", err)
 	}
+	// A snapshot the citing page links beside a dead source needs no lookup
+	for i := range validation {
+		if evidence[i].ArchiveURL != "" && archive.IsDead(validation[i]) {
+			validation[i].ArchiveChecked = true
+			validation[i].ArchivedURL = evidence[i].ArchiveURL
+			if capturedAt, ok := archive.SnapshotTime(evidence[i].ArchiveURL); ok {
+				validation[i].ArchivedAt = &capturedAt
+			}
+		}
+	}
+	if p.archive != nil {
+		p.archive.AnnotateDead(ctx, validation)
+	}
+
+	// Fields read from the evidence pages complete those from the citing page
+	for i := range validation {
+		if validation[i].Citation != nil {
+			evidence[i].Citation = evidence[i].Citation.Merge(validation[i].Citation)
+		}
+	}
 
 	// 5. Calculate score
 	scoreResult := p.scorer.Calculate(claims, evidence, validation)
 
 	// Append TLS signals to score
-	scoreResult.Signals = append(scoreResult.Signals, tlsSignals...)
+	scoreResult = p.scorer.AddSignals(scoreResult, len(evidence), tlsSignals)
 
-	// 6. Detect Wikipedia-specific conflicts (edit wars, historical entities)
-	if strings.Contains(fetchResult.FinalURL, "wikipedia.org") {
-		doc, err := html.Parse(strings.NewReader(fetchResult.HTML))
-		if err == nil {
-			adapter := adapters.NewWikipediaAdapter()
+	// 6. Detect Wikipedia-specific conflicts (edit wars, historical entities, cleanup tags)
+	if wikiAdapter, ok := adapter.(*adapters.WikipediaAdapter); ok {
+		// Create a separate context with shorter timeout for conflict detection
+		conflictCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
 
-			// Create a separate context with shorter timeout for conflict detection
-			conflictCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-			defer cancel()
-
-			conflictSignals := adapter.DetectWikipediaConflicts(conflictCtx, fetchResult.FinalURL, fetchResult.HTML, doc)
-			// Append conflict signals to score
-			scoreResult.Signals = append(scoreResult.Signals, conflictSignals...)
-		}
+		conflictSignals := wikiAdapter.DetectWikipediaConflicts(conflictCtx, fetchResult.FinalURL, fetchResult.HTML, doc, p.config.Wikipedia, p.entities)
+		// Append conflict signals to score
+		scoreResult = p.scorer.AddSignals(scoreResult, len(evidence), conflictSignals)
 	}
 
+	// Claims dating a historical entity to years it did not exist
+	scoreResult = p.scorer.AddSignals(scoreResult, len(evidence), anachronismSignals(p.entities.Anachronisms(claims)))
+
 	// 7. Build report (without LLM summary yet)
 	report := &model.Report{
 		Subject:    fetchResult.Subject,
 		SourceURL:  fetchResult.FinalURL,
 		FetchedAt:  time.Now().UTC(),
 		FetchMeta:  fetchResult.Meta,
+		Adapter:    adapter.Name(),
 		Claims:     claims,
 		Evidence:   evidence,
 		Validation: validation,
@@ -159,14 +369,20 @@ func (p *Pipeline) ScanURL(ctx context.Context, url string) (*ScanResult, error)
 	}
 
 	// 8. Store in cache (before LLM summary — cache the deterministic result)
-	if p.cache != nil {
+	if p.cache != nil && cacheable {
 		if data, err := json.Marshal(report); err == nil {
-			key := cache.CacheKey(url)
-			_ = p.cache.Set(key, data, p.config.Cache.TTL)
+			_ = p.cache.Set(p.cacheKey(key), data, p.config.Cache.TTL)
+		}
+	}
+
+	// 9. Record in scan history (never overwritten, unlike the cache)
+	if p.history != nil {
+		if err := p.history.Append(key, report); err != nil {
+			fmt.Printf("Warning: Failed to record scan history: %v\n", err)
 		}
 	}
 
-	// 9. Generate LLM summary if enabled (AFTER scoring, never affects score)
+	// 10. Generate LLM summary if enabled (AFTER scoring, never affects score)
 	if p.summarizer != nil && p.summarizer.IsEnabled() {
 		llmSummary, err := p.summarizer.GenerateSummary(ctx, *report)
 		if err != nil {
@@ -183,8 +399,47 @@ func (p *Pipeline) ScanURL(ctx context.Context, url string) (*ScanResult, error)
 	}, nil
 }
 
+// cacheKey returns the cache key for a URL; forced adapters, custom
+// scoring rules, custom entity catalogs, keyword packs and non-default
+// validation and archive settings get their own entries so they never
+// serve (or poison) the default result
+func (p *Pipeline) cacheKey(url string) string {
+	key := url
+	if name := p.config.Extraction.Adapter; name != "" {
+		key += "#adapter=" + name
+	}
+	if rulesFile := p.config.Scoring.RulesFile; rulesFile != "" {
+		key += "#rules=" + rulesFile
+	}
+	if !p.config.Identifiers.Enabled {
+		key += "#identifiers=off"
+	}
+	if catalogFile := p.config.Entities.CatalogFile; catalogFile != "" {
+		key += "#entities=" + catalogFile
+	}
+	if language := p.config.Extraction.Language; language != "" {
+		key += "#lang=" + language
+	}
+	if packs := p.config.Extraction.KeywordPacks; len(packs) > 0 {
+		key += "#packs=" + strings.Join(packs, ",")
+	}
+	if p.config.Validation.ContentDates {
+		key += "#content-dates"
+	}
+	if p.config.Validation.Soft404 {
+		key += "#soft404"
+		if p.config.Validation.Soft404Probe {
+			key += "=probe"
+		}
+	}
+	if !p.config.Archive.Enabled {
+		key += "#archive=off"
+	}
+	return cache.CacheKey(key)
+}
+
 // RenderReport renders the report to the specified outputs
-func (p *Pipeline) RenderReport(report *model.Report, jsonPath string, mdPath string, verbose bool) error {
+func (p *Pipeline) RenderReport(report *model.Report, jsonPath, mdPath, htmlPath string, verbose bool) error {
 	// Render JSON
 	if jsonPath != "" {
 		if err := p.renderer.RenderJSON(report, jsonPath); err != nil {
@@ -205,6 +460,16 @@ func (p *Pipeline) RenderReport(report *model.Report, jsonPath string, mdPath st
 		}
 	}
 
+	// Render self-contained HTML
+	if htmlPath != "" {
+		if err := p.renderer.RenderHTML(report, htmlPath); err != nil {
+			return fmt.Errorf("render HTML: %w", err)
+		}
+		if verbose {
+			fmt.Printf("✓ Wrote HTML: %s\n", htmlPath)
+		}
+	}
+
 	// Render LLM summary to separate file if present
 	if report.LLM != nil && report.LLM.Enabled && mdPath != "" {
 		llmMdPath := strings.TrimSuffix(mdPath, ".md") + ".llm.md"
@@ -222,6 +487,31 @@ func (p *Pipeline) RenderReport(report *model.Report, jsonPath string, mdPath st
 	return nil
 }
 
+// anachronismSignals creates one signal per claim that dates a historical
+// entity outside its lifespan
+func anachronismSignals(found []entities.Anachronism) []model.Signal {
+	var signals []model.Signal
+	for _, a := range found {
+		signals = append(signals, model.Signal{
+			Type:        model.SignalAnachronism,
+			Severity:    model.SeverityWarning,
+			Description: fmt.Sprintf("Anachronism: claim dates %s to %s, but it existed %s", a.Entity.Name, a.Date.Text, a.Entity.Lifespan()),
+			Data: map[string]interface{}{
+				"claim":        a.Claim.Text,
+				"entity":       a.Entity.Name,
+				"matched_name": a.Name,
+				"started":      a.Entity.StartYear,
+				"ended":        a.Entity.EndYear,
+				"date":         a.Date.Text,
+				"date_from":    a.Date.From,
+				"date_to":      a.Date.To,
+				"explanation":  "The claim places the entity in a period when it did not exist, which may indicate a contested or retroactive origin claim",
+			},
+		})
+	}
+	return signals
+}
+
 // generateTLSSignals creates signals for TLS/certificate issues
 func (p *Pipeline) generateTLSSignals(url string, tls *model.TLSInfo) []model.Signal {
 	var signals []model.Signal
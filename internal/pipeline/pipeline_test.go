@@ -0,0 +1,467 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// newTestPipeline returns a pipeline with caching, history, archive lookups and LLM disabled
+func newTestPipeline(adapter string) *Pipeline {
+	cfg := model.DefaultConfig()
+	cfg.Cache.Enabled = false
+	cfg.History.Enabled = false
+	cfg.Archive.Enabled = false
+	cfg.Extraction.Adapter = adapter
+	return NewPipeline(cfg)
+}
+
+func newArticleServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/article" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = fmt.Fprintf(w, `<html><body><main>
+			<p>Laksa originated in the Peranakan communities of the Malay peninsula.</p>
+			<p>Under the law, the dish must be served hot according to tradition.</p>
+			<a href="%s/source-1">Source one</a>
+			<a href="%s/source-2">Source two</a>
+		</main></body></html>`, server.URL, server.URL)
+	}))
+	return server
+}
+
+func TestScanURL_AutoDetectsGenericAdapter(t *testing.T) {
+	server := newArticleServer(t)
+	defer server.Close()
+
+	result, err := newTestPipeline("").ScanURL(context.Background(), server.URL+"/article")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report := result.Report
+	if report.Adapter != "generic" {
+		t.Errorf("Expected generic adapter, got %q", report.Adapter)
+	}
+	if len(report.Evidence) != 2 {
+		t.Errorf("Expected 2 evidence links from generic adapter, got %d", len(report.Evidence))
+	}
+	if len(report.Claims) == 0 {
+		t.Error("Expected claims from generic adapter")
+	}
+	for _, claim := range report.Claims {
+		// Links sit outside the claim paragraphs, so no claim is anchored
+		if claim.Support != model.ClaimUnsupported {
+			t.Errorf("Expected unanchored claim %q to be unsupported, got %q", claim.Text, claim.Support)
+		}
+	}
+}
+
+func TestScanURL_ForcedAdapter(t *testing.T) {
+	server := newArticleServer(t)
+	defer server.Close()
+
+	result, err := newTestPipeline("legal").ScanURL(context.Background(), server.URL+"/article")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report := result.Report
+	if report.Adapter != "legal" {
+		t.Errorf("Expected legal adapter, got %q", report.Adapter)
+	}
+	for _, claim := range report.Claims {
+		if !strings.HasPrefix(claim.Heuristic, "legal:") {
+			t.Errorf("Expected legal heuristic, got %q", claim.Heuristic)
+		}
+	}
+	if len(report.Evidence) != 2 {
+		t.Errorf("Expected legal adapter to extract 2 evidence links, got %d", len(report.Evidence))
+	}
+}
+
+func TestScanURL_UnknownAdapter(t *testing.T) {
+	_, err := newTestPipeline("nonexistent").ScanURL(context.Background(), "http://127.0.0.1:1/never-fetched")
+	if err == nil {
+		t.Fatal("Expected error for unknown adapter")
+	}
+	if !strings.Contains(err.Error(), `unknown adapter "nonexistent"`) {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestScanURL_CitationMetadata(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Last-Modified", time.Now().Format(time.RFC1123))
+		switch r.URL.Path {
+		case "/article":
+			coins := url.Values{
+				"ctx_ver":  {"Z39.88-2004"},
+				"rft.date": {"2009-04-01"},
+				"rft_id":   {server.URL + "/paper"},
+			}
+			_, _ = fmt.Fprintf(w, `<html><body>
+				<p>The bridge was first opened in 1887 according to the survey.<sup><a href="#note-1">[1]</a></sup></p>
+				<ol><li id="note-1"><cite><a href="%s/paper">Survey</a></cite><span class="Z3988" title="%s"></span></li></ol>
+			</body></html>`, server.URL, coins.Encode())
+		case "/paper":
+			_, _ = fmt.Fprint(w, `<html><head><meta name="citation_title" content="Bridge Survey"><meta name="citation_author" content="Jane Smith"></head></html>`)
+		}
+	}))
+	defer server.Close()
+
+	cfg := model.DefaultConfig()
+	cfg.Cache.Enabled = false
+	cfg.History.Enabled = false
+	cfg.Archive.Enabled = false
+	cfg.Validation.ContentDates = true
+	result, err := NewPipeline(cfg).ScanURL(context.Background(), server.URL+"/article")
+	if err != nil {
+		t.Fatalf("ScanURL failed: %v", err)
+	}
+	report := result.Report
+
+	if len(report.Evidence) != 1 || report.Evidence[0].Citation == nil {
+		t.Fatalf("Expected one cited work with bibliographic fields, got %+v", report.Evidence)
+	}
+	citation := report.Evidence[0].Citation
+	if citation.Title != "Bridge Survey" || len(citation.Authors) != 1 || citation.PublishedAt == nil {
+		t.Errorf("Expected COinS date completed by the page's citation_* fields, got %+v", citation)
+	}
+	// Freshness uses the work's 2009 date, not the page's Last-Modified
+	if v := report.Validation[0]; v.DateSource != model.DateSourceCitedWork || !v.IsVeryStale {
+		t.Errorf("Expected age from the cited work, got %+v", v)
+	}
+}
+
+func TestScanURL_WikipediaReferences(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/Bridge":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = fmt.Fprintf(w, `<html><body><div class="mw-parser-output">
+				<p>The bridge was first opened in 1887 by the city council.<sup class="reference"><a href="#cite_note-1">[1]</a></sup></p>
+				<p>It was designed by an engineer according to local records.<sup class="reference"><a href="#cite_note-2">[2]</a></sup></p>
+				<p>The bridge originated as a ferry crossing.<sup class="noprint Inline-Template Template-Fact">[<i><a href="/wiki/Wikipedia:Citation_needed">citation needed</a></i>]</sup>
+				It was founded by merchants.<sup class="noprint Inline-Template">[<i><a href="/wiki/Wikipedia:Accuracy_dispute#Disputed_statement">dubious</a></i>]</sup></p>
+				<h2>References</h2>
+				<ol class="references">
+					<li id="cite_note-1"><span class="mw-cite-backlink"><a href="#cite_ref-1">^</a></span>
+						<span class="reference-text"><cite class="citation web">Smith, Jane (12 May 2019). <a rel="nofollow" class="external text" href="%[1]s/story">"Opening Day"</a>. <i>City News</i>. <a rel="nofollow" class="external text" href="https://web.archive.org/web/20190601000000/%[1]s/story">Archived</a> from the original on 1 June 2019. Retrieved 3 March 2020.</cite><sup class="noprint Inline-Template">[<i><a href="/wiki/Wikipedia:Link_rot">dead link</a></i>]</sup></span></li>
+					<li id="cite_note-2"><span class="mw-cite-backlink"><a href="#cite_ref-2">^</a></span>
+						<span class="reference-text"><cite class="citation book">Jones, K. (1998). <i>Bridges of the Valley</i>. Valley Press.</cite></span></li>
+				</ol>
+			</div></body></html>`, server.URL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	result, err := newTestPipeline("wikipedia").ScanURL(context.Background(), server.URL+"/wiki/Bridge")
+	if err != nil {
+		t.Fatalf("ScanURL failed: %v", err)
+	}
+	report := result.Report
+
+	if len(report.Evidence) != 2 {
+		t.Fatalf("Expected the web source and the book, got %+v", report.Evidence)
+	}
+	web, book := report.Evidence[0], report.Evidence[1]
+	if web.URL != server.URL+"/story" || !web.MarkedDead || !strings.HasPrefix(web.ArchiveURL, "https://web.archive.org/web/20190601000000/") {
+		t.Errorf("Expected the dead-tagged source with its snapshot, got %+v", web)
+	}
+	if web.Citation == nil || web.Citation.Title != "Opening Day" || web.Citation.PublishedAt == nil ||
+		web.Citation.PublishedAt.Format("2006-01-02") != "2019-05-12" || web.Citation.AccessedAt == nil {
+		t.Errorf("Expected title and dates from the reference, got %+v", web.Citation)
+	}
+	if !book.Offline || book.URL != server.URL+"/wiki/Bridge#cite_note-2" || book.Citation == nil ||
+		book.Citation.Title != "Bridges of the Valley" || book.Citation.PublishedAt == nil || book.Citation.PublishedAt.Year() != 1998 {
+		t.Errorf("Expected the book cited without a link, got %+v", book)
+	}
+
+	// The dead source gets the page's snapshot; the book is neither checked nor dead
+	if v := report.Validation[0]; !v.IsDead || v.ArchivedURL != web.ArchiveURL || v.ArchivedAt == nil {
+		t.Errorf("Expected the linked snapshot on the dead source, got %+v", v)
+	}
+	if v := report.Validation[1]; v.Status != model.ValidationOffline || v.IsDead {
+		t.Errorf("Expected the book to be offline, got %+v", v)
+	}
+	designed := report.Claims[1]
+	if !strings.Contains(designed.Text, "designed") || designed.Support != model.ClaimSupported {
+		t.Errorf("Expected the claim citing the book to be supported, got %+v", designed)
+	}
+
+	var tags *model.Signal
+	for i := range report.Score.Signals {
+		if report.Score.Signals[i].Type == model.SignalCleanupTags {
+			tags = &report.Score.Signals[i]
+		}
+	}
+	if tags == nil || tags.Data["citation_needed"] != 1 || tags.Data["dubious"] != 1 || tags.Data["total"] != 2 {
+		t.Errorf("Expected one citation needed and one dubious tag, got %+v", tags)
+	}
+}
+
+func TestScanURL_WikipediaEditWar(t *testing.T) {
+	// Chronological content hashes: a/b flip-flops are five reverts; the
+	// repeated "a" is a null edit and the "rv" summary is not a revert
+	hashes := []string{"a", "b", "a", "b", "a", "b", "a", "a", "c", "d", "e", "f"}
+	comments := map[int]string{8: "rv vandalism"}
+	now := time.Now()
+
+	var revisions []map[string]interface{}
+	for i := len(hashes) - 1; i >= 0; i-- {
+		revisions = append(revisions, map[string]interface{}{
+			"revid":     i + 1,
+			"timestamp": now.Add(-time.Duration(len(hashes)-i) * time.Hour).UTC().Format(time.RFC3339),
+			"user":      fmt.Sprintf("Editor%d", i%3),
+			"comment":   comments[i],
+			"sha1":      hashes[i],
+		})
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/wiki/Bridge":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = fmt.Fprint(w, `<html><body><div class="mw-parser-output"><p>The bridge was first opened in 1887 by the city council.</p></div></body></html>`)
+		case r.URL.Path != "/w/api.php" || query.Get("format") != "json":
+			http.NotFound(w, r)
+		case query.Get("prop") == "info":
+			_, _ = fmt.Fprint(w, `{"query":{"pages":{"1":{"protection":[{"type":"edit","level":"extendedconfirmed","expiry":"infinity"},{"type":"move","level":"sysop","expiry":"infinity"}]}}}}`)
+		case query.Get("titles") == "Talk:Bridge":
+			_, _ = fmt.Fprintf(w, `{"query":{"pages":{"2":{"revisions":[{"user":"Editor0","timestamp":%[1]q},{"user":"Editor1","timestamp":%[1]q},{"user":"Editor1","timestamp":%[1]q}]}}}}`, now.UTC().Format(time.RFC3339))
+		case query.Get("titles") == "Bridge":
+			if !strings.Contains(query.Get("rvprop"), "sha1") || query.Get("rvend") == "" {
+				t.Errorf("Unexpected revisions query: %s", r.URL.RawQuery)
+			}
+			// Two pages of six revisions
+			page, next := revisions[:6], `,"continue":{"rvcontinue":"20260101|7","continue":"||"}`
+			if query.Get("rvcontinue") != "" {
+				page, next = revisions[6:], ""
+			}
+			body, _ := json.Marshal(page)
+			_, _ = fmt.Fprintf(w, `{"query":{"pages":{"1":{"revisions":%s}}}%s}`, body, next)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := model.DefaultConfig()
+	cfg.Cache.Enabled = false
+	cfg.History.Enabled = false
+	cfg.Archive.Enabled = false
+	cfg.Extraction.Adapter = "wikipedia"
+	cfg.Wikipedia.APIURL = server.URL + "/w/api.php"
+	result, err := NewPipeline(cfg).ScanURL(context.Background(), server.URL+"/wiki/Bridge")
+	if err != nil {
+		t.Fatalf("ScanURL failed: %v", err)
+	}
+
+	var editWar *model.Signal
+	for i := range result.Report.Score.Signals {
+		if result.Report.Score.Signals[i].Type == model.SignalEditWar {
+			editWar = &result.Report.Score.Signals[i]
+		}
+	}
+	if editWar == nil {
+		t.Fatalf("Expected an edit war signal, got %+v", result.Report.Score.Signals)
+	}
+	data := editWar.Data
+	if data["recent_edits"] != 12 || data["revert_count"] != 5 || data["reverted_edits"] != 5 || data["unique_editors"] != 3 {
+		t.Errorf("Expected 12 edits with 5 hash reverts by 3 editors, got %v", data)
+	}
+	if data["protection"] != "extendedconfirmed" || data["talk_edits"] != 3 || data["talk_editors"] != 2 || data["window_days"] != 30.0 {
+		t.Errorf("Expected protection and talk page indicators over 30 days, got %v", data)
+	}
+	if editWar.Severity != model.SeverityCritical {
+		t.Errorf("Expected a critical edit war, got %s", editWar.Severity)
+	}
+}
+
+func TestScanURL_WikipediaEntityCatalog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/Pretzel" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = fmt.Fprint(w, `<html><body><div class="mw-parser-output"><p>The pretzel was first baked in the Königreich Preußen according to local bakers. It was later popular in the Soviet Union.</p></div></body></html>`)
+	}))
+	defer server.Close()
+
+	catalogFile := filepath.Join(t.TempDir(), "entities.yaml")
+	catalog := "entities:\n  - name: Kingdom of Prussia\n    names:\n      de: [Königreich Preußen]\n    start_year: 1701\n    end_year: 1918\n    successors: [Free State of Prussia]\n"
+	if err := os.WriteFile(catalogFile, []byte(catalog), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := model.DefaultConfig()
+	cfg.Cache.Enabled = false
+	cfg.History.Enabled = false
+	cfg.Archive.Enabled = false
+	cfg.Extraction.Adapter = "wikipedia"
+	cfg.Wikipedia.APIURL = server.URL + "/w/api.php"
+	cfg.Entities.CatalogFile = catalogFile
+	result, err := NewPipeline(cfg).ScanURL(context.Background(), server.URL+"/wiki/Pretzel")
+	if err != nil {
+		t.Fatalf("ScanURL failed: %v", err)
+	}
+
+	var found []model.Signal
+	for _, signal := range result.Report.Score.Signals {
+		if signal.Type == model.SignalHistoricalEntity {
+			found = append(found, signal)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("Expected only the custom catalog's entity, got %+v", found)
+	}
+	data := found[0].Data
+	if data["entity"] != "Kingdom of Prussia" || data["started"] != 1701 || data["catalog"] != catalogFile {
+		t.Errorf("Unexpected signal data %v", data)
+	}
+	if names, _ := data["matched_names"].([]string); len(names) != 1 || names[0] != "Königreich Preußen" {
+		t.Errorf("Expected the German name to match, got %v", data["matched_names"])
+	}
+}
+
+func TestScanURL_Anachronism(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = fmt.Fprint(w, `<html><body>
+			<p>The soup originated in Kyivan Rus in the 16th century according to one cookbook.</p>
+			<p>The soup was first served in Kyivan Rus in the 12th century.</p>
+		</body></html>`)
+	}))
+	defer server.Close()
+
+	result, err := newTestPipeline("generic").ScanURL(context.Background(), server.URL+"/soup")
+	if err != nil {
+		t.Fatalf("ScanURL failed: %v", err)
+	}
+
+	for _, claim := range result.Report.Claims {
+		if len(claim.Dates) != 1 {
+			t.Errorf("Expected one date on %q, got %+v", claim.Text, claim.Dates)
+		}
+	}
+
+	var found []model.Signal
+	for _, signal := range result.Report.Score.Signals {
+		if signal.Type == model.SignalAnachronism {
+			found = append(found, signal)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("Expected one anachronism, got %+v", found)
+	}
+	data := found[0].Data
+	if data["entity"] != "Kyivan Rus" || data["date"] != "16th century" || data["date_from"] != 1501 || data["ended"] != 1240 {
+		t.Errorf("Unexpected signal data %v", data)
+	}
+	if claim, _ := data["claim"].(string); !strings.Contains(claim, "16th century") {
+		t.Errorf("Expected the claim text, got %q", claim)
+	}
+}
+
+func TestScanURL_KeywordPacks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/ru":
+			_, _ = fmt.Fprint(w, `<html lang="ru-RU"><body><div class="mw-parser-output">
+				<p>Борщ — суп на основе свёклы, распространённый в Восточной Европе.</p>
+				<h2>История</h2>
+				<p>Согласно историкам, борщ впервые упоминается в XVI веке. Суп подают горячим со сметаной.</p>
+			</div></body></html>`)
+		default:
+			_, _ = fmt.Fprint(w, `<html><body>
+				<p>La soupe est originaire d'Europe de l'Est selon les historiens.</p>
+				<p>Borscht originated in Eastern Europe according to historians.</p>
+			</body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	result, err := newTestPipeline("wikipedia").ScanURL(context.Background(), server.URL+"/ru")
+	if err != nil {
+		t.Fatalf("ScanURL failed: %v", err)
+	}
+	claims := result.Report.Claims
+	if len(claims) != 1 || !strings.HasPrefix(claims[0].Text, "Согласно историкам") {
+		t.Fatalf("Expected the Russian History-section claim, got %+v", claims)
+	}
+	if claims[0].Language != "ru" || claims[0].Heuristic != "wikipedia:впервые" {
+		t.Errorf("Expected the Russian pack to match, got %+v", claims[0])
+	}
+
+	// A pack file adds French; forcing it overrides detection (the page has no lang)
+	pack := filepath.Join(t.TempDir(), "fr.yaml")
+	if err := os.WriteFile(pack, []byte("language: fr\nclaims: [originaire, selon]\nsentences: {terminators: ['.'], space_after: true, min_length: 20, max_length: 500}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := model.DefaultConfig()
+	cfg.Cache.Enabled = false
+	cfg.History.Enabled = false
+	cfg.Archive.Enabled = false
+	cfg.Extraction.Language = "fr"
+	cfg.Extraction.KeywordPacks = []string{pack}
+
+	result, err = NewPipeline(cfg).ScanURL(context.Background(), server.URL+"/fr")
+	if err != nil {
+		t.Fatalf("ScanURL failed: %v", err)
+	}
+	claims = result.Report.Claims
+	if len(claims) != 1 || claims[0].Language != "fr" || claims[0].Heuristic != "keyword:originaire" {
+		t.Errorf("Expected only the French claim, got %+v", claims)
+	}
+}
+
+func TestCacheKey_ValidationAndArchiveSettings(t *testing.T) {
+	const url = "https://example.com/page"
+	base := (&Pipeline{config: model.DefaultConfig()}).cacheKey(url)
+
+	variants := map[string]func(cfg *model.Config){
+		"content dates": func(cfg *model.Config) { cfg.Validation.ContentDates = true },
+		"soft 404":      func(cfg *model.Config) { cfg.Validation.Soft404 = true },
+		"soft 404 probe": func(cfg *model.Config) {
+			cfg.Validation.Soft404 = true
+			cfg.Validation.Soft404Probe = true
+		},
+		"archive off": func(cfg *model.Config) { cfg.Archive.Enabled = false },
+	}
+
+	seen := map[string]string{base: "defaults"}
+	for name, apply := range variants {
+		cfg := model.DefaultConfig()
+		apply(cfg)
+		key := (&Pipeline{config: cfg}).cacheKey(url)
+		if other, ok := seen[key]; ok {
+			t.Errorf("%s: cache key matches %s", name, other)
+		}
+		seen[key] = name
+	}
+}
@@ -72,10 +72,16 @@ func (r *Renderer) RenderMarkdown(report *model.Report, path string) (err error)
 	printf("# Entropia Report: %s\n\n", report.Subject)
 	printf("**Source:** %s\n\n", report.SourceURL)
 	printf("**Fetched:** %s\n\n", report.FetchedAt.Format("2006-01-02 15:04:05 UTC"))
+	if report.Adapter != "" {
+		printf("**Adapter:** %s\n\n", report.Adapter)
+	}
 
 	// Support Index
 	printf("## Support Index: %d / 100\n\n", report.Score.Index)
 	printf("**Confidence:** %s\n\n", report.Score.Confidence)
+	if report.Score.Rules != "" {
+		printf("**Scoring Rules:** %s\n\n", report.Score.Rules)
+	}
 	if report.Score.Conflict {
 		printf("**⚠️ Conflict Detected:** Mutually exclusive claims present\n\n")
 	}
@@ -88,7 +94,14 @@ func (r *Renderer) RenderMarkdown(report *model.Report, path string) (err error)
 				printf("\n*... and %d more claims*\n", len(report.Claims)-10)
 				break
 			}
-			printf("%d. %s\n", i+1, claim.Text)
+			switch claim.Support {
+			case model.ClaimSupported:
+				printf("%d. %s *(%d source(s))*\n", i+1, claim.Text, len(claim.EvidenceRefs))
+			case model.ClaimUnsupported:
+				printf("%d. %s *(unsupported)*\n", i+1, claim.Text)
+			default:
+				printf("%d. %s\n", i+1, claim.Text)
+			}
 		}
 	} else {
 		printf("*No claims detected*\n")
@@ -182,8 +195,19 @@ func (r *Renderer) RenderMarkdown(report *model.Report, path string) (err error)
 		accessibleCount := 0
 		deadCount := 0
 		staleCount := 0
+		disallowedCount := 0
+		offlineCount := 0
+		contentDatedCount := 0
+		citationDatedCount := 0
+		soft404Count := 0
 
 		for _, v := range report.Validation {
+			if v.Status == model.ValidationDisallowed {
+				disallowedCount++
+			}
+			if v.Status == model.ValidationOffline {
+				offlineCount++
+			}
 			if v.IsAccessible {
 				accessibleCount++
 			}
@@ -193,13 +217,71 @@ func (r *Renderer) RenderMarkdown(report *model.Report, path string) (err error)
 			if v.IsStale {
 				staleCount++
 			}
+			if v.DateSource == model.DateSourceCitedWork {
+				citationDatedCount++
+			} else if v.PublishedAt != nil {
+				contentDatedCount++
+			}
+			if v.Soft404 != "" {
+				soft404Count++
+			}
 		}
 
 		printf("- Total evidence validated: %d\n", len(report.Validation))
 		printf("- Accessible: %d (%.0f%%)\n", accessibleCount, float64(accessibleCount)/float64(len(report.Validation))*100)
 		printf("- Dead links: %d\n", deadCount)
+		if soft404Count > 0 {
+			printf("- Soft 404s (error or parking page served as success): %d\n", soft404Count)
+		}
+		if disallowedCount > 0 {
+			printf("- Not checked (robots.txt disallows): %d\n", disallowedCount)
+		}
+		if offlineCount > 0 {
+			printf("- Not checked (cited without a link): %d\n", offlineCount)
+		}
+		markedDeadCount := 0
+		for _, e := range report.Evidence {
+			if e.MarkedDead {
+				markedDeadCount++
+			}
+		}
+		if markedDeadCount > 0 {
+			printf("- Tagged dead by the page's editors: %d\n", markedDeadCount)
+		}
 		printf("- Stale sources (>1 year): %d\n", staleCount)
+		if contentDatedCount > 0 {
+			printf("- Dated from page metadata: %d\n", contentDatedCount)
+		}
+		if citationDatedCount > 0 {
+			printf("- Dated from citation metadata: %d\n", citationDatedCount)
+		}
 		println()
+
+		// Dead evidence with archive status
+		var dead []model.ValidationResult
+		for _, v := range report.Validation {
+			if v.Dead() {
+				dead = append(dead, v)
+			}
+		}
+		if len(dead) > 0 {
+			printf("### Dead Evidence (%d)\n\n", len(dead))
+			for i, v := range dead {
+				if i >= 20 {
+					printf("\n*... and %d more dead links*\n", len(dead)-20)
+					break
+				}
+				switch {
+				case v.ArchivedURL != "" && v.ArchivedAt != nil:
+					printf("- %s — [archived %s](%s)\n", v.URL, v.ArchivedAt.Format("2006-01-02"), v.ArchivedURL)
+				case v.ArchiveChecked:
+					printf("- %s — no archived copy\n", v.URL)
+				default:
+					printf("- %s\n", v.URL)
+				}
+			}
+			println()
+		}
 	}
 
 	// Principles
@@ -250,7 +332,13 @@ func (r *Renderer) RenderSummary(report *model.Report) {
 	fmt.Printf("\n")
 	fmt.Printf("  Support Index:  %d / 100  (%s confidence)\n", report.Score.Index, report.Score.Confidence)
 	fmt.Printf("  Claims:         %d\n", len(report.Claims))
+	if supported, linked := countSupported(report.Claims); linked {
+		fmt.Printf("  Supported:      %d / %d claims\n", supported, len(report.Claims))
+	}
 	fmt.Printf("  Evidence:       %d\n", len(report.Evidence))
+	if report.Adapter != "" {
+		fmt.Printf("  Adapter:        %s\n", report.Adapter)
+	}
 
 	if report.Score.Conflict {
 		fmt.Printf("  ⚠️  Conflict:    Detected\n")
@@ -272,4 +360,18 @@ func (r *Renderer) RenderSummary(report *model.Report) {
 	fmt.Printf("\n")
 	fmt.Printf("═══════════════════════════════════════════════════════════\n")
 	fmt.Printf("\n")
-}
\ No newline at end of file
+}
+
+// countSupported counts supported claims; linked is false when claim
+// linkage was not computed (e.g., reports from older versions)
+func countSupported(claims []model.Claim) (supported int, linked bool) {
+	for _, claim := range claims {
+		if claim.Support != "" {
+			linked = true
+		}
+		if claim.Support == model.ClaimSupported {
+			supported++
+		}
+	}
+	return supported, linked
+}
@@ -0,0 +1,197 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// SARIF 2.1.0 subset used by Entropia
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Rules for results that are not signals
+const (
+	sarifRuleScanError   = "scan_error"
+	sarifRuleQualityGate = "quality_gate"
+)
+
+// sarifLevel maps signal severity to a SARIF result level
+func sarifLevel(severity model.SignalSeverity) string {
+	switch severity {
+	case model.SeverityCritical:
+		return "error"
+	case model.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// RenderSARIF writes one SARIF result per signal of every report (plus scan
+// errors and gate failures) to the specified path
+func (r *Renderer) RenderSARIF(results []CIResult, toolVersion, path string) error {
+	ruleDescriptions := map[string]string{}
+	sarifResults := []sarifResult{}
+
+	for _, result := range results {
+		location := []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: result.URL}}}}
+
+		if result.Error != nil {
+			ruleDescriptions[sarifRuleScanError] = "Page could not be scanned"
+			sarifResults = append(sarifResults, sarifResult{
+				RuleID:    sarifRuleScanError,
+				Level:     "error",
+				Message:   sarifMessage{Text: result.Error.Error()},
+				Locations: location,
+			})
+			continue
+		}
+		if result.Report == nil {
+			continue
+		}
+
+		for _, signal := range result.Report.Score.Signals {
+			ruleDescriptions[string(signal.Type)] = signalRuleDescription(signal.Type)
+			sarifResults = append(sarifResults, sarifResult{
+				RuleID:    string(signal.Type),
+				Level:     sarifLevel(signal.Severity),
+				Message:   sarifMessage{Text: signal.Description},
+				Locations: location,
+				Properties: map[string]interface{}{
+					"support_index": result.Report.Score.Index,
+					"data":          signal.Data,
+				},
+			})
+		}
+
+		for _, failure := range result.Failures {
+			ruleDescriptions[sarifRuleQualityGate] = "Report failed the CI quality gate"
+			sarifResults = append(sarifResults, sarifResult{
+				RuleID:    sarifRuleQualityGate,
+				Level:     "error",
+				Message:   sarifMessage{Text: failure},
+				Locations: location,
+				Properties: map[string]interface{}{
+					"support_index": result.Report.Score.Index,
+				},
+			})
+		}
+	}
+
+	ruleIDs := make([]string, 0, len(ruleDescriptions))
+	for id := range ruleDescriptions {
+		ruleIDs = append(ruleIDs, id)
+	}
+	sort.Strings(ruleIDs)
+	rules := make([]sarifRule, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		rules = append(rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: ruleDescriptions[id]}})
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "entropia",
+				InformationURI: "https://github.com/ppiankov/entropia",
+				Version:        toolVersion,
+				Rules:          rules,
+			}},
+			Results: sarifResults,
+		}},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode SARIF: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write SARIF: %w", err)
+	}
+	return nil
+}
+
+// signalRuleDescription describes a signal type for SARIF rule metadata
+func signalRuleDescription(signalType model.SignalType) string {
+	switch signalType {
+	case model.SignalEvidenceCoverage:
+		return "Share of claims anchored to evidence"
+	case model.SignalAuthorityDistribution:
+		return "Balance of primary, secondary and tertiary sources"
+	case model.SignalFreshness:
+		return "Age of cited sources"
+	case model.SignalAccessibility:
+		return "Share of evidence links that are reachable"
+	case model.SignalDeadEvidence:
+		return "Dead evidence with and without archived copies"
+	case model.SignalConflict:
+		return "Competing claims"
+	case model.SignalEditWar:
+		return "Wikipedia edit war"
+	case model.SignalHistoricalEntity:
+		return "Reference to a historical entity that did not exist at the time"
+	case model.SignalFreshnessAnomaly:
+		return "Suspiciously recent sources for a historical topic"
+	case model.SignalAnachronism:
+		return "Claim dating a historical entity to years when it did not exist"
+	case model.SignalCleanupTags:
+		return "Statements tagged by Wikipedia editors as unsourced or disputed"
+	case model.SignalNoTLS, model.SignalExpiredCertificate, model.SignalSelfSignedCertificate, model.SignalCertificateMismatch:
+		return "TLS configuration of the scanned page"
+	default:
+		return string(signalType)
+	}
+}
@@ -0,0 +1,407 @@
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"golang.org/x/net/html"
+)
+
+// SourceStdin is the scan argument that reads the page from standard input
+const SourceStdin = "-"
+
+// IsLocalSource reports whether a scan argument names a local page (stdin,
+// a file:// URL or an existing file) rather than an http(s) URL
+func IsLocalSource(source string) bool {
+	if source == SourceStdin || strings.HasPrefix(source, "file://") {
+		return true
+	}
+	if strings.Contains(source, "://") {
+		return false
+	}
+	info, err := os.Stat(source)
+	return err == nil && !info.IsDir()
+}
+
+// IsWARC reports whether a path names a WARC file (.warc or .warc.gz)
+func IsWARC(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".warc") || strings.HasSuffix(lower, ".warc.gz")
+}
+
+// LoadSource loads a local page: "-" reads stdin, anything else is a
+// file:// URL or file path. sourceURL is where the page was originally served
+// (links resolve against it); when empty, the page's canonical URL or the
+// file URL is used. WARC files are read with NewWARCStore.
+func LoadSource(source, sourceURL string, stdin io.Reader, maxBytes int64) (*FetchResult, error) {
+	if sourceURL != "" && !isAbsoluteHTTP(sourceURL) {
+		return nil, fmt.Errorf("invalid source URL %q", sourceURL)
+	}
+	if source == SourceStdin {
+		return LoadReader(stdin, sourceURL, maxBytes)
+	}
+	return LoadFile(FilePath(source), sourceURL, maxBytes)
+}
+
+// FilePath converts a file:// URL (absolute or relative, e.g.
+// file://page.html) to a file path; other values are returned unchanged
+func FilePath(source string) string {
+	if !strings.HasPrefix(source, "file://") {
+		return source
+	}
+	path := strings.TrimPrefix(source, "file://")
+	if strings.HasPrefix(path, "localhost/") {
+		path = strings.TrimPrefix(path, "localhost")
+	}
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	return filepath.FromSlash(path)
+}
+
+// FileURL returns the absolute file:// URL of a path
+func FileURL(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// LoadFile reads a saved page from disk
+func LoadFile(path, sourceURL string, maxBytes int64) (*FetchResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(f, maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	meta := localMeta(body, path)
+	meta.Origin = FileURL(path)
+
+	return localResult(body, meta, sourceURL, meta.Origin), nil
+}
+
+// LoadReader reads a page from r (e.g. stdin)
+func LoadReader(r io.Reader, sourceURL string, maxBytes int64) (*FetchResult, error) {
+	body, err := io.ReadAll(io.LimitReader(r, maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read input: %w", err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, fmt.Errorf("read input: empty document")
+	}
+
+	meta := localMeta(body, "")
+	meta.Origin = "stdin"
+
+	return localResult(body, meta, sourceURL, "file:///dev/stdin"), nil
+}
+
+// localMeta builds fetch metadata for a page that was not fetched over HTTP
+func localMeta(body []byte, path string) model.FetchMeta {
+	contentType := http.DetectContentType(body)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm", ".xhtml":
+		contentType = "text/html; charset=utf-8"
+	case ".md", ".markdown":
+		contentType = "text/markdown; charset=utf-8"
+	case ".rst":
+		contentType = "text/x-rst; charset=utf-8"
+	}
+	return model.FetchMeta{
+		StatusCode:  http.StatusOK,
+		ContentType: contentType,
+		Headers:     map[string]string{},
+	}
+}
+
+// localResult picks the page URL: the configured source URL, else the
+// page's own canonical URL, else the fallback (file URL)
+func localResult(body []byte, meta model.FetchMeta, sourceURL, fallbackURL string) *FetchResult {
+	finalURL := sourceURL
+	if finalURL == "" {
+		finalURL = canonicalURL(body)
+	}
+	if finalURL == "" {
+		finalURL = fallbackURL
+	}
+
+	return &FetchResult{
+		HTML:     string(body),
+		Meta:     meta,
+		Subject:  extractSubject(finalURL),
+		FinalURL: finalURL,
+	}
+}
+
+// canonicalURL returns the absolute http(s) URL from <link rel="canonical">
+// or <meta property="og:url"> in the page head, if any
+func canonicalURL(body []byte) string {
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	var ogURL string
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return ogURL
+		case html.EndTagToken:
+			if name, _ := tokenizer.TagName(); string(name) == "head" {
+				return ogURL
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "body":
+				return ogURL
+			case "link":
+				if strings.EqualFold(tokenAttr(token, "rel"), "canonical") && isAbsoluteHTTP(tokenAttr(token, "href")) {
+					return tokenAttr(token, "href")
+				}
+			case "meta":
+				if tokenAttr(token, "property") == "og:url" && isAbsoluteHTTP(tokenAttr(token, "content")) && ogURL == "" {
+					ogURL = tokenAttr(token, "content")
+				}
+			}
+		}
+	}
+}
+
+// tokenAttr returns the value of the named attribute
+func tokenAttr(token html.Token, name string) string {
+	for _, a := range token.Attr {
+		if a.Key == name {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// isAbsoluteHTTP reports whether s is an absolute http(s) URL
+func isAbsoluteHTTP(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// DocumentStore serves pages from local copies (a WARC file or a directory
+// of saved HTML) instead of the network, keyed by page URL
+type DocumentStore struct {
+	urls  []string
+	pages map[string]func() (*FetchResult, error)
+}
+
+func newDocumentStore() *DocumentStore {
+	return &DocumentStore{pages: make(map[string]func() (*FetchResult, error))}
+}
+
+func (s *DocumentStore) add(pageURL string, load func() (*FetchResult, error)) {
+	if _, exists := s.pages[pageURL]; exists {
+		return // First copy wins
+	}
+	s.urls = append(s.urls, pageURL)
+	s.pages[pageURL] = load
+}
+
+// URLs returns the page URLs in the store, in discovery order
+func (s *DocumentStore) URLs() []string {
+	return append([]string(nil), s.urls...)
+}
+
+// Load returns the stored page for a URL (ok = false when not stored)
+func (s *DocumentStore) Load(pageURL string) (result *FetchResult, ok bool, err error) {
+	load, ok := s.pages[pageURL]
+	if !ok {
+		return nil, false, nil
+	}
+	result, err = load()
+	return result, true, err
+}
+
+// NewDirStore indexes the .html/.htm files under dir. With sourceBase, a
+// file's URL is sourceBase joined with its path relative to dir (index.html
+// maps to the directory URL); otherwise it is the file:// URL and the page
+// URL comes from its canonical link when loaded.
+func NewDirStore(dir, sourceBase string, maxBytes int64) (*DocumentStore, error) {
+	var base *url.URL
+	if sourceBase != "" {
+		parsed, err := url.Parse(sourceBase)
+		if err != nil || !isAbsoluteHTTP(sourceBase) {
+			return nil, fmt.Errorf("invalid source URL %q", sourceBase)
+		}
+		if !strings.HasSuffix(parsed.Path, "/") {
+			parsed.Path += "/"
+		}
+		base = parsed
+	}
+
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".html", ".htm":
+			if !d.IsDir() {
+				paths = append(paths, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read directory: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("read directory %s: no HTML files found", dir)
+	}
+	sort.Strings(paths)
+
+	store := newDocumentStore()
+	for _, path := range paths {
+		path := path
+		if base == nil {
+			store.add(FileURL(path), func() (*FetchResult, error) {
+				return LoadFile(path, "", maxBytes)
+			})
+			continue
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		if name := filepath.Base(rel); strings.EqualFold(name, "index.html") || strings.EqualFold(name, "index.htm") {
+			rel = strings.TrimSuffix(rel, name)
+		}
+		pageURL := base.ResolveReference(&url.URL{Path: rel}).String()
+		store.add(pageURL, func() (*FetchResult, error) {
+			return LoadFile(path, pageURL, maxBytes)
+		})
+	}
+
+	return store, nil
+}
+
+// GlobFiles returns the files matching a glob pattern, sorted. Besides the
+// filepath.Match syntax, "**" matches any number of directories and {a,b}
+// matches either alternative; hidden directories (.git) are skipped.
+func GlobFiles(pattern string) ([]string, error) {
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	re, err := globRegexp(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+	}
+
+	// Walk from the longest directory prefix without wildcards
+	var static []string
+	for _, segment := range strings.Split(pattern, "/") {
+		if strings.ContainsAny(segment, "*?[{") {
+			break
+		}
+		static = append(static, segment)
+	}
+	root := "."
+	if len(static) > 0 {
+		root = strings.Join(static, "/")
+		if root == "" {
+			root = "/"
+		}
+	}
+
+	if _, err := os.Stat(filepath.FromSlash(root)); err != nil {
+		return nil, fmt.Errorf("no files match %s", pattern)
+	}
+
+	var paths []string
+	err = filepath.WalkDir(filepath.FromSlash(root), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if re.MatchString(filepath.ToSlash(path)) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read directory: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files match %s", pattern)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// globRegexp compiles a slash-separated glob pattern
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	braces := 0
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				expr.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				expr.WriteString(".*")
+				i++
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [")
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end
+		case '{':
+			braces++
+			expr.WriteString("(?:")
+		case '}':
+			if braces == 0 {
+				return nil, fmt.Errorf("unmatched }")
+			}
+			braces--
+			expr.WriteString(")")
+		case ',':
+			if braces > 0 {
+				expr.WriteString("|")
+			} else {
+				expr.WriteString(",")
+			}
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if braces > 0 {
+		return nil, fmt.Errorf("unterminated {")
+	}
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
@@ -0,0 +1,272 @@
+package pipeline
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// warcRecord formats one WARC record with the given headers and block
+func warcRecord(warcType, target, contentType, block string) string {
+	return fmt.Sprintf("WARC/1.0\r\nWARC-Type: %s\r\nWARC-Target-URI: %s\r\nContent-Type: %s\r\nContent-Length: %d\r\n\r\n%s\r\n\r\n",
+		warcType, target, contentType, len(block), block)
+}
+
+func TestLoadSource(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "page.html")
+	writeFile(t, path, `<html><head><link rel="canonical" href="https://example.com/wiki/Laksa"></head><body>Text</body></html>`)
+
+	doc, err := LoadSource("file://"+path, "", nil, 1<<20)
+	if err != nil {
+		t.Fatalf("LoadSource failed: %v", err)
+	}
+	if doc.FinalURL != "https://example.com/wiki/Laksa" {
+		t.Errorf("FinalURL = %q, want canonical URL", doc.FinalURL)
+	}
+	if doc.Subject != "Laksa" || doc.Meta.Origin != FileURL(path) || !strings.HasPrefix(doc.Meta.ContentType, "text/html") {
+		t.Errorf("Unexpected result: subject %q, origin %q, content type %q", doc.Subject, doc.Meta.Origin, doc.Meta.ContentType)
+	}
+
+	// --source-url wins over the canonical link
+	doc, err = LoadSource(path, "https://mirror.example.org/laksa", nil, 1<<20)
+	if err != nil {
+		t.Fatalf("LoadSource failed: %v", err)
+	}
+	if doc.FinalURL != "https://mirror.example.org/laksa" {
+		t.Errorf("FinalURL = %q, want source URL", doc.FinalURL)
+	}
+
+	// Stdin without a canonical link falls back to a placeholder file URL
+	doc, err = LoadSource(SourceStdin, "", strings.NewReader("<p>Hello</p>"), 1<<20)
+	if err != nil {
+		t.Fatalf("LoadSource(stdin) failed: %v", err)
+	}
+	if doc.FinalURL != "file:///dev/stdin" || doc.Meta.Origin != "stdin" {
+		t.Errorf("Unexpected stdin result: %q from %q", doc.FinalURL, doc.Meta.Origin)
+	}
+
+	if _, err := LoadSource(SourceStdin, "", strings.NewReader("  \n"), 1<<20); err == nil {
+		t.Error("Expected an error for empty input")
+	}
+	if _, err := LoadSource(path, "example.com/page", nil, 1<<20); err == nil {
+		t.Error("Expected an error for a relative source URL")
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	tests := map[string]string{
+		"file:///tmp/a%20b.html":        "/tmp/a b.html",
+		"file://page.html":              "page.html",
+		"file://localhost/tmp/x.html":   "/tmp/x.html",
+		"saved/page.html":               "saved/page.html",
+		"file://../mirror/index.html":   "../mirror/index.html",
+		"file:///var/www/site/doc.html": "/var/www/site/doc.html",
+	}
+	for in, want := range tests {
+		if got := FilePath(in); got != filepath.FromSlash(want) {
+			t.Errorf("FilePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNewWARCStore(t *testing.T) {
+	page := "<html><body><p>Archived page</p></body></html>"
+	warc := warcRecord("warcinfo", "", "application/warc-fields", "software: test\r\n") +
+		warcRecord("request", "https://example.com/a", "application/http; msgtype=request", "GET /a HTTP/1.1\r\nHost: example.com\r\n\r\n") +
+		warcRecord("response", "<https://example.com/a>", "application/http; msgtype=response",
+			fmt.Sprintf("HTTP/1.1 200 OK\r\nContent-Type: text/html\r\nLast-Modified: Mon, 02 Jan 2023 15:04:05 GMT\r\nContent-Length: %d\r\n\r\n%s", len(page), page)) +
+		warcRecord("response", "https://example.com/missing", "application/http; msgtype=response", "HTTP/1.1 404 Not Found\r\nContent-Type: text/html\r\n\r\nGone") +
+		warcRecord("response", "https://example.com/logo.png", "application/http; msgtype=response", "HTTP/1.1 200 OK\r\nContent-Type: image/png\r\n\r\n\x89PNG") +
+		warcRecord("resource", "https://example.com/b", "text/html", "<p>Resource page</p>")
+
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "crawl.warc")
+	writeFile(t, plain, warc)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(warc))
+	_ = gz.Close()
+	compressed := filepath.Join(dir, "crawl.warc.gz")
+	writeFile(t, compressed, buf.String())
+
+	for _, path := range []string{plain, compressed} {
+		store, err := NewWARCStore(path, 1<<20)
+		if err != nil {
+			t.Fatalf("NewWARCStore(%s) failed: %v", path, err)
+		}
+		want := []string{"https://example.com/a", "https://example.com/b"}
+		if !reflect.DeepEqual(store.URLs(), want) {
+			t.Fatalf("URLs() = %v, want %v", store.URLs(), want)
+		}
+
+		doc, ok, err := store.Load("https://example.com/a")
+		if !ok || err != nil {
+			t.Fatalf("Load() = %v, %v", ok, err)
+		}
+		if doc.HTML != page || doc.Meta.LastModified == "" || doc.Meta.Origin != FileURL(path) {
+			t.Errorf("Unexpected page: %q, last modified %q, origin %q", doc.HTML, doc.Meta.LastModified, doc.Meta.Origin)
+		}
+		if _, ok, _ := store.Load("https://example.com/missing"); ok {
+			t.Error("Expected non-2xx responses to be skipped")
+		}
+	}
+
+	empty := filepath.Join(dir, "empty.warc")
+	writeFile(t, empty, warcRecord("warcinfo", "", "application/warc-fields", "software: test\r\n"))
+	if _, err := NewWARCStore(empty, 1<<20); err == nil {
+		t.Error("Expected an error for a WARC without HTML pages")
+	}
+}
+
+func TestNewDirStore(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "index.html"), "<p>Home</p>")
+	writeFile(t, filepath.Join(dir, "guides", "setup.html"), "<p>Setup</p>")
+	writeFile(t, filepath.Join(dir, "guides", "index.htm"), "<p>Guides</p>")
+	writeFile(t, filepath.Join(dir, "style.css"), "body {}")
+
+	store, err := NewDirStore(dir, "https://docs.example.com/v2", 1<<20)
+	if err != nil {
+		t.Fatalf("NewDirStore failed: %v", err)
+	}
+	want := []string{
+		"https://docs.example.com/v2/guides/",
+		"https://docs.example.com/v2/guides/setup.html",
+		"https://docs.example.com/v2/",
+	}
+	if !reflect.DeepEqual(store.URLs(), want) {
+		t.Fatalf("URLs() = %v, want %v", store.URLs(), want)
+	}
+	doc, ok, err := store.Load(want[1])
+	if !ok || err != nil || doc.FinalURL != want[1] || doc.HTML != "<p>Setup</p>" {
+		t.Errorf("Load() = %+v, %v, %v", doc, ok, err)
+	}
+
+	// Without a base URL pages keep their file:// URLs
+	store, err = NewDirStore(dir, "", 1<<20)
+	if err != nil {
+		t.Fatalf("NewDirStore failed: %v", err)
+	}
+	if urls := store.URLs(); len(urls) != 3 || !strings.HasPrefix(urls[0], "file://") {
+		t.Errorf("URLs() = %v, want 3 file URLs", urls)
+	}
+
+	if _, err := NewDirStore(t.TempDir(), "", 1<<20); err == nil {
+		t.Error("Expected an error for a directory without HTML files")
+	}
+}
+
+func TestScanDocument_ResolvesLinksAgainstSourceURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "article.html")
+	writeFile(t, path, `<html><body><main>
+		<p>Laksa originated in the Peranakan communities of the Malay peninsula.</p>
+		<a href="/source-1">Source one</a>
+		<a href="source-2">Source two</a>
+	</main></body></html>`)
+
+	doc, err := LoadSource(path, server.URL+"/article", nil, 1<<20)
+	if err != nil {
+		t.Fatalf("LoadSource failed: %v", err)
+	}
+	result, err := newTestPipeline("generic").ScanDocument(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("ScanDocument failed: %v", err)
+	}
+
+	report := result.Report
+	if report.SourceURL != server.URL+"/article" || report.FetchMeta.Origin != FileURL(path) {
+		t.Errorf("Unexpected report source %q from %q", report.SourceURL, report.FetchMeta.Origin)
+	}
+
+	found := false
+	for _, ev := range report.Evidence {
+		if ev.URL == server.URL+"/source-1" {
+			found = true
+			if !ev.IsSameHost {
+				t.Error("Expected relative link to count as same-host")
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected relative link resolved against the source URL, got %+v", report.Evidence)
+	}
+}
+
+func TestScanURL_DocumentStore(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "index.html"), `<html><body><main><p>Laksa originated in the Peranakan communities of the Malay peninsula.</p></main></body></html>`)
+
+	store, err := NewDirStore(dir, "https://offline.invalid/", 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newTestPipeline("generic")
+	p.SetDocumentStore(store)
+
+	// offline.invalid cannot resolve, so this only passes if the page is read locally
+	result, err := p.ScanURL(context.Background(), "https://offline.invalid/")
+	if err != nil {
+		t.Fatalf("ScanURL failed: %v", err)
+	}
+	if result.Report.SourceURL != "https://offline.invalid/" || len(result.Report.Claims) == 0 {
+		t.Errorf("Unexpected report: %q with %d claims", result.Report.SourceURL, len(result.Report.Claims))
+	}
+}
+
+func TestGlobFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"docs/index.md", "docs/guide/install.md", "docs/guide/api.rst", "docs/notes.txt", "docs/.cache/skip.md"} {
+		writeFile(t, filepath.Join(dir, name), "# Page\n")
+	}
+
+	tests := map[string][]string{
+		"docs/**/*.md":       {"docs/guide/install.md", "docs/index.md"},
+		"docs/*.md":          {"docs/index.md"},
+		"docs/**/*.{md,rst}": {"docs/guide/api.rst", "docs/guide/install.md", "docs/index.md"},
+	}
+	for pattern, want := range tests {
+		got, err := GlobFiles(filepath.Join(dir, pattern))
+		if err != nil {
+			t.Errorf("GlobFiles(%q) failed: %v", pattern, err)
+			continue
+		}
+		if len(got) != len(want) {
+			t.Errorf("GlobFiles(%q) = %v, want %v", pattern, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != filepath.Join(dir, want[i]) {
+				t.Errorf("GlobFiles(%q)[%d] = %s, want %s", pattern, i, got[i], want[i])
+			}
+		}
+	}
+
+	if _, err := GlobFiles(filepath.Join(dir, "docs/**/*.adoc")); err == nil {
+		t.Error("Expected an error when nothing matches")
+	}
+}
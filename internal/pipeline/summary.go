@@ -0,0 +1,444 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// Limits for the ranked lists of a batch summary
+const (
+	summaryWorstPages = 10
+	summaryTopHosts   = 20
+)
+
+// BatchSummary aggregates the reports of one batch run
+type BatchSummary struct {
+	GeneratedAt     time.Time      `json:"generated_at"`
+	Total           int            `json:"total"`
+	Succeeded       int            `json:"succeeded"`
+	Failed          int            `json:"failed"`
+	Remaining       int            `json:"remaining,omitempty"` // URLs not scanned yet (interrupted batch)
+	Index           IndexStats     `json:"index"`
+	Confidence      map[string]int `json:"confidence"`        // Pages per confidence level
+	WorstPages      []PageScore    `json:"worst_pages"`       // Lowest support index first
+	CitedHosts      []HostCount    `json:"cited_hosts"`       // Most cited external hosts
+	SharedDeadHosts []DeadHost     `json:"shared_dead_hosts"` // Hosts with dead links on more than one page
+	Signals         []SignalCount  `json:"signals"`           // Signal frequencies by type
+	Failures        []FailedPage   `json:"failures,omitempty"`
+	Pages           []PageScore    `json:"pages"` // Every scanned page, in input order
+}
+
+// IndexStats describes the distribution of support indexes
+type IndexStats struct {
+	Mean    float64       `json:"mean"`
+	Median  float64       `json:"median"`
+	Min     int           `json:"min"`
+	Max     int           `json:"max"`
+	Buckets []IndexBucket `json:"buckets"`
+}
+
+// IndexBucket counts pages whose index falls in [Low, High]
+type IndexBucket struct {
+	Low   int `json:"low"`
+	High  int `json:"high"`
+	Pages int `json:"pages"`
+}
+
+// PageScore is the headline result of one scanned page
+type PageScore struct {
+	URL        string `json:"url"`
+	Subject    string `json:"subject"`
+	Index      int    `json:"index"`
+	Confidence string `json:"confidence"`
+	Evidence   int    `json:"evidence"`
+	Dead       int    `json:"dead"`
+	Critical   int    `json:"critical"`
+	Warnings   int    `json:"warnings"`
+}
+
+// HostCount counts citations of one host across pages
+type HostCount struct {
+	Host      string `json:"host"`
+	Citations int    `json:"citations"`
+	Pages     int    `json:"pages"`
+}
+
+// DeadHost lists the pages citing dead links on one host
+type DeadHost struct {
+	Host      string   `json:"host"`
+	DeadLinks int      `json:"dead_links"`
+	Pages     []string `json:"pages"`
+}
+
+// SignalCount counts pages carrying a signal type, by severity
+type SignalCount struct {
+	Type     model.SignalType `json:"type"`
+	Pages    int              `json:"pages"`
+	Info     int              `json:"info"`
+	Warning  int              `json:"warning"`
+	Critical int              `json:"critical"`
+}
+
+// FailedPage is a URL that could not be scanned
+type FailedPage struct {
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+// SummarizeBatch aggregates per-URL batch results (the same results used for
+// CI reports; gate failures are ignored here)
+func SummarizeBatch(results []CIResult) *BatchSummary {
+	summary := &BatchSummary{
+		GeneratedAt: time.Now().UTC(),
+		Total:       len(results),
+		Confidence:  map[string]int{},
+	}
+
+	hostCitations := map[string]int{}
+	hostPages := map[string]int{}
+	deadLinks := map[string]int{}
+	deadPages := map[string][]string{}
+	signals := map[model.SignalType]*SignalCount{}
+	var indexes []int
+
+	for _, result := range results {
+		if result.Error != nil || result.Report == nil {
+			summary.Failed++
+			message := "no report"
+			if result.Error != nil {
+				message = result.Error.Error()
+			}
+			summary.Failures = append(summary.Failures, FailedPage{URL: result.URL, Error: message})
+			continue
+		}
+
+		report := result.Report
+		summary.Succeeded++
+		indexes = append(indexes, report.Score.Index)
+		summary.Confidence[report.Score.Confidence]++
+
+		page := PageScore{
+			URL:        result.URL,
+			Subject:    report.Subject,
+			Index:      report.Score.Index,
+			Confidence: report.Score.Confidence,
+			Evidence:   len(report.Evidence),
+		}
+
+		// Cited hosts: every citation counts, each page once per host
+		seen := map[string]bool{}
+		for _, ev := range report.Evidence {
+			if ev.IsSameHost || ev.Host == "" {
+				continue
+			}
+			host := strings.ToLower(ev.Host)
+			hostCitations[host]++
+			if !seen[host] {
+				seen[host] = true
+				hostPages[host]++
+			}
+		}
+
+		// Dead hosts, remembered per page
+		seenDead := map[string]bool{}
+		for _, v := range report.Validation {
+			if !v.Dead() {
+				continue
+			}
+			page.Dead++
+			host := summaryHost(v.URL)
+			if host == "" {
+				continue
+			}
+			deadLinks[host]++
+			if !seenDead[host] {
+				seenDead[host] = true
+				deadPages[host] = append(deadPages[host], result.URL)
+			}
+		}
+
+		// Signals: one count per page and type, at the highest severity seen
+		pageSignals := map[model.SignalType]model.SignalSeverity{}
+		for _, signal := range report.Score.Signals {
+			switch signal.Severity {
+			case model.SeverityCritical:
+				page.Critical++
+			case model.SeverityWarning:
+				page.Warnings++
+			}
+			if current, ok := pageSignals[signal.Type]; !ok || severityRank(signal.Severity) > severityRank(current) {
+				pageSignals[signal.Type] = signal.Severity
+			}
+		}
+		for signalType, severity := range pageSignals {
+			count := signals[signalType]
+			if count == nil {
+				count = &SignalCount{Type: signalType}
+				signals[signalType] = count
+			}
+			count.Pages++
+			switch severity {
+			case model.SeverityCritical:
+				count.Critical++
+			case model.SeverityWarning:
+				count.Warning++
+			default:
+				count.Info++
+			}
+		}
+
+		summary.Pages = append(summary.Pages, page)
+	}
+
+	summary.Index = indexStats(indexes)
+
+	// Worst pages: lowest index, then most critical signals
+	worst := append([]PageScore(nil), summary.Pages...)
+	sort.SliceStable(worst, func(i, j int) bool {
+		if worst[i].Index != worst[j].Index {
+			return worst[i].Index < worst[j].Index
+		}
+		return worst[i].Critical > worst[j].Critical
+	})
+	if len(worst) > summaryWorstPages {
+		worst = worst[:summaryWorstPages]
+	}
+	summary.WorstPages = worst
+
+	for host, citations := range hostCitations {
+		summary.CitedHosts = append(summary.CitedHosts, HostCount{Host: host, Citations: citations, Pages: hostPages[host]})
+	}
+	sort.Slice(summary.CitedHosts, func(i, j int) bool {
+		a, b := summary.CitedHosts[i], summary.CitedHosts[j]
+		if a.Citations != b.Citations {
+			return a.Citations > b.Citations
+		}
+		return a.Host < b.Host
+	})
+	if len(summary.CitedHosts) > summaryTopHosts {
+		summary.CitedHosts = summary.CitedHosts[:summaryTopHosts]
+	}
+
+	for host, pages := range deadPages {
+		if len(pages) < 2 {
+			continue
+		}
+		summary.SharedDeadHosts = append(summary.SharedDeadHosts, DeadHost{Host: host, DeadLinks: deadLinks[host], Pages: pages})
+	}
+	sort.Slice(summary.SharedDeadHosts, func(i, j int) bool {
+		a, b := summary.SharedDeadHosts[i], summary.SharedDeadHosts[j]
+		if len(a.Pages) != len(b.Pages) {
+			return len(a.Pages) > len(b.Pages)
+		}
+		return a.Host < b.Host
+	})
+
+	for _, count := range signals {
+		summary.Signals = append(summary.Signals, *count)
+	}
+	sort.Slice(summary.Signals, func(i, j int) bool {
+		a, b := summary.Signals[i], summary.Signals[j]
+		if a.Critical+a.Warning != b.Critical+b.Warning {
+			return a.Critical+a.Warning > b.Critical+b.Warning
+		}
+		return a.Type < b.Type
+	})
+
+	return summary
+}
+
+// indexStats computes mean, median, range and 20-point buckets
+func indexStats(indexes []int) IndexStats {
+	stats := IndexStats{}
+	for low := 0; low < 100; low += 20 {
+		high := low + 19
+		if high == 99 {
+			high = 100
+		}
+		stats.Buckets = append(stats.Buckets, IndexBucket{Low: low, High: high})
+	}
+	if len(indexes) == 0 {
+		return stats
+	}
+
+	sorted := append([]int(nil), indexes...)
+	sort.Ints(sorted)
+	stats.Min = sorted[0]
+	stats.Max = sorted[len(sorted)-1]
+
+	sum := 0
+	for _, index := range sorted {
+		sum += index
+		for i := range stats.Buckets {
+			if index >= stats.Buckets[i].Low && index <= stats.Buckets[i].High {
+				stats.Buckets[i].Pages++
+				break
+			}
+		}
+	}
+	stats.Mean = float64(sum) / float64(len(sorted))
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		stats.Median = float64(sorted[mid-1]+sorted[mid]) / 2
+	} else {
+		stats.Median = float64(sorted[mid])
+	}
+	return stats
+}
+
+// severityRank orders severities from info to critical
+func severityRank(severity model.SignalSeverity) int {
+	switch severity {
+	case model.SeverityCritical:
+		return 2
+	case model.SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// summaryHost returns the lower-cased host name of a URL
+func summaryHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// RenderBatchSummaryJSON writes the batch summary as JSON to the specified path
+func (r *Renderer) RenderBatchSummaryJSON(summary *BatchSummary, path string) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode JSON: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write summary: %w", err)
+	}
+	return nil
+}
+
+// RenderBatchSummaryMarkdown writes the batch summary as Markdown to the specified path
+func (r *Renderer) RenderBatchSummaryMarkdown(summary *BatchSummary, path string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close file: %w", closeErr)
+		}
+	}()
+
+	printf := func(format string, a ...interface{}) {
+		if err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(f, format, a...)
+	}
+
+	printf("# Entropia Batch Summary\n\n")
+	printf("**Generated:** %s\n\n", summary.GeneratedAt.Format("2006-01-02 15:04:05 UTC"))
+	printf("**Pages:** %d scanned, %d failed (%d total)\n\n", summary.Succeeded, summary.Failed, summary.Total)
+	if summary.Remaining > 0 {
+		printf("**⚠️ Partial:** %d URL(s) not scanned yet (rerun with `--resume`)\n\n", summary.Remaining)
+	}
+
+	// Index distribution
+	printf("## Support Index Distribution\n\n")
+	if summary.Succeeded > 0 {
+		printf("**Mean:** %.1f · **Median:** %.1f · **Range:** %d–%d\n\n", summary.Index.Mean, summary.Index.Median, summary.Index.Min, summary.Index.Max)
+	}
+	printf("| Index | Pages | |\n|---|---:|---|\n")
+	for i := len(summary.Index.Buckets) - 1; i >= 0; i-- {
+		bucket := summary.Index.Buckets[i]
+		printf("| %d–%d | %d | %s |\n", bucket.Low, bucket.High, bucket.Pages, strings.Repeat("█", bucket.Pages))
+	}
+	printf("\n")
+	for _, level := range []string{"high", "medium", "low-medium", "low"} {
+		if n := summary.Confidence[level]; n > 0 {
+			printf("- %s confidence: %d\n", level, n)
+		}
+	}
+	printf("\n")
+
+	// Worst pages
+	printf("## Lowest Scoring Pages (%d)\n\n", len(summary.WorstPages))
+	if len(summary.WorstPages) > 0 {
+		printf("| Index | Page | Evidence | Dead | Critical | Warnings |\n|---:|---|---:|---:|---:|---:|\n")
+		for _, page := range summary.WorstPages {
+			printf("| %d | [%s](%s) | %d | %d | %d | %d |\n", page.Index, markdownCell(page.Subject), page.URL, page.Evidence, page.Dead, page.Critical, page.Warnings)
+		}
+	} else {
+		printf("*No pages scanned*\n")
+	}
+	printf("\n")
+
+	// Cited hosts
+	printf("## Most Cited Hosts\n\n")
+	if len(summary.CitedHosts) > 0 {
+		printf("| Host | Citations | Pages |\n|---|---:|---:|\n")
+		for _, host := range summary.CitedHosts {
+			printf("| %s | %d | %d |\n", host.Host, host.Citations, host.Pages)
+		}
+	} else {
+		printf("*No external evidence*\n")
+	}
+	printf("\n")
+
+	// Shared dead hosts
+	printf("## Dead Hosts Shared Across Pages (%d)\n\n", len(summary.SharedDeadHosts))
+	if len(summary.SharedDeadHosts) > 0 {
+		for _, host := range summary.SharedDeadHosts {
+			printf("- **%s** — %d dead link(s) on %d pages\n", host.Host, host.DeadLinks, len(host.Pages))
+			for _, page := range host.Pages {
+				printf("  - %s\n", page)
+			}
+		}
+	} else {
+		printf("*No dead host is cited by more than one page*\n")
+	}
+	printf("\n")
+
+	// Signal frequencies
+	printf("## Signal Frequencies\n\n")
+	if len(summary.Signals) > 0 {
+		printf("| Signal | Pages | Critical | Warning | Info |\n|---|---:|---:|---:|---:|\n")
+		for _, signal := range summary.Signals {
+			printf("| %s | %d | %d | %d | %d |\n", signal.Type, signal.Pages, signal.Critical, signal.Warning, signal.Info)
+		}
+	} else {
+		printf("*No signals*\n")
+	}
+	printf("\n")
+
+	// Failures
+	if len(summary.Failures) > 0 {
+		printf("## Failed Scans (%d)\n\n", len(summary.Failures))
+		for _, failure := range summary.Failures {
+			printf("- %s: %s\n", failure.URL, failure.Error)
+		}
+		printf("\n")
+	}
+
+	if r.IncludeFooter {
+		printf("---\n\n")
+		printf("*Generated by Entropia — a mirror, not an oracle*\n")
+	}
+
+	return err
+}
+
+// markdownCell escapes pipes so text fits in a Markdown table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -0,0 +1,130 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+func summaryReport(url string, index int, deadURLs []string, signals ...model.Signal) *model.Report {
+	report := &model.Report{
+		Subject:   url,
+		SourceURL: url,
+		Evidence: []model.Evidence{
+			{URL: "https://cited.example.org/a", Host: "cited.example.org"},
+			{URL: "https://cited.example.org/b", Host: "cited.example.org"},
+			{URL: "https://docs.example.com/other", Host: "docs.example.com", IsSameHost: true},
+		},
+		Score: model.Score{Index: index, Confidence: "medium", Signals: signals},
+	}
+	for _, u := range deadURLs {
+		report.Evidence = append(report.Evidence, model.Evidence{URL: u, Host: summaryHost(u)})
+		report.Validation = append(report.Validation, model.ValidationResult{URL: u, IsDead: true})
+	}
+	return report
+}
+
+func TestSummarizeBatch(t *testing.T) {
+	warning := model.Signal{Type: model.SignalAccessibility, Severity: model.SeverityWarning}
+	critical := model.Signal{Type: model.SignalAccessibility, Severity: model.SeverityCritical}
+	info := model.Signal{Type: model.SignalFreshness, Severity: model.SeverityInfo}
+
+	results := []CIResult{
+		{URL: "https://docs.example.com/a", Report: summaryReport("https://docs.example.com/a", 30, []string{"https://gone.example.net/1", "https://gone.example.net/2"}, warning, critical, info)},
+		{URL: "https://docs.example.com/b", Report: summaryReport("https://docs.example.com/b", 90, []string{"https://gone.example.net/3", "https://once.example.net/x"}, info)},
+		{URL: "https://docs.example.com/c", Report: summaryReport("https://docs.example.com/c", 60, nil)},
+		{URL: "https://docs.example.com/broken", Error: errors.New("unexpected status: 500")},
+	}
+
+	summary := SummarizeBatch(results)
+
+	if summary.Total != 4 || summary.Succeeded != 3 || summary.Failed != 1 {
+		t.Errorf("Expected 4 total, 3 succeeded, 1 failed, got %d/%d/%d", summary.Total, summary.Succeeded, summary.Failed)
+	}
+	if summary.Index.Min != 30 || summary.Index.Max != 90 || summary.Index.Median != 60 || summary.Index.Mean != 60 {
+		t.Errorf("Unexpected index stats: %+v", summary.Index)
+	}
+	bucketed := 0
+	for _, bucket := range summary.Index.Buckets {
+		bucketed += bucket.Pages
+	}
+	if bucketed != 3 {
+		t.Errorf("Expected 3 pages across buckets, got %d", bucketed)
+	}
+
+	if len(summary.WorstPages) != 3 || summary.WorstPages[0].URL != "https://docs.example.com/a" || summary.WorstPages[0].Dead != 2 {
+		t.Errorf("Expected page a first among worst pages, got %+v", summary.WorstPages)
+	}
+
+	if len(summary.CitedHosts) == 0 || summary.CitedHosts[0].Host != "cited.example.org" || summary.CitedHosts[0].Citations != 6 || summary.CitedHosts[0].Pages != 3 {
+		t.Errorf("Expected cited.example.org first with 6 citations on 3 pages, got %+v", summary.CitedHosts)
+	}
+	for _, host := range summary.CitedHosts {
+		if host.Host == "docs.example.com" {
+			t.Error("Same-host evidence should not count as a cited host")
+		}
+	}
+
+	if len(summary.SharedDeadHosts) != 1 || summary.SharedDeadHosts[0].Host != "gone.example.net" || summary.SharedDeadHosts[0].DeadLinks != 3 || len(summary.SharedDeadHosts[0].Pages) != 2 {
+		t.Errorf("Expected only gone.example.net shared by 2 pages, got %+v", summary.SharedDeadHosts)
+	}
+
+	signals := map[model.SignalType]SignalCount{}
+	for _, signal := range summary.Signals {
+		signals[signal.Type] = signal
+	}
+	if got := signals[model.SignalAccessibility]; got.Pages != 1 || got.Critical != 1 || got.Warning != 0 {
+		t.Errorf("Expected accessibility counted once at critical, got %+v", got)
+	}
+	if got := signals[model.SignalFreshness]; got.Pages != 2 || got.Info != 2 {
+		t.Errorf("Expected freshness info on 2 pages, got %+v", got)
+	}
+	if summary.Signals[0].Type != model.SignalAccessibility {
+		t.Errorf("Expected signals with warnings or criticals first, got %s", summary.Signals[0].Type)
+	}
+}
+
+func TestRenderBatchSummary(t *testing.T) {
+	summary := SummarizeBatch([]CIResult{
+		{URL: "https://docs.example.com/a", Report: summaryReport("https://docs.example.com/a", 30, []string{"https://gone.example.net/1"})},
+		{URL: "https://docs.example.com/b", Report: summaryReport("https://docs.example.com/b", 70, []string{"https://gone.example.net/2"})},
+		{URL: "https://docs.example.com/broken", Error: errors.New("timeout")},
+	})
+	dir := t.TempDir()
+	renderer := NewRenderer(false)
+
+	jsonPath := filepath.Join(dir, "batch-summary.json")
+	if err := renderer.RenderBatchSummaryJSON(summary, jsonPath); err != nil {
+		t.Fatalf("RenderBatchSummaryJSON failed: %v", err)
+	}
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded BatchSummary
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Summary is not valid JSON: %v", err)
+	}
+	if decoded.Succeeded != 2 || len(decoded.SharedDeadHosts) != 1 {
+		t.Errorf("Unexpected decoded summary: %+v", decoded)
+	}
+
+	mdPath := filepath.Join(dir, "batch-summary.md")
+	if err := renderer.RenderBatchSummaryMarkdown(summary, mdPath); err != nil {
+		t.Fatalf("RenderBatchSummaryMarkdown failed: %v", err)
+	}
+	md, err := os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Entropia Batch Summary", "Lowest Scoring Pages", "cited.example.org", "**gone.example.net**", "## Failed Scans (1)"} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("Expected Markdown to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package pipeline
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// NewWARCStore loads the HTML pages captured in a WARC file (.warc or
+// .warc.gz): successful "response" records and HTML "resource" records,
+// keyed by WARC-Target-URI. Each body is capped at maxBytes.
+func NewWARCStore(path string, maxBytes int64) (*DocumentStore, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open WARC: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	reader := bufio.NewReader(f)
+	var input io.Reader = reader
+	if magic, err := reader.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(reader) // Multi-member .warc.gz reads as one stream
+		if err != nil {
+			return nil, fmt.Errorf("decompress WARC: %w", err)
+		}
+		defer func() { _ = gz.Close() }()
+		input = gz
+	}
+
+	store := newDocumentStore()
+	origin := FileURL(path)
+	err = readWARC(input, maxBytes, func(page *FetchResult) {
+		page.Meta.Origin = origin
+		store.add(page.FinalURL, func() (*FetchResult, error) {
+			return page, nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read WARC %s: %w", path, err)
+	}
+	if len(store.urls) == 0 {
+		return nil, fmt.Errorf("read WARC %s: no HTML pages found", path)
+	}
+
+	return store, nil
+}
+
+// readWARC walks WARC records, calling onPage for each captured HTML page
+func readWARC(r io.Reader, maxBytes int64, onPage func(*FetchResult)) error {
+	br := bufio.NewReader(r)
+	for {
+		// Records are separated by blank lines
+		line, err := br.ReadString('\n')
+		if errors.Is(err, io.EOF) && strings.TrimSpace(line) == "" {
+			return nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "WARC/") {
+			return fmt.Errorf("expected WARC record, got %q", truncate(line, 40))
+		}
+
+		header, err := textproto.NewReader(br).ReadMIMEHeader()
+		if err != nil {
+			return fmt.Errorf("record header: %w", err)
+		}
+		length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+		if err != nil || length < 0 {
+			return fmt.Errorf("record %s: invalid Content-Length", header.Get("WARC-Record-ID"))
+		}
+
+		block := io.LimitReader(br, length)
+		if page := warcPage(header, block, maxBytes); page != nil {
+			onPage(page)
+		}
+		if _, err := io.Copy(io.Discard, block); err != nil {
+			return fmt.Errorf("record %s: %w", header.Get("WARC-Record-ID"), err)
+		}
+	}
+}
+
+// warcPage decodes an HTML page from a record block, or returns nil
+func warcPage(header textproto.MIMEHeader, block io.Reader, maxBytes int64) *FetchResult {
+	target := strings.Trim(header.Get("WARC-Target-URI"), "<>")
+	if !isAbsoluteHTTP(target) {
+		return nil
+	}
+
+	meta := model.FetchMeta{Headers: map[string]string{}}
+	var body []byte
+
+	switch header.Get("WARC-Type") {
+	case "response":
+		if !strings.HasPrefix(header.Get("Content-Type"), "application/http") {
+			return nil
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(block), nil)
+		if err != nil {
+			return nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return nil
+		}
+
+		var bodyReader io.Reader = resp.Body
+		if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+			gz, err := gzip.NewReader(resp.Body)
+			if err != nil {
+				return nil
+			}
+			defer func() { _ = gz.Close() }()
+			bodyReader = gz
+		}
+		if body, err = io.ReadAll(io.LimitReader(bodyReader, maxBytes)); err != nil {
+			return nil
+		}
+
+		meta.StatusCode = resp.StatusCode
+		meta.ContentType = resp.Header.Get("Content-Type")
+		meta.LastModified = resp.Header.Get("Last-Modified")
+		meta.ETag = resp.Header.Get("ETag")
+		for _, key := range []string{"Content-Length", "Server", "Cache-Control"} {
+			if val := resp.Header.Get(key); val != "" {
+				meta.Headers[key] = val
+			}
+		}
+	case "resource":
+		var err error
+		if body, err = io.ReadAll(io.LimitReader(block, maxBytes)); err != nil {
+			return nil
+		}
+		meta.StatusCode = http.StatusOK
+		meta.ContentType = header.Get("Content-Type")
+	default:
+		return nil // request, metadata, revisit, warcinfo...
+	}
+
+	if meta.ContentType == "" {
+		meta.ContentType = http.DetectContentType(body)
+	}
+	if !strings.Contains(strings.ToLower(meta.ContentType), "html") || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	return &FetchResult{
+		HTML:     string(body),
+		Meta:     meta,
+		Subject:  extractSubject(target),
+		FinalURL: target,
+	}
+}
+
+// truncate shortens s to at most n bytes for error messages
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
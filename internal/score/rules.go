@@ -0,0 +1,171 @@
+package score
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// Rules is the declarative scoring configuration loaded from a rules file.
+// Every number the scorer uses comes from here so reports can show the
+// formulas that were actually applied.
+type Rules struct {
+	Name           string                   `json:"name"`            // Shown in reports (e.g., "builtin", "legal-team-v2")
+	Weights        Weights                  `json:"weights"`         // Points per component (must sum to 100)
+	AuthorityTiers AuthorityTierWeights     `json:"authority_tiers"` // Relative weight of each authority tier
+	Thresholds     Thresholds               `json:"thresholds"`      // Severity and staleness cut-offs
+	Penalties      map[model.SignalType]int `json:"penalties"`       // Points deducted when a signal type is present
+}
+
+// Weights sets the maximum points for each score component
+type Weights struct {
+	Coverage      int `json:"coverage"`
+	Authority     int `json:"authority"`
+	Freshness     int `json:"freshness"`
+	Accessibility int `json:"accessibility"`
+}
+
+// AuthorityTierWeights sets the relative value of each authority tier
+type AuthorityTierWeights struct {
+	Primary   int `json:"primary"`
+	Secondary int `json:"secondary"`
+	Tertiary  int `json:"tertiary"`
+}
+
+// Thresholds sets the cut-offs used for severities and staleness
+type Thresholds struct {
+	CoverageCriticalRatio      float64 `json:"coverage_critical_ratio"`      // Evidence/claim ratio below this is critical
+	CoverageWarningRatio       float64 `json:"coverage_warning_ratio"`       // Evidence/claim ratio below this is a warning
+	SupportedCriticalRatio     float64 `json:"supported_critical_ratio"`     // Supported-claim ratio below this is critical
+	SupportedWarningRatio      float64 `json:"supported_warning_ratio"`      // Supported-claim ratio below this is a warning
+	AccessibilityCriticalRatio float64 `json:"accessibility_critical_ratio"` // Accessible ratio below this is critical
+	AccessibilityWarningRatio  float64 `json:"accessibility_warning_ratio"`  // Accessible ratio below this is a warning
+	StaleDays                  int     `json:"stale_days"`                   // Median age above this is a warning
+	VeryStaleDays              int     `json:"very_stale_days"`              // Median age above this is critical
+	FreshnessHorizonYears      float64 `json:"freshness_horizon_years"`      // Median age at which freshness reaches 0
+	ConfidenceHigh             int     `json:"confidence_high"`              // Index at or above this is "high" confidence
+	ConfidenceMedium           int     `json:"confidence_medium"`            // Index at or above this is "medium" confidence
+	MinEvidence                int     `json:"min_evidence"`                 // Fewer evidence links always means "low" confidence
+}
+
+// DefaultRulesName is the name of the built-in rule set
+const DefaultRulesName = "builtin"
+
+// DefaultRules returns the built-in scoring rules (40/30/20/10, -10 for conflicts)
+func DefaultRules() *Rules {
+	return &Rules{
+		Name: DefaultRulesName,
+		Weights: Weights{
+			Coverage:      40,
+			Authority:     30,
+			Freshness:     20,
+			Accessibility: 10,
+		},
+		AuthorityTiers: AuthorityTierWeights{
+			Primary:   3,
+			Secondary: 2,
+			Tertiary:  1,
+		},
+		Thresholds: Thresholds{
+			CoverageCriticalRatio:      0.5,
+			CoverageWarningRatio:       1.0,
+			SupportedCriticalRatio:     0.4,
+			SupportedWarningRatio:      0.7,
+			AccessibilityCriticalRatio: 0.5,
+			AccessibilityWarningRatio:  0.8,
+			StaleDays:                  365,
+			VeryStaleDays:              365 * 3,
+			FreshnessHorizonYears:      4,
+			ConfidenceHigh:             80,
+			ConfidenceMedium:           60,
+			MinEvidence:                3,
+		},
+		Penalties: map[model.SignalType]int{
+			model.SignalConflict: 10,
+		},
+	}
+}
+
+// LoadRules reads a rules JSON file. Fields missing from the file keep their
+// built-in values, so a file may override only what it needs; unknown fields
+// are rejected so a misspelled key does not silently fall back to a default.
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read rules file: %w", err)
+	}
+
+	rules := DefaultRules()
+	rules.Name = path
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(rules); err != nil {
+		return nil, fmt.Errorf("parse rules file: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rules file %s: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+// Validate checks that the rules produce an index in 0-100 and that all
+// thresholds are consistent. All problems are reported at once.
+func (r *Rules) Validate() error {
+	var errs []error
+
+	w := r.Weights
+	if w.Coverage < 0 || w.Authority < 0 || w.Freshness < 0 || w.Accessibility < 0 {
+		errs = append(errs, errors.New("weights must not be negative"))
+	}
+	if sum := w.Coverage + w.Authority + w.Freshness + w.Accessibility; sum != 100 {
+		errs = append(errs, fmt.Errorf("weights must sum to 100, got %d", sum))
+	}
+
+	tiers := r.AuthorityTiers
+	if tiers.Primary <= 0 || tiers.Secondary < 0 || tiers.Tertiary < 0 {
+		errs = append(errs, errors.New("authority_tiers.primary must be positive and other tiers non-negative"))
+	}
+	if tiers.Secondary > tiers.Primary || tiers.Tertiary > tiers.Primary {
+		errs = append(errs, errors.New("authority_tiers.primary must be the highest tier weight"))
+	}
+
+	t := r.Thresholds
+	if t.CoverageCriticalRatio < 0 || t.CoverageCriticalRatio > t.CoverageWarningRatio {
+		errs = append(errs, errors.New("coverage_critical_ratio must be between 0 and coverage_warning_ratio"))
+	}
+	if t.SupportedCriticalRatio < 0 || t.SupportedCriticalRatio > t.SupportedWarningRatio || t.SupportedWarningRatio > 1 {
+		errs = append(errs, errors.New("supported ratios must satisfy 0 <= critical <= warning <= 1"))
+	}
+	if t.AccessibilityCriticalRatio < 0 || t.AccessibilityCriticalRatio > t.AccessibilityWarningRatio || t.AccessibilityWarningRatio > 1 {
+		errs = append(errs, errors.New("accessibility ratios must satisfy 0 <= critical <= warning <= 1"))
+	}
+	if t.StaleDays <= 0 || t.VeryStaleDays < t.StaleDays {
+		errs = append(errs, errors.New("stale_days must be positive and not exceed very_stale_days"))
+	}
+	if t.FreshnessHorizonYears <= 0 {
+		errs = append(errs, errors.New("freshness_horizon_years must be positive"))
+	}
+	if t.ConfidenceMedium < 0 || t.ConfidenceHigh < t.ConfidenceMedium || t.ConfidenceHigh > 100 {
+		errs = append(errs, errors.New("confidence thresholds must satisfy 0 <= medium <= high <= 100"))
+	}
+	if t.MinEvidence < 0 {
+		errs = append(errs, errors.New("min_evidence must not be negative"))
+	}
+
+	for signalType, penalty := range r.Penalties {
+		if !model.IsKnownSignalType(signalType) {
+			errs = append(errs, fmt.Errorf("penalty for unknown signal type %q", signalType))
+		}
+		if penalty < 0 || penalty > 100 {
+			errs = append(errs, fmt.Errorf("penalty for %q must be between 0 and 100, got %d", signalType, penalty))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,185 @@
+package score
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+func writeRulesFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write rules file: %v", err)
+	}
+	return path
+}
+
+func TestDefaultRules_Valid(t *testing.T) {
+	if err := DefaultRules().Validate(); err != nil {
+		t.Fatalf("Expected built-in rules to be valid, got %v", err)
+	}
+}
+
+func TestLoadRules_PartialOverride(t *testing.T) {
+	path := writeRulesFile(t, `{
+		"name": "docs-team",
+		"weights": {"coverage": 50, "authority": 20, "freshness": 20, "accessibility": 10},
+		"penalties": {"edit_war": 15}
+	}`)
+
+	rules, err := LoadRules(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rules.Name != "docs-team" {
+		t.Errorf("Expected name docs-team, got %q", rules.Name)
+	}
+	if rules.Weights.Coverage != 50 {
+		t.Errorf("Expected coverage weight 50, got %d", rules.Weights.Coverage)
+	}
+	// Unspecified fields keep built-in values
+	if rules.Thresholds.StaleDays != 365 {
+		t.Errorf("Expected default stale_days 365, got %d", rules.Thresholds.StaleDays)
+	}
+	if rules.Penalties[model.SignalConflict] != 10 {
+		t.Errorf("Expected default conflict penalty 10, got %d", rules.Penalties[model.SignalConflict])
+	}
+	if rules.Penalties[model.SignalEditWar] != 15 {
+		t.Errorf("Expected edit_war penalty 15, got %d", rules.Penalties[model.SignalEditWar])
+	}
+}
+
+func TestLoadRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"bad json", `{"weights": `, "parse rules file"},
+		{"misspelled key", `{"wieghts": {"coverage": 100}}`, `unknown field "wieghts"`},
+		{"misspelled nested key", `{"thresholds": {"stale_dyas": 30}}`, `unknown field "stale_dyas"`},
+		{"weights sum", `{"weights": {"coverage": 10, "authority": 10, "freshness": 10, "accessibility": 10}}`, "must sum to 100"},
+		{"unknown signal", `{"penalties": {"made_up": 5}}`, `unknown signal type "made_up"`},
+		{"stale order", `{"thresholds": {"stale_days": 800, "very_stale_days": 400}}`, "stale_days"},
+		{"ratio order", `{"thresholds": {"coverage_critical_ratio": 2}}`, "coverage_critical_ratio"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadRules(writeRulesFile(t, tt.content))
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestScorer_CustomRules_FormulaAndWeights(t *testing.T) {
+	rules := DefaultRules()
+	rules.Name = "custom"
+	rules.Weights = Weights{Coverage: 60, Authority: 20, Freshness: 10, Accessibility: 10}
+	scorer := NewScorerWithRules(rules)
+
+	claims := []model.Claim{{Text: "a"}, {Text: "b"}}
+	evidence := []model.Evidence{{URL: "https://a"}, {URL: "https://b"}}
+	validation := []model.ValidationResult{
+		{URL: "https://a", IsAccessible: true, Authority: model.TierPrimary},
+		{URL: "https://b", IsAccessible: true, Authority: model.TierPrimary},
+	}
+
+	result := scorer.Calculate(claims, evidence, validation)
+
+	if result.Rules != "custom" {
+		t.Errorf("Expected rules name custom, got %q", result.Rules)
+	}
+
+	for _, signal := range result.Signals {
+		if signal.Type == model.SignalEvidenceCoverage {
+			if signal.Data["score"] != 60 {
+				t.Errorf("Expected coverage score 60, got %v", signal.Data["score"])
+			}
+			if signal.Data["formula"] != "min(evidence_count / claim_count * 60, 60)" {
+				t.Errorf("Unexpected coverage formula: %v", signal.Data["formula"])
+			}
+		}
+	}
+
+	// 60 coverage + 20 authority + 5 freshness (no data) + 10 accessibility
+	if result.Index != 95 {
+		t.Errorf("Expected index 95, got %d", result.Index)
+	}
+}
+
+func TestScorer_AddSignals_AppliesPenaltyOncePerType(t *testing.T) {
+	rules := DefaultRules()
+	rules.Penalties[model.SignalNoTLS] = 7
+	scorer := NewScorerWithRules(rules)
+
+	base := model.Score{Index: 50}
+	result := scorer.AddSignals(base, 0, []model.Signal{
+		{Type: model.SignalNoTLS},
+		{Type: model.SignalNoTLS},
+		{Type: model.SignalEditWar}, // No penalty configured
+	})
+
+	if result.Index != 43 {
+		t.Errorf("Expected index 43 after one no_tls penalty, got %d", result.Index)
+	}
+	if len(result.Signals) != 3 {
+		t.Errorf("Expected 3 signals appended, got %d", len(result.Signals))
+	}
+	if result.Signals[0].Data["penalty"] != 7 {
+		t.Errorf("Expected penalty recorded on first signal, got %v", result.Signals[0].Data["penalty"])
+	}
+}
+
+func TestScorer_AddSignals_AppliesPenaltyOnceAcrossCalls(t *testing.T) {
+	rules := DefaultRules()
+	rules.Penalties[model.SignalEditWar] = 10
+	scorer := NewScorerWithRules(rules)
+
+	result := scorer.AddSignals(model.Score{Index: 50}, 0, []model.Signal{{Type: model.SignalEditWar}})
+	result = scorer.AddSignals(result, 0, []model.Signal{{Type: model.SignalEditWar}})
+
+	if result.Index != 40 {
+		t.Errorf("Expected index 40 after one edit_war penalty, got %d", result.Index)
+	}
+	if _, ok := result.Signals[1].Data["penalty"]; ok {
+		t.Error("Expected no penalty recorded on the repeated signal")
+	}
+}
+
+func TestScorer_AddSignals_RecomputesConfidence(t *testing.T) {
+	rules := DefaultRules()
+	rules.Penalties[model.SignalEditWar] = 60
+	scorer := NewScorerWithRules(rules)
+
+	var evidence []model.Evidence
+	var validation []model.ValidationResult
+	for _, u := range []string{"https://a.gov", "https://b.gov", "https://c.gov", "https://d.gov", "https://e.gov"} {
+		evidence = append(evidence, model.Evidence{URL: u})
+		validation = append(validation, model.ValidationResult{URL: u, IsAccessible: true, Authority: model.TierPrimary})
+	}
+	claims := []model.Claim{{Text: "a", Support: model.ClaimSupported}, {Text: "b", Support: model.ClaimSupported}}
+
+	base := scorer.Calculate(claims, evidence, validation)
+	if base.Confidence != "high" {
+		t.Fatalf("Expected high confidence before penalties, got %q (index %d)", base.Confidence, base.Index)
+	}
+
+	result := scorer.AddSignals(base, len(evidence), []model.Signal{{Type: model.SignalEditWar, Severity: model.SeverityWarning}})
+	if result.Index != base.Index-60 {
+		t.Errorf("Expected index %d after the edit_war penalty, got %d", base.Index-60, result.Index)
+	}
+	if result.Confidence != "low" {
+		t.Errorf("Expected confidence re-judged on index %d to be low, got %q", result.Index, result.Confidence)
+	}
+}
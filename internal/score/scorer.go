@@ -10,33 +10,48 @@ import (
 )
 
 // Scorer calculates the support index and generates signals
-type Scorer struct{}
+type Scorer struct {
+	rules *Rules
+}
 
-// NewScorer creates a new scorer
+// NewScorer creates a new scorer using the built-in rules
 func NewScorer() *Scorer {
-	return &Scorer{}
+	return NewScorerWithRules(DefaultRules())
+}
+
+// NewScorerWithRules creates a new scorer using the given rules
+func NewScorerWithRules(rules *Rules) *Scorer {
+	if rules == nil {
+		rules = DefaultRules()
+	}
+	return &Scorer{rules: rules}
 }
 
 // Calculate calculates the support score and generates diagnostic signals
 func (s *Scorer) Calculate(claims []model.Claim, evidence []model.Evidence, validation []model.ValidationResult) model.Score {
 	var signals []model.Signal
 
-	// 1. Evidence Coverage (0-40 points)
+	// 1. Evidence Coverage (0-weights.coverage points)
 	coverageScore, coverageSignal := s.calculateCoverage(claims, evidence)
 	signals = append(signals, coverageSignal)
 
-	// 2. Authority Distribution (0-30 points)
+	// 2. Authority Distribution (0-weights.authority points)
 	authorityScore, authoritySignal := s.calculateAuthority(validation)
 	signals = append(signals, authoritySignal)
 
-	// 3. Freshness (0-20 points)
+	// 3. Freshness (0-weights.freshness points)
 	freshnessScore, freshnessSignal := s.calculateFreshness(validation)
 	signals = append(signals, freshnessSignal)
 
-	// 4. Accessibility (0-10 points)
+	// 4. Accessibility (0-weights.accessibility points)
 	accessScore, accessSignal := s.calculateAccessibility(validation)
 	signals = append(signals, accessSignal)
 
+	// Dead evidence: recoverable from an archive, or lost
+	if deadSignal := s.detectDeadEvidence(validation); deadSignal.Type != "" {
+		signals = append(signals, deadSignal)
+	}
+
 	// 5. Conflict Detection (penalty)
 	conflictDetected, conflictSignal := s.detectConflict(claims)
 	if conflictDetected {
@@ -52,13 +67,8 @@ func (s *Scorer) Calculate(claims []model.Claim, evidence []model.Evidence, vali
 	// Calculate total score
 	totalScore := coverageScore + authorityScore + freshnessScore + accessScore
 
-	// Apply conflict penalty
-	if conflictDetected {
-		totalScore -= 10
-		if totalScore < 0 {
-			totalScore = 0
-		}
-	}
+	// Apply per-signal penalties (e.g., conflict)
+	totalScore = s.applyPenalties(totalScore, nil, signals)
 
 	// Determine confidence level
 	confidence := s.determineConfidence(totalScore, len(evidence), conflictDetected)
@@ -68,11 +78,54 @@ func (s *Scorer) Calculate(claims []model.Claim, evidence []model.Evidence, vali
 		Confidence: confidence,
 		Conflict:   conflictDetected,
 		Signals:    signals,
+		Rules:      s.rules.Name,
 	}
 }
 
-// calculateCoverage calculates evidence coverage score (0-40 points)
+// AddSignals appends signals produced outside the scorer (TLS, Wikipedia
+// conflicts, anachronisms), applies any penalties the rules assign to them
+// and re-judges confidence on the penalised index. evidenceCount is the
+// number of evidence items the score was calculated from.
+func (s *Scorer) AddSignals(score model.Score, evidenceCount int, signals []model.Signal) model.Score {
+	score.Index = s.applyPenalties(score.Index, score.Signals, signals)
+	score.Confidence = s.determineConfidence(score.Index, evidenceCount, score.Conflict)
+	score.Signals = append(score.Signals, signals...)
+	return score
+}
+
+// applyPenalties deducts the configured penalty once per penalised signal
+// type present, recording the deduction in the signal data. Types already
+// in existing were penalised when those signals were added.
+func (s *Scorer) applyPenalties(index int, existing, signals []model.Signal) int {
+	applied := make(map[model.SignalType]bool)
+	for _, signal := range existing {
+		applied[signal.Type] = true
+	}
+	for i := range signals {
+		penalty := s.rules.Penalties[signals[i].Type]
+		if penalty == 0 || applied[signals[i].Type] {
+			continue
+		}
+		applied[signals[i].Type] = true
+
+		if signals[i].Data == nil {
+			signals[i].Data = make(map[string]interface{})
+		}
+		signals[i].Data["penalty"] = penalty
+
+		index -= penalty
+	}
+
+	if index < 0 {
+		index = 0
+	}
+	return index
+}
+
+// calculateCoverage calculates evidence coverage score (0-weights.coverage points)
 func (s *Scorer) calculateCoverage(claims []model.Claim, evidence []model.Evidence) (int, model.Signal) {
+	weight := s.rules.Weights.Coverage
+	thresholds := s.rules.Thresholds
 	claimCount := len(claims)
 	evidenceCount := len(evidence)
 
@@ -88,13 +141,50 @@ func (s *Scorer) calculateCoverage(claims []model.Claim, evidence []model.Eviden
 		}
 	}
 
+	// Prefer per-claim linkage when the extractor computed it
+	supportedCount, linked := 0, false
+	for _, claim := range claims {
+		if claim.Support != "" {
+			linked = true
+		}
+		if claim.Support == model.ClaimSupported {
+			supportedCount++
+		}
+	}
+	if linked {
+		ratio := float64(supportedCount) / float64(claimCount)
+		score := int(ratio * float64(weight))
+
+		severity := model.SeverityInfo
+		if ratio < thresholds.SupportedCriticalRatio {
+			severity = model.SeverityCritical
+		} else if ratio < thresholds.SupportedWarningRatio {
+			severity = model.SeverityWarning
+		}
+
+		return score, model.Signal{
+			Type:        model.SignalEvidenceCoverage,
+			Severity:    severity,
+			Description: fmt.Sprintf("Supported claims: %d/%d (%.0f%%)", supportedCount, claimCount, ratio*100),
+			Data: map[string]interface{}{
+				"claims":      claimCount,
+				"supported":   supportedCount,
+				"unsupported": claimCount - supportedCount,
+				"evidence":    evidenceCount,
+				"ratio":       ratio,
+				"score":       score,
+				"formula":     fmt.Sprintf("(supported_claims / claim_count) * %d", weight),
+			},
+		}
+	}
+
 	ratio := float64(evidenceCount) / float64(claimCount)
-	score := int(math.Min(ratio*40, 40))
+	score := int(math.Min(ratio*float64(weight), float64(weight)))
 
 	severity := model.SeverityInfo
-	if ratio < 0.5 {
+	if ratio < thresholds.CoverageCriticalRatio {
 		severity = model.SeverityCritical
-	} else if ratio < 1.0 {
+	} else if ratio < thresholds.CoverageWarningRatio {
 		severity = model.SeverityWarning
 	}
 
@@ -107,13 +197,16 @@ func (s *Scorer) calculateCoverage(claims []model.Claim, evidence []model.Eviden
 			"evidence": evidenceCount,
 			"ratio":    ratio,
 			"score":    score,
-			"formula":  "min(evidence_count / claim_count * 40, 40)",
+			"formula":  fmt.Sprintf("min(evidence_count / claim_count * %d, %d)", weight, weight),
 		},
 	}
 }
 
-// calculateAuthority calculates authority distribution score (0-30 points)
+// calculateAuthority calculates authority distribution score (0-weights.authority points)
 func (s *Scorer) calculateAuthority(validation []model.ValidationResult) (int, model.Signal) {
+	weight := s.rules.Weights.Authority
+	tiers := s.rules.AuthorityTiers
+
 	if len(validation) == 0 {
 		return 0, model.Signal{
 			Type:        model.SignalAuthorityDistribution,
@@ -139,9 +232,9 @@ func (s *Scorer) calculateAuthority(validation []model.ValidationResult) (int, m
 	}
 
 	total := len(validation)
-	weightedSum := float64(primaryCount*3 + secondaryCount*2 + tertiaryCount*1)
-	maxPossible := float64(total * 3)
-	score := int((weightedSum / maxPossible) * 30)
+	weightedSum := float64(primaryCount*tiers.Primary + secondaryCount*tiers.Secondary + tertiaryCount*tiers.Tertiary)
+	maxPossible := float64(total * tiers.Primary)
+	score := int((weightedSum / maxPossible) * float64(weight))
 
 	severity := model.SeverityInfo
 	if primaryCount == 0 {
@@ -158,13 +251,17 @@ func (s *Scorer) calculateAuthority(validation []model.ValidationResult) (int, m
 			"tertiary":  tertiaryCount,
 			"total":     total,
 			"score":     score,
-			"formula":   "(primary*3 + secondary*2 + tertiary*1) / (total*3) * 30",
+			"formula": fmt.Sprintf("(primary*%d + secondary*%d + tertiary*%d) / (total*%d) * %d",
+				tiers.Primary, tiers.Secondary, tiers.Tertiary, tiers.Primary, weight),
 		},
 	}
 }
 
-// calculateFreshness calculates freshness score (0-20 points)
+// calculateFreshness calculates freshness score (0-weights.freshness points)
 func (s *Scorer) calculateFreshness(validation []model.ValidationResult) (int, model.Signal) {
+	weight := s.rules.Weights.Freshness
+	thresholds := s.rules.Thresholds
+
 	var ages []int
 	for _, v := range validation {
 		if v.Age != nil {
@@ -173,11 +270,11 @@ func (s *Scorer) calculateFreshness(validation []model.ValidationResult) (int, m
 	}
 
 	if len(ages) == 0 {
-		return 10, model.Signal{
+		return weight / 2, model.Signal{
 			Type:        model.SignalFreshness,
 			Severity:    model.SeverityInfo,
 			Description: "No freshness data available (assuming moderate)",
-			Data:        map[string]interface{}{"samples": 0, "score": 10},
+			Data:        map[string]interface{}{"samples": 0, "score": weight / 2},
 		}
 	}
 
@@ -186,16 +283,17 @@ func (s *Scorer) calculateFreshness(validation []model.ValidationResult) (int, m
 	medianAge := ages[len(ages)/2]
 	medianAgeYears := float64(medianAge) / 365.0
 
-	// Score: 20 points for fresh, decreasing by 5 points per year
-	score := 20 - int(medianAgeYears*5)
+	// Score: full points for fresh, reaching 0 at the freshness horizon
+	decayPerYear := float64(weight) / thresholds.FreshnessHorizonYears
+	score := weight - int(medianAgeYears*decayPerYear)
 	if score < 0 {
 		score = 0
 	}
 
 	severity := model.SeverityInfo
-	if medianAgeYears > 3 {
+	if medianAge > thresholds.VeryStaleDays {
 		severity = model.SeverityCritical
-	} else if medianAgeYears > 1 {
+	} else if medianAge > thresholds.StaleDays {
 		severity = model.SeverityWarning
 	}
 
@@ -214,19 +312,22 @@ func (s *Scorer) calculateFreshness(validation []model.ValidationResult) (int, m
 		Severity:    severity,
 		Description: description,
 		Data: map[string]interface{}{
-			"median_age_days":       medianAge,
-			"median_age_years":      medianAgeYears,
-			"samples":               len(ages),
-			"total_sources":         totalSources,
-			"freshness_coverage":    freshnessPercentage,
-			"score":                 score,
-			"formula":               "20 - min(median_age_years * 5, 20)",
+			"median_age_days":    medianAge,
+			"median_age_years":   medianAgeYears,
+			"samples":            len(ages),
+			"total_sources":      totalSources,
+			"freshness_coverage": freshnessPercentage,
+			"score":              score,
+			"formula":            fmt.Sprintf("%d - min(median_age_years * %g, %d)", weight, decayPerYear, weight),
 		},
 	}
 }
 
-// calculateAccessibility calculates accessibility score (0-10 points)
+// calculateAccessibility calculates accessibility score (0-weights.accessibility points)
 func (s *Scorer) calculateAccessibility(validation []model.ValidationResult) (int, model.Signal) {
+	weight := s.rules.Weights.Accessibility
+	thresholds := s.rules.Thresholds
+
 	if len(validation) == 0 {
 		return 0, model.Signal{
 			Type:        model.SignalAccessibility,
@@ -236,33 +337,108 @@ func (s *Scorer) calculateAccessibility(validation []model.ValidationResult) (in
 		}
 	}
 
-	accessibleCount := 0
+	// Links robots.txt forbids checking are unknown, neither alive nor dead,
+	// as are works cited without a link; soft 404s (error or parking pages
+	// served as 2xx) count as dead
+	accessibleCount, disallowedCount, offlineCount, soft404Count := 0, 0, 0, 0
 	for _, v := range validation {
-		if v.IsAccessible {
+		if v.Status == model.ValidationDisallowed {
+			disallowedCount++
+		} else if v.Status == model.ValidationOffline {
+			offlineCount++
+		} else if v.IsAccessible && v.Soft404 != "" {
+			soft404Count++
+		} else if v.IsAccessible {
 			accessibleCount++
 		}
 	}
 
-	ratio := float64(accessibleCount) / float64(len(validation))
-	score := int(ratio * 10)
+	checked := len(validation) - disallowedCount - offlineCount
+	if checked == 0 {
+		return 0, model.Signal{
+			Type:        model.SignalAccessibility,
+			Severity:    model.SeverityWarning,
+			Description: fmt.Sprintf("No evidence could be checked (%d disallowed by robots.txt, %d without a link)", disallowedCount, offlineCount),
+			Data:        map[string]interface{}{"validated": 0, "disallowed": disallowedCount, "offline": offlineCount},
+		}
+	}
+
+	ratio := float64(accessibleCount) / float64(checked)
+	score := int(ratio * float64(weight))
 
 	severity := model.SeverityInfo
-	if ratio < 0.5 {
+	if ratio < thresholds.AccessibilityCriticalRatio {
 		severity = model.SeverityCritical
-	} else if ratio < 0.8 {
+	} else if ratio < thresholds.AccessibilityWarningRatio {
 		severity = model.SeverityWarning
 	}
 
+	description := fmt.Sprintf("Accessibility: %d/%d (%.0f%%)", accessibleCount, checked, ratio*100)
+	if soft404Count > 0 {
+		description += fmt.Sprintf(", %d soft 404", soft404Count)
+	}
+	if disallowedCount > 0 {
+		description += fmt.Sprintf(", %d not checked (robots.txt)", disallowedCount)
+	}
+	if offlineCount > 0 {
+		description += fmt.Sprintf(", %d without a link", offlineCount)
+	}
+
 	return score, model.Signal{
 		Type:        model.SignalAccessibility,
 		Severity:    severity,
-		Description: fmt.Sprintf("Accessibility: %d/%d (%.0f%%)", accessibleCount, len(validation), ratio*100),
+		Description: description,
 		Data: map[string]interface{}{
 			"accessible": accessibleCount,
-			"total":      len(validation),
+			"total":      checked,
+			"disallowed": disallowedCount,
+			"offline":    offlineCount,
+			"soft_404":   soft404Count,
 			"ratio":      ratio,
 			"score":      score,
-			"formula":    "(accessible_count / total) * 10",
+			"formula":    fmt.Sprintf("(accessible_count / checked_count) * %d", weight),
+		},
+	}
+}
+
+// detectDeadEvidence separates dead links (including soft 404s) that have an
+// archived snapshot from those that are lost. Returns an empty signal when
+// no dead link was checked against an archive.
+func (s *Scorer) detectDeadEvidence(validation []model.ValidationResult) model.Signal {
+	deadCount, archivedCount, lostCount := 0, 0, 0
+	for _, v := range validation {
+		if !v.Dead() {
+			continue
+		}
+		deadCount++
+		if !v.ArchiveChecked {
+			continue
+		}
+		if v.ArchivedURL != "" {
+			archivedCount++
+		} else {
+			lostCount++
+		}
+	}
+
+	if archivedCount+lostCount == 0 {
+		return model.Signal{}
+	}
+
+	severity := model.SeverityInfo
+	if lostCount > 0 {
+		severity = model.SeverityWarning
+	}
+
+	return model.Signal{
+		Type:        model.SignalDeadEvidence,
+		Severity:    severity,
+		Description: fmt.Sprintf("Dead evidence: %d archived, %d lost", archivedCount, lostCount),
+		Data: map[string]interface{}{
+			"dead":      deadCount,
+			"archived":  archivedCount,
+			"lost":      lostCount,
+			"unchecked": deadCount - archivedCount - lostCount,
 		},
 	}
 }
@@ -297,7 +473,6 @@ func (s *Scorer) detectConflict(claims []model.Claim) (bool, model.Signal) {
 			Data: map[string]interface{}{
 				"origin_claims": len(originClaims),
 				"entities":      len(countries),
-				"penalty":       10,
 			},
 		}
 	}
@@ -351,13 +526,14 @@ func (s *Scorer) determineConfidence(score int, evidenceCount int, conflict bool
 		return "low-medium"
 	}
 
-	if evidenceCount < 3 {
+	thresholds := s.rules.Thresholds
+	if evidenceCount < thresholds.MinEvidence {
 		return "low"
 	}
 
-	if score >= 80 {
+	if score >= thresholds.ConfidenceHigh {
 		return "high"
-	} else if score >= 60 {
+	} else if score >= thresholds.ConfidenceMedium {
 		return "medium"
 	} else {
 		return "low"
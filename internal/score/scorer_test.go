@@ -1,6 +1,7 @@
 package score
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ppiankov/entropia/internal/model"
@@ -723,3 +724,147 @@ func TestScorer_ScoreClampedToZero(t *testing.T) {
 		t.Errorf("Expected score >= 0 even with conflict penalty, got %d", result.Index)
 	}
 }
+
+func TestScorer_Coverage_SupportedClaims(t *testing.T) {
+	scorer := NewScorer()
+
+	// 200 footer links cannot make 5 unsupported claims look covered
+	claims := make([]model.Claim, 5)
+	for i := range claims {
+		claims[i] = model.Claim{Text: "Unsupported claim", Support: model.ClaimUnsupported}
+	}
+	claims[0].Support = model.ClaimSupported
+	claims[0].EvidenceRefs = []string{"https://example.com/0"}
+
+	evidence := make([]model.Evidence, 200)
+	for i := range evidence {
+		evidence[i] = model.Evidence{URL: "https://example.com/footer"}
+	}
+
+	score, signal := scorer.calculateCoverage(claims, evidence)
+
+	// 1/5 supported * 40 = 8
+	if score != 8 {
+		t.Errorf("Expected coverage score 8, got %d", score)
+	}
+	if signal.Severity != model.SeverityCritical {
+		t.Errorf("Expected critical severity for 20%% supported claims, got %s", signal.Severity)
+	}
+	if signal.Data["supported"] != 1 || signal.Data["unsupported"] != 4 {
+		t.Errorf("Expected 1 supported / 4 unsupported in signal data, got %v", signal.Data)
+	}
+}
+
+func TestScorer_Coverage_LegacyRatioWithoutLinkage(t *testing.T) {
+	scorer := NewScorer()
+
+	// Claims without Support (linkage not computed) fall back to evidence/claim ratio
+	claims := []model.Claim{{Text: "a"}, {Text: "b"}}
+	evidence := []model.Evidence{{URL: "https://example.com/1"}, {URL: "https://example.com/2"}}
+
+	score, signal := scorer.calculateCoverage(claims, evidence)
+	if score != 40 {
+		t.Errorf("Expected full coverage score, got %d", score)
+	}
+	if _, ok := signal.Data["supported"]; ok {
+		t.Error("Expected legacy coverage signal without supported count")
+	}
+}
+
+func TestScorer_Accessibility_ExcludesRobotsDisallowed(t *testing.T) {
+	scorer := NewScorer()
+
+	validation := []model.ValidationResult{
+		{URL: "https://a.example.com", Status: model.ValidationAccessible, IsAccessible: true},
+		{URL: "https://b.example.com", Status: model.ValidationDisallowed},
+		{URL: "https://c.example.com", Status: model.ValidationDisallowed},
+	}
+
+	score, signal := scorer.calculateAccessibility(validation)
+
+	// 1/1 checked link accessible: disallowed links are not counted as failures
+	if score != 10 {
+		t.Errorf("Expected full accessibility score, got %d", score)
+	}
+	if signal.Severity != model.SeverityInfo {
+		t.Errorf("Expected info severity, got %s", signal.Severity)
+	}
+	if signal.Data["disallowed"] != 2 {
+		t.Errorf("Expected 2 disallowed in signal data, got %v", signal.Data["disallowed"])
+	}
+}
+
+func TestScorer_Accessibility_ExcludesOffline(t *testing.T) {
+	scorer := NewScorer()
+
+	validation := []model.ValidationResult{
+		{URL: "https://a.example.com", Status: model.ValidationAccessible, IsAccessible: true},
+		{URL: "https://en.wikipedia.org/wiki/Bridge#cite_note-2", Status: model.ValidationOffline},
+	}
+
+	// Works cited without a link are neither alive nor dead
+	score, signal := scorer.calculateAccessibility(validation)
+	if score != 10 || signal.Data["offline"] != 1 || signal.Data["total"] != 1 {
+		t.Errorf("Expected 1/1 checked with 1 offline, got %d %v", score, signal.Data)
+	}
+}
+
+func TestScorer_Accessibility_Soft404CountsAsDead(t *testing.T) {
+	scorer := NewScorer()
+
+	validation := []model.ValidationResult{
+		{URL: "https://a.example.com/ok", Status: model.ValidationAccessible, IsAccessible: true},
+		{URL: "https://b.example.com/gone", Status: model.ValidationAccessible, IsAccessible: true, Soft404: model.Soft404NotFoundPage},
+		{URL: "https://c.example.com/old", Status: model.ValidationAccessible, IsAccessible: true, Soft404: model.Soft404RedirectToRoot},
+		{URL: "https://d.example.com/parked", Status: model.ValidationAccessible, IsAccessible: true, Soft404: model.Soft404ParkedDomain},
+	}
+
+	score, signal := scorer.calculateAccessibility(validation)
+
+	// 1/4 really accessible
+	if score != 2 {
+		t.Errorf("Expected score 2, got %d", score)
+	}
+	if signal.Severity != model.SeverityCritical {
+		t.Errorf("Expected critical severity, got %s", signal.Severity)
+	}
+	if signal.Data["soft_404"] != 3 {
+		t.Errorf("Expected 3 soft 404s in signal data, got %v", signal.Data["soft_404"])
+	}
+	if !strings.Contains(signal.Description, "3 soft 404") {
+		t.Errorf("Expected soft 404 count in description, got %q", signal.Description)
+	}
+}
+
+func TestScorer_DeadEvidence_ArchivedVsLost(t *testing.T) {
+	scorer := NewScorer()
+
+	validation := []model.ValidationResult{
+		{URL: "https://a.example.com/", IsAccessible: true},
+		{URL: "https://b.example.com/", IsDead: true, ArchiveChecked: true, ArchivedURL: "https://web.archive.org/web/2019/https://b.example.com/"},
+		{URL: "https://c.example.com/", IsDead: true, ArchiveChecked: true},
+		{URL: "https://d.example.com/", IsAccessible: true, Soft404: model.Soft404RedirectToRoot, ArchiveChecked: true},
+		{URL: "https://e.example.com/", IsDead: true}, // lookup failed
+	}
+
+	signal := scorer.detectDeadEvidence(validation)
+
+	if signal.Type != model.SignalDeadEvidence {
+		t.Fatalf("Expected dead_evidence signal, got %q", signal.Type)
+	}
+	if signal.Severity != model.SeverityWarning {
+		t.Errorf("Expected warning when evidence is lost, got %s", signal.Severity)
+	}
+	if signal.Data["archived"] != 1 || signal.Data["lost"] != 2 || signal.Data["unchecked"] != 1 {
+		t.Errorf("Unexpected signal data: %v", signal.Data)
+	}
+
+	allArchived := scorer.detectDeadEvidence(validation[:2])
+	if allArchived.Severity != model.SeverityInfo {
+		t.Errorf("Expected info when all dead evidence is archived, got %s", allArchived.Severity)
+	}
+
+	if none := scorer.detectDeadEvidence(validation[4:]); none.Type != "" {
+		t.Errorf("Expected no signal without archive lookups, got %q", none.Type)
+	}
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// JobStatus is the lifecycle state of an asynchronous batch job
+type JobStatus string
+
+const (
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// Job tracks an asynchronous batch scan
+type Job struct {
+	ID         string      `json:"id"`
+	Status     JobStatus   `json:"status"`
+	Total      int         `json:"total"`
+	Completed  int         `json:"completed"` // Finished scans, successful or not
+	Failed     int         `json:"failed"`
+	CreatedAt  time.Time   `json:"created_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+	Results    []JobResult `json:"results"`
+}
+
+// JobResult is the outcome of one URL in a batch job
+type JobResult struct {
+	URL      string `json:"url"`
+	ReportID string `json:"report_id,omitempty"` // Fetch with GET /reports/{id}
+	Index    *int   `json:"index,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// store holds jobs and reports in memory.
+// Reports are evicted oldest first once maxReports is exceeded; finished
+// jobs are evicted oldest first when a new job takes the count past
+// maxJobs. Running jobs are never evicted, and a job is never dropped just
+// because it finished, so its results stay readable until newer jobs arrive.
+type store struct {
+	mu          sync.RWMutex
+	jobs        map[string]*Job
+	jobOrder    []string
+	maxJobs     int
+	reports     map[string]*model.Report
+	reportOrder []string
+	maxReports  int
+}
+
+func newStore(maxReports, maxJobs int) *store {
+	return &store{
+		jobs:       make(map[string]*Job),
+		maxJobs:    maxJobs,
+		reports:    make(map[string]*model.Report),
+		maxReports: maxReports,
+	}
+}
+
+// addReport stores a report and returns its ID
+func (s *store) addReport(report *model.Report) string {
+	id := newID()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reports[id] = report
+	s.reportOrder = append(s.reportOrder, id)
+	if s.maxReports > 0 && len(s.reportOrder) > s.maxReports {
+		evicted := s.reportOrder[0]
+		s.reportOrder = s.reportOrder[1:]
+		delete(s.reports, evicted)
+	}
+
+	return id
+}
+
+func (s *store) report(id string) (*model.Report, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	report, ok := s.reports[id]
+	return report, ok
+}
+
+// newJob registers a running job for the given number of URLs
+func (s *store) newJob(total int) *Job {
+	job := &Job{
+		ID:        newID(),
+		Status:    JobRunning,
+		Total:     total,
+		CreatedAt: time.Now(),
+		Results:   make([]JobResult, 0, total),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+	s.jobOrder = append(s.jobOrder, job.ID)
+	s.evictJobs()
+
+	return job
+}
+
+// evictJobs drops the oldest finished jobs while more than maxJobs are held.
+// Callers must hold s.mu.
+func (s *store) evictJobs() {
+	if s.maxJobs <= 0 {
+		return
+	}
+	excess := len(s.jobOrder) - s.maxJobs
+	kept := s.jobOrder[:0]
+	for _, id := range s.jobOrder {
+		if excess > 0 && s.jobs[id].FinishedAt != nil {
+			delete(s.jobs, id)
+			excess--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	s.jobOrder = kept
+}
+
+// addJobResult records one finished URL for a job
+func (s *store) addJobResult(id string, result JobResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return
+	}
+	job.Results = append(job.Results, result)
+	job.Completed++
+	if result.Error != "" {
+		job.Failed++
+	}
+}
+
+// finishJob marks a job as done with the given final status
+func (s *store) finishJob(id string, status JobStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return
+	}
+	now := time.Now()
+	job.Status = status
+	job.FinishedAt = &now
+}
+
+// job returns a snapshot of a job safe to encode outside the lock
+func (s *store) job(id string) (Job, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	snapshot := *job
+	snapshot.Results = append(make([]JobResult, 0, len(job.Results)), job.Results...)
+	return snapshot, true
+}
+
+// newID returns a random 16-character hex identifier
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,251 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/worker"
+)
+
+// maxRequestBytes bounds request bodies
+const maxRequestBytes = 1 << 20
+
+// Options configures the API server
+type Options struct {
+	ScanTimeout  time.Duration // Per-request timeout for POST /scan
+	BatchTimeout time.Duration // Total timeout for each batch job
+	MaxBatchURLs int           // Maximum URLs accepted per batch (0 = unlimited)
+	MaxReports   int           // Reports kept in memory before the oldest are evicted (0 = unlimited)
+	MaxJobs      int           // Jobs kept in memory before the oldest finished ones are evicted (0 = unlimited)
+}
+
+// DefaultOptions returns the server defaults
+func DefaultOptions() Options {
+	return Options{
+		ScanTimeout:  2 * time.Minute,
+		BatchTimeout: 30 * time.Minute,
+		MaxBatchURLs: 1000,
+		MaxReports:   1000,
+		MaxJobs:      100,
+	}
+}
+
+// Server exposes the scan pipeline as a JSON API.
+// All scans, synchronous or batched, go through one BatchProcessor and
+// therefore share its per-domain rate limiter.
+type Server struct {
+	processor *worker.BatchProcessor
+	opts      Options
+	store     *store
+
+	ctx    context.Context // Cancelled by Close to stop running jobs
+	cancel context.CancelFunc
+	jobs   sync.WaitGroup
+}
+
+// New creates an API server backed by processor
+func New(processor *worker.BatchProcessor, opts Options) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Server{
+		processor: processor,
+		opts:      opts,
+		store:     newStore(opts.MaxReports, opts.MaxJobs),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Handler returns the HTTP routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("POST /scan", s.handleScan)
+	mux.HandleFunc("POST /batch", s.handleBatch)
+	mux.HandleFunc("GET /jobs/{id}", s.handleJob)
+	mux.HandleFunc("GET /reports/{id}", s.handleReport)
+	return mux
+}
+
+// Close cancels running batch jobs and waits for them to stop
+func (s *Server) Close() {
+	s.cancel()
+	s.jobs.Wait()
+}
+
+type scanRequest struct {
+	URL string `json:"url"`
+}
+
+type batchRequest struct {
+	URLs []string `json:"urls"`
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleScan runs one scan synchronously and returns the report
+func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
+	var req scanRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := validateURL(req.URL); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx := r.Context()
+	if s.opts.ScanTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.ScanTimeout)
+		defer cancel()
+	}
+
+	result := s.processor.ProcessURL(ctx, req.URL)
+	if result.Error != nil {
+		status := http.StatusBadGateway
+		if errors.Is(result.Error, context.DeadlineExceeded) {
+			status = http.StatusGatewayTimeout
+		}
+		writeError(w, status, result.Error)
+		return
+	}
+
+	id := s.store.addReport(result.Report)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":     id,
+		"report": result.Report,
+	})
+}
+
+// handleBatch starts an asynchronous batch job and returns its ID
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Deduplicate like batch input files
+	var urls []string
+	seen := make(map[string]bool)
+	for _, raw := range req.URLs {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || seen[raw] {
+			continue
+		}
+		if err := validateURL(raw); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		seen[raw] = true
+		urls = append(urls, raw)
+	}
+
+	if len(urls) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("urls must contain at least one URL"))
+		return
+	}
+	if s.opts.MaxBatchURLs > 0 && len(urls) > s.opts.MaxBatchURLs {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("batch has %d URLs (max %d)", len(urls), s.opts.MaxBatchURLs))
+		return
+	}
+
+	job := s.store.newJob(len(urls))
+
+	s.jobs.Add(1)
+	go s.runJob(job.ID, urls)
+
+	snapshot, _ := s.store.job(job.ID)
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, snapshot)
+}
+
+// runJob processes a batch in the background, recording results as they finish
+func (s *Server) runJob(id string, urls []string) {
+	defer s.jobs.Done()
+
+	ctx := s.ctx
+	if s.opts.BatchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.BatchTimeout)
+		defer cancel()
+	}
+
+	s.processor.ProcessURLsFunc(ctx, urls, func(result *worker.ScanResult) {
+		jobResult := JobResult{URL: result.URL}
+		if result.Error != nil {
+			jobResult.Error = result.Error.Error()
+		} else {
+			index := result.Report.Score.Index
+			jobResult.ReportID = s.store.addReport(result.Report)
+			jobResult.Index = &index
+		}
+		s.store.addJobResult(id, jobResult)
+	})
+
+	status := JobCompleted
+	if ctx.Err() != nil {
+		status = JobCancelled
+	}
+	s.store.finishJob(id, status)
+}
+
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.store.job(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("job not found"))
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.store.report(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("report not found (unknown or evicted)"))
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// validateURL accepts only absolute http(s) URLs, so API callers cannot
+// make the server read local files
+func validateURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", raw, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid URL %q: must be an absolute http or https URL", raw)
+	}
+	return nil
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("decode request: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/pipeline"
+	"github.com/ppiankov/entropia/internal/worker"
+)
+
+// fakeScanner returns a fixed report, failing for URLs containing "fail"
+type fakeScanner struct{}
+
+func (f *fakeScanner) ScanURL(ctx context.Context, url string) (*pipeline.ScanResult, error) {
+	if strings.Contains(url, "fail") {
+		return nil, errors.New("fetch failed")
+	}
+	return &pipeline.ScanResult{
+		Report: &model.Report{
+			SourceURL: url,
+			Score:     model.Score{Index: 42},
+		},
+	}, nil
+}
+
+func newTestServer(t *testing.T, opts Options) *httptest.Server {
+	t.Helper()
+	processor := worker.NewBatchProcessor(&fakeScanner{}, 2, 0, 0)
+	srv := New(processor, opts)
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(func() {
+		ts.Close()
+		srv.Close()
+	})
+	return ts
+}
+
+func postJSON(t *testing.T, url string, body interface{}) *http.Response {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("POST %s failed: %v", url, err)
+	}
+	return resp
+}
+
+func decode(t *testing.T, resp *http.Response, v interface{}) {
+	t.Helper()
+	defer func() { _ = resp.Body.Close() }()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+}
+
+func TestServer_ScanAndFetchReport(t *testing.T) {
+	ts := newTestServer(t, DefaultOptions())
+
+	resp := postJSON(t, ts.URL+"/scan", scanRequest{URL: "https://example.com/page"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var scanResp struct {
+		ID     string       `json:"id"`
+		Report model.Report `json:"report"`
+	}
+	decode(t, resp, &scanResp)
+	if scanResp.ID == "" || scanResp.Report.Score.Index != 42 {
+		t.Fatalf("unexpected scan response: %+v", scanResp)
+	}
+
+	resp, err := http.Get(ts.URL + "/reports/" + scanResp.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report model.Report
+	decode(t, resp, &report)
+	if report.SourceURL != "https://example.com/page" {
+		t.Errorf("expected stored report, got source %q", report.SourceURL)
+	}
+}
+
+func TestServer_ScanRejectsNonHTTPURL(t *testing.T) {
+	ts := newTestServer(t, DefaultOptions())
+
+	for _, raw := range []string{"file:///etc/passwd", "example.com", ""} {
+		resp := postJSON(t, ts.URL+"/scan", scanRequest{URL: raw})
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", raw, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_ScanFailure(t *testing.T) {
+	ts := newTestServer(t, DefaultOptions())
+
+	resp := postJSON(t, ts.URL+"/scan", scanRequest{URL: "https://fail.example.com"})
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_BatchJob(t *testing.T) {
+	ts := newTestServer(t, DefaultOptions())
+
+	resp := postJSON(t, ts.URL+"/batch", batchRequest{URLs: []string{
+		"https://a.example.com",
+		"https://fail.example.com",
+		"https://a.example.com", // duplicate
+		"https://b.example.com",
+	}})
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", resp.StatusCode)
+	}
+	location := resp.Header.Get("Location")
+	var job Job
+	decode(t, resp, &job)
+	if job.Total != 3 {
+		t.Errorf("expected 3 deduplicated URLs, got %d", job.Total)
+	}
+	if location != "/jobs/"+job.ID {
+		t.Errorf("unexpected Location header %q", location)
+	}
+
+	// Poll until the job finishes
+	deadline := time.Now().Add(5 * time.Second)
+	for job.Status == JobRunning {
+		if time.Now().After(deadline) {
+			t.Fatal("job did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+		resp, err := http.Get(ts.URL + location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decode(t, resp, &job)
+	}
+
+	if job.Status != JobCompleted || job.Completed != 3 || job.Failed != 1 {
+		t.Fatalf("unexpected final job state: %+v", job)
+	}
+	for _, result := range job.Results {
+		if result.Error != "" {
+			continue
+		}
+		resp, err := http.Get(ts.URL + "/reports/" + result.ReportID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("report %s for %s: expected 200, got %d", result.ReportID, result.URL, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_BatchLimits(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxBatchURLs = 1
+	ts := newTestServer(t, opts)
+
+	resp := postJSON(t, ts.URL+"/batch", batchRequest{})
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("empty batch: expected 400, got %d", resp.StatusCode)
+	}
+
+	resp = postJSON(t, ts.URL+"/batch", batchRequest{URLs: []string{"https://a.example.com", "https://b.example.com"}})
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized batch: expected 413, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_UnknownIDs(t *testing.T) {
+	ts := newTestServer(t, DefaultOptions())
+
+	for _, path := range []string{"/jobs/missing", "/reports/missing"} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestStore_EvictsOldestReports(t *testing.T) {
+	s := newStore(2, 0)
+	first := s.addReport(&model.Report{SourceURL: "1"})
+	s.addReport(&model.Report{SourceURL: "2"})
+	s.addReport(&model.Report{SourceURL: "3"})
+
+	if _, ok := s.report(first); ok {
+		t.Error("expected oldest report to be evicted")
+	}
+	if len(s.reports) != 2 {
+		t.Errorf("expected 2 reports kept, got %d", len(s.reports))
+	}
+}
+
+func TestStore_EvictsOldestFinishedJobs(t *testing.T) {
+	s := newStore(0, 2)
+	running := s.newJob(1)
+	finished := s.newJob(1)
+	s.finishJob(finished.ID, JobCompleted)
+	latest := s.newJob(1)
+
+	if _, ok := s.job(finished.ID); ok {
+		t.Error("expected oldest finished job to be evicted")
+	}
+	for _, id := range []string{running.ID, latest.ID} {
+		if _, ok := s.job(id); !ok {
+			t.Errorf("expected job %s to be kept", id)
+		}
+	}
+
+	// Running jobs are kept over the cap
+	s.newJob(1)
+	if len(s.jobs) != 3 {
+		t.Errorf("expected 3 jobs while all are running, got %d", len(s.jobs))
+	}
+}
+
+func TestStore_KeepsJobsThatFinishOverTheCap(t *testing.T) {
+	s := newStore(0, 1)
+	first := s.newJob(1)
+	second := s.newJob(1)
+	s.finishJob(first.ID, JobCompleted)
+	s.finishJob(second.ID, JobCompleted)
+
+	job, ok := s.job(second.ID)
+	if !ok {
+		t.Fatal("expected the last finished job to stay retrievable")
+	}
+	if job.Status != JobCompleted {
+		t.Errorf("expected status completed, got %s", job.Status)
+	}
+
+	// The next job makes room by evicting the finished ones
+	s.newJob(1)
+	if _, ok := s.job(first.ID); ok {
+		t.Error("expected finished jobs to be evicted when a new job arrives")
+	}
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"strings"
+	"time"
+)
+
+// dateLayouts are the publication date formats seen in page and citation
+// metadata, most specific first
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	"2006-01",
+	"2006/01",
+	time.RFC1123,
+	time.RFC1123Z,
+	"January 2, 2006",
+	"2 January 2006",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+	"January 2006",
+	"2006",
+}
+
+// ParseDate parses a metadata date string, returning false when no layout matches
+func ParseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
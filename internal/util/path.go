@@ -0,0 +1,19 @@
+package util
+
+import (
+	"os"
+	"strings"
+)
+
+// ExpandHome expands a leading "~/" to the user's home directory.
+// The path is returned unchanged if the home directory cannot be determined.
+func ExpandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return home + path[1:]
+}
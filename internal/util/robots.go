@@ -2,6 +2,7 @@ package util
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -12,25 +13,53 @@ import (
 	"github.com/temoto/robotstxt"
 )
 
+// ErrRobotsDisallowed is returned when robots.txt forbids fetching a URL
+var ErrRobotsDisallowed = errors.New("robots.txt disallows fetching")
+
+// DefaultRobotsTTL is how long a host's robots.txt is cached before it is
+// fetched again, so long-running processes see changes
+const DefaultRobotsTTL = 24 * time.Hour
+
 // RobotsChecker checks robots.txt compliance
 type RobotsChecker struct {
-	cache      map[string]*robotstxt.RobotsData
+	cache      map[string]robotsEntry
 	mu         sync.RWMutex
 	httpClient *http.Client
 	userAgent  string
+	ttl        time.Duration
+}
+
+// robotsEntry is a cached robots.txt and when it was fetched
+type robotsEntry struct {
+	data      *robotstxt.RobotsData
+	fetchedAt time.Time
 }
 
 // NewRobotsChecker creates a new robots.txt checker
 func NewRobotsChecker(userAgent string, timeout time.Duration) *RobotsChecker {
 	return &RobotsChecker{
-		cache: make(map[string]*robotstxt.RobotsData),
+		cache: make(map[string]robotsEntry),
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
 		userAgent: userAgent,
+		ttl:       DefaultRobotsTTL,
 	}
 }
 
+// SetCacheTTL sets how long a host's robots.txt is cached (0 = for the
+// life of the checker)
+func (r *RobotsChecker) SetCacheTTL(ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ttl = ttl
+}
+
+// SetProxy routes robots.txt requests through the given proxy function
+func (r *RobotsChecker) SetProxy(proxy func(*http.Request) (*url.URL, error)) {
+	r.httpClient.Transport = &http.Transport{Proxy: proxy}
+}
+
 // CanFetch checks if the URL can be fetched according to robots.txt
 // Returns (allowed, crawlDelay, error)
 func (r *RobotsChecker) CanFetch(ctx context.Context, rawURL string) (bool, time.Duration, error) {
@@ -49,7 +78,11 @@ func (r *RobotsChecker) CanFetch(ctx context.Context, rawURL string) (bool, time
 	}
 
 	// Check if path is allowed
-	allowed := data.TestAgent(parsed.Path, r.userAgent)
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	allowed := data.TestAgent(path, r.userAgent)
 
 	// Get crawl delay
 	crawlDelay := time.Duration(0)
@@ -64,11 +97,12 @@ func (r *RobotsChecker) CanFetch(ctx context.Context, rawURL string) (bool, time
 func (r *RobotsChecker) getRobotsData(ctx context.Context, host string, robotsURL string) (*robotstxt.RobotsData, error) {
 	// Check cache first
 	r.mu.RLock()
-	data, exists := r.cache[host]
+	entry, exists := r.cache[host]
+	fresh := exists && (r.ttl <= 0 || time.Since(entry.fetchedAt) < r.ttl)
 	r.mu.RUnlock()
 
-	if exists {
-		return data, nil
+	if fresh {
+		return entry.data, nil
 	}
 
 	// Fetch robots.txt
@@ -92,6 +126,12 @@ func (r *RobotsChecker) getRobotsData(ctx context.Context, host string, robotsUR
 		return data, nil
 	}
 
+	// A server error is temporary: the caller allows the URL, and the next
+	// check fetches robots.txt again instead of caching a disallow-all
+	if resp.StatusCode >= 500 {
+		return nil, fmt.Errorf("fetch robots.txt: status %d", resp.StatusCode)
+	}
+
 	// Parse robots.txt
 	data, err2 := robotstxt.FromResponse(resp)
 	if err2 != nil {
@@ -108,14 +148,14 @@ func (r *RobotsChecker) getRobotsData(ctx context.Context, host string, robotsUR
 func (r *RobotsChecker) cacheData(host string, data *robotstxt.RobotsData) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.cache[host] = data
+	r.cache[host] = robotsEntry{data: data, fetchedAt: time.Now()}
 }
 
 // Clear clears the robots.txt cache
 func (r *RobotsChecker) Clear() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.cache = make(map[string]*robotstxt.RobotsData)
+	r.cache = make(map[string]robotsEntry)
 }
 
 // GetCrawlDelay returns the crawl delay for a URL
@@ -130,6 +170,22 @@ func (r *RobotsChecker) IsAllowed(ctx context.Context, rawURL string) bool {
 	return allowed
 }
 
+// Sitemaps returns the Sitemap URLs listed in the robots.txt of rawURL's host
+func (r *RobotsChecker) Sitemaps(ctx context.Context, rawURL string) ([]string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse URL: %w", err)
+	}
+
+	robotsURL := fmt.Sprintf("%s://%s/robots.txt", parsed.Scheme, parsed.Host)
+	data, err := r.getRobotsData(ctx, parsed.Host, robotsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.Sitemaps, nil
+}
+
 // NormalizeUserAgent normalizes the user agent string for robots.txt matching
 func NormalizeUserAgent(ua string) string {
 	// Extract the product name (first token)
@@ -0,0 +1,145 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/util"
+	"golang.org/x/net/html"
+)
+
+// extractCitation reads the bibliographic fields an evidence page states
+// about itself: Highwire-style citation_* meta tags (Google Scholar's
+// format, used by most journals and repositories), then schema.org JSON-LD
+// for fields still missing. Returns nil when the page has neither.
+func extractCitation(body []byte) *model.Citation {
+	meta := &model.Citation{Sources: []model.CitationSource{model.CitationSourceMeta}}
+	var ld *model.Citation
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	inJSONLD := false
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "meta":
+				value := strings.TrimSpace(attr(token, "content"))
+				if value == "" {
+					continue
+				}
+				switch strings.ToLower(attr(token, "name")) {
+				case "citation_title":
+					meta.Title = firstNonEmpty(meta.Title, value)
+				case "citation_author":
+					meta.Authors = append(meta.Authors, value)
+				case "citation_journal_title", "citation_conference_title", "citation_publisher":
+					meta.Publisher = firstNonEmpty(meta.Publisher, value)
+				case "citation_publication_date", "citation_date", "citation_online_date":
+					if t, ok := util.ParseDate(value); ok && meta.PublishedAt == nil {
+						meta.PublishedAt = &t
+					}
+				}
+			case "script":
+				inJSONLD = strings.EqualFold(strings.TrimSpace(attr(token, "type")), "application/ld+json")
+			}
+		case html.TextToken:
+			if inJSONLD && ld == nil {
+				var data interface{}
+				if err := json.Unmarshal(tokenizer.Text(), &data); err == nil {
+					ld = jsonLDCitation(data)
+				}
+			}
+		case html.EndTagToken:
+			inJSONLD = false
+		}
+	}
+
+	if meta.Title == "" && len(meta.Authors) == 0 && meta.Publisher == "" && meta.PublishedAt == nil {
+		return ld
+	}
+	return meta.Merge(ld)
+}
+
+// jsonLDCitation returns the fields of the first JSON-LD object (including
+// @graph members) that describes a published work: one with a headline,
+// author, publisher or datePublished, other than the site itself
+func jsonLDCitation(data interface{}) *model.Citation {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		work := false
+		for _, key := range []string{"headline", "author", "publisher", "datePublished"} {
+			if _, ok := v[key]; ok {
+				work = true
+			}
+		}
+		if work && v["@type"] != "WebSite" {
+			citation := &model.Citation{
+				Title:     firstNonEmpty(jsonLDString(v["headline"]), jsonLDString(v["name"])),
+				Authors:   jsonLDNames(v["author"]),
+				Publisher: jsonLDName(v["publisher"]),
+				Sources:   []model.CitationSource{model.CitationSourceJSONLD},
+			}
+			if t, ok := util.ParseDate(jsonLDString(v["datePublished"])); ok {
+				citation.PublishedAt = &t
+			}
+			return citation
+		}
+		if graph, ok := v["@graph"]; ok {
+			return jsonLDCitation(graph)
+		}
+	case []interface{}:
+		for _, child := range v {
+			if citation := jsonLDCitation(child); citation != nil {
+				return citation
+			}
+		}
+	}
+	return nil
+}
+
+// jsonLDNames reads a person or organization, or a list of them
+func jsonLDNames(value interface{}) []string {
+	var names []string
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			if name := jsonLDName(item); name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	if name := jsonLDName(value); name != "" {
+		names = append(names, name)
+	}
+	return names
+}
+
+// jsonLDName reads a name given as a string or an object with "name"
+func jsonLDName(value interface{}) string {
+	if object, ok := value.(map[string]interface{}); ok {
+		return jsonLDString(object["name"])
+	}
+	return jsonLDString(value)
+}
+
+func jsonLDString(value interface{}) string {
+	s, _ := value.(string)
+	return strings.TrimSpace(s)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/util"
+	"golang.org/x/net/html"
+)
+
+// extractPublishedDate scans HTML for a publication date. Sources are ranked
+// by how explicitly they state publication: article:published_time, then
+// citation_date, then JSON-LD datePublished, then the first <time datetime>.
+func extractPublishedDate(r io.Reader) (time.Time, model.DateSource, bool) {
+	found := make(map[model.DateSource]time.Time)
+	record := func(source model.DateSource, value string) {
+		if _, ok := found[source]; ok {
+			return
+		}
+		if t, ok := util.ParseDate(value); ok {
+			found[source] = t
+		}
+	}
+
+	tokenizer := html.NewTokenizer(r)
+	inJSONLD := false
+
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			break // io.EOF or truncated body; use what was found
+		}
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "meta":
+				key := strings.ToLower(attr(token, "property"))
+				if key == "" {
+					key = strings.ToLower(attr(token, "name"))
+				}
+				switch key {
+				case "article:published_time":
+					record(model.DateSourceArticlePublished, attr(token, "content"))
+				case "citation_date", "citation_publication_date", "citation_online_date":
+					record(model.DateSourceCitationDate, attr(token, "content"))
+				}
+			case "time":
+				if datetime := attr(token, "datetime"); datetime != "" {
+					record(model.DateSourceTimeElement, datetime)
+				}
+			case "script":
+				inJSONLD = strings.EqualFold(strings.TrimSpace(attr(token, "type")), "application/ld+json")
+			}
+		case html.TextToken:
+			if inJSONLD {
+				var data interface{}
+				if err := json.Unmarshal(tokenizer.Text(), &data); err == nil {
+					if value, ok := findJSONLDDate(data); ok {
+						record(model.DateSourceJSONLD, value)
+					}
+				}
+			}
+		case html.EndTagToken:
+			inJSONLD = false
+		}
+	}
+
+	for _, source := range []model.DateSource{
+		model.DateSourceArticlePublished,
+		model.DateSourceCitationDate,
+		model.DateSourceJSONLD,
+		model.DateSourceTimeElement,
+	} {
+		if t, ok := found[source]; ok {
+			return t, source, true
+		}
+	}
+	return time.Time{}, "", false
+}
+
+// findJSONLDDate walks a JSON-LD document (including @graph arrays) for datePublished
+func findJSONLDDate(data interface{}) (string, bool) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if value, ok := v["datePublished"].(string); ok && value != "" {
+			return value, true
+		}
+		// Visit nested objects in key order so the result is deterministic
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if value, ok := findJSONLDDate(v[key]); ok {
+				return value, true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if value, ok := findJSONLDDate(child); ok {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// attr returns the value of the named attribute, or "" if absent
+func attr(token html.Token, name string) string {
+	for _, a := range token.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
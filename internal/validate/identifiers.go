@@ -0,0 +1,96 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ppiankov/entropia/internal/model"
+)
+
+// Handle System response codes
+const (
+	handleFound    = 1
+	handleNotFound = 100
+)
+
+// handleResponse is the part of a handle API response validation reads
+type handleResponse struct {
+	ResponseCode int `json:"responseCode"`
+	Values       []struct {
+		Type string `json:"type"`
+		Data struct {
+			Value interface{} `json:"value"`
+		} `json:"data"`
+	} `json:"values"`
+}
+
+// validateDOI looks a DOI up in the resolver's handle API
+// (<doi_resolver>/api/handles/<doi>). A registered DOI is accessible and its
+// landing page is reported as the redirect; an unregistered one is dead.
+// Publisher pages are not requested: many reject automated clients.
+func (v *Validator) validateDOI(ctx context.Context, result model.ValidationResult, doi string) model.ValidationResult {
+	lookup := strings.TrimSuffix(v.resolvers.DOIResolver, "/") + "/api/handles/" + (&url.URL{Path: doi}).EscapedPath()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lookup, nil)
+	if err != nil {
+		result.Status = model.ValidationInaccessible
+		result.Error = fmt.Sprintf("create request: %v", err)
+		return result
+	}
+	req.Header.Set("User-Agent", v.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		// The resolver is unreachable; that says nothing about the DOI
+		result.Status = model.ValidationInaccessible
+		result.Error = fmt.Sprintf("DOI lookup failed: %v", err)
+		return result
+	}
+	defer func() { _ = resp.Body.Close() }()
+	result.StatusCode = resp.StatusCode
+
+	var handle handleResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, v.maxBodyBytes)).Decode(&handle); err != nil && resp.StatusCode == http.StatusOK {
+		result.Status = model.ValidationInaccessible
+		result.Error = fmt.Sprintf("decode DOI lookup: %v", err)
+		return result
+	}
+
+	switch {
+	case handle.ResponseCode == handleFound:
+		result.Status = model.ValidationAccessible
+		result.IsAccessible = true
+		for _, value := range handle.Values {
+			if target, ok := value.Data.Value.(string); ok && value.Type == "URL" {
+				result.RedirectURL = target
+				break
+			}
+		}
+	case handle.ResponseCode == handleNotFound || resp.StatusCode == http.StatusNotFound:
+		result.Status = model.ValidationDead
+		result.IsDead = true
+		result.Error = "DOI not registered"
+	default:
+		result.Status = model.ValidationInaccessible
+		result.Error = fmt.Sprintf("DOI lookup returned HTTP %d (response code %d)", resp.StatusCode, handle.ResponseCode)
+	}
+	return result
+}
+
+// identifierTier is the authority of a cited identifier: DOIs and arXiv IDs
+// name scholarly records, ISBNs published books
+func identifierTier(t model.IdentifierType) model.AuthorityTier {
+	switch t {
+	case model.IdentifierDOI, model.IdentifierArXiv:
+		return model.TierPrimary
+	case model.IdentifierISBN:
+		return model.TierSecondary
+	}
+	return model.TierTertiary
+}
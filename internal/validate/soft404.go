@@ -0,0 +1,250 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/ppiankov/entropia/internal/model"
+	"golang.org/x/net/html"
+)
+
+// notFoundPhrases mark "page not found" templates served with 200
+var notFoundPhrases = []string{
+	"page not found",
+	"404 not found",
+	"error 404",
+	"404 error",
+	"page cannot be found",
+	"page could not be found",
+	"page can't be found",
+	"page does not exist",
+	"page doesn't exist",
+	"page you requested",
+	"page you are looking for",
+	"page you were looking for",
+	"page you're looking for",
+	"no longer available",
+	"no longer exists",
+	"has been removed",
+	"nothing was found",
+}
+
+// parkedPhrases mark domain-parking and for-sale pages
+var parkedPhrases = []string{
+	"this domain is for sale",
+	"domain is for sale",
+	"this domain may be for sale",
+	"buy this domain",
+	"the domain has expired",
+	"this domain has expired",
+	"domain parking",
+	"parked free",
+	"parked domain",
+	"this domain is parked",
+}
+
+// parkingHosts are domain marketplaces and parking services that expired
+// domains redirect to
+var parkingHosts = []string{
+	"sedo.com",
+	"sedoparking.com",
+	"dan.com",
+	"afternic.com",
+	"hugedomains.com",
+	"parkingcrew.net",
+	"bodis.com",
+	"above.com",
+	"godaddy.com/domainsearch",
+}
+
+// maxNotFoundTextLen bounds body-text matching: real articles that merely
+// mention "page not found" are much longer than error templates
+const maxNotFoundTextLen = 3000
+
+// pageSummary is the part of an HTML page the soft-404 heuristics look at
+type pageSummary struct {
+	Title string
+	Text  string // Visible text, whitespace-collapsed
+}
+
+// summarizePage extracts the title and visible text of an HTML body
+func summarizePage(body []byte) pageSummary {
+	var title, text strings.Builder
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	inTitle, skip := false, 0
+
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		switch tt {
+		case html.StartTagToken:
+			switch name, _ := tokenizer.TagName(); string(name) {
+			case "title":
+				inTitle = true
+			case "script", "style", "noscript":
+				skip++
+			}
+		case html.EndTagToken:
+			switch name, _ := tokenizer.TagName(); string(name) {
+			case "title":
+				inTitle = false
+			case "script", "style", "noscript":
+				if skip > 0 {
+					skip--
+				}
+			}
+		case html.TextToken:
+			if inTitle {
+				title.Write(tokenizer.Text())
+			} else if skip == 0 {
+				text.Write(tokenizer.Text())
+				text.WriteByte(' ')
+			}
+		}
+	}
+
+	return pageSummary{
+		Title: strings.Join(strings.Fields(title.String()), " "),
+		Text:  strings.Join(strings.Fields(text.String()), " "),
+	}
+}
+
+// classifyPage applies title/body heuristics to an accessible page
+func classifyPage(page pageSummary) model.Soft404Kind {
+	title := strings.ToLower(page.Title)
+	text := strings.ToLower(page.Text)
+
+	if containsAny(title, parkedPhrases) || (len(text) <= maxNotFoundTextLen && containsAny(text, parkedPhrases)) {
+		return model.Soft404ParkedDomain
+	}
+	if containsAny(title, notFoundPhrases) || strings.HasPrefix(title, "404") {
+		return model.Soft404NotFoundPage
+	}
+	if len(text) <= maxNotFoundTextLen && containsAny(text, notFoundPhrases) {
+		return model.Soft404NotFoundPage
+	}
+	return ""
+}
+
+// classifyRedirect flags deep links that ended on a homepage or a parking service
+func classifyRedirect(originalURL, finalURL string) model.Soft404Kind {
+	if finalURL == "" || finalURL == originalURL {
+		return ""
+	}
+	final, err := url.Parse(finalURL)
+	if err != nil {
+		return ""
+	}
+
+	target := strings.ToLower(final.Host + final.Path)
+	for _, host := range parkingHosts {
+		if strings.HasPrefix(target, host) || strings.Contains(target, "."+host) {
+			return model.Soft404ParkedDomain
+		}
+	}
+
+	original, err := url.Parse(originalURL)
+	if err != nil {
+		return ""
+	}
+	if !isRootPath(original.Path) && isRootPath(final.Path) && final.RawQuery == "" {
+		return model.Soft404RedirectToRoot
+	}
+	return ""
+}
+
+// isRootPath reports whether a path is a site's homepage
+func isRootPath(path string) bool {
+	switch strings.ToLower(strings.TrimSuffix(path, "/")) {
+	case "", "/index.html", "/index.htm", "/index.php", "/home":
+		return true
+	}
+	return false
+}
+
+func containsAny(s string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if strings.Contains(s, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostProbe caches the response a host gives for a path that cannot exist
+type hostProbe struct {
+	once     sync.Once
+	soft404  bool   // Host answered the random path with 2xx
+	finalURL string // Where the random path ended up (after redirects)
+	title    string
+}
+
+// matchesProbe reports whether a page looks like the host's response to a
+// random path. The probe runs once per host and is skipped when robots.txt
+// disallows it.
+func (v *Validator) matchesProbe(ctx context.Context, pageURL, finalURL string, page pageSummary) bool {
+	parsed, err := url.Parse(pageURL)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	host := parsed.Scheme + "://" + parsed.Host
+
+	v.probesMu.Lock()
+	probe, ok := v.probes[host]
+	if !ok {
+		probe = &hostProbe{}
+		v.probes[host] = probe
+	}
+	v.probesMu.Unlock()
+
+	probe.once.Do(func() { v.runProbe(ctx, host, probe) })
+
+	if !probe.soft404 {
+		return false
+	}
+	if probe.finalURL != "" && probe.finalURL == finalURL {
+		return true
+	}
+	return probe.title != "" && strings.EqualFold(probe.title, page.Title)
+}
+
+// runProbe requests a random path on host and records the response
+func (v *Validator) runProbe(ctx context.Context, host string, probe *hostProbe) {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return
+	}
+	probeURL := host + "/entropia-probe-" + hex.EncodeToString(token)
+
+	if v.robots != nil {
+		if allowed, _, err := v.robots.CanFetch(ctx, probeURL); err != nil || !allowed {
+			return
+		}
+	}
+
+	resp, err := v.request(ctx, http.MethodGet, probeURL)
+	if err != nil {
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return // Host returns real errors for missing pages
+	}
+
+	probe.soft404 = true
+	if final := resp.Request.URL.String(); final != probeURL {
+		probe.finalURL = final
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, v.maxBodyBytes))
+	probe.title = summarizePage(body).Title
+}
@@ -1,9 +1,16 @@
 package validate
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -21,9 +28,26 @@ var validateSleepFunc = time.Sleep
 type Validator struct {
 	httpClient *http.Client
 	maxWorkers int
+	userAgent  string
 	authority  *AuthorityClassifier
+	robots     *util.RobotsChecker     // Optional robots.txt enforcement (nil = disabled)
+	resolvers  *model.IdentifierConfig // Optional identifier resolvers (nil = validate evidence URLs as-is)
+
+	contentDates bool  // GET evidence and read publication dates from the body
+	soft404      bool  // GET evidence and check the body for not-found/parking templates
+	soft404Probe bool  // Compare pages against a random path on the same host
+	maxBodyBytes int64 // Body read limit for contentDates and soft404
+
+	probesMu sync.Mutex
+	probes   map[string]*hostProbe // Per-host random path responses (soft404Probe)
 }
 
+// defaultMaxBodyBytes bounds evidence body reads when no limit is configured
+const defaultMaxBodyBytes = 256_000
+
+// defaultUserAgent identifies evidence requests unless SetUserAgent is called
+const defaultUserAgent = "Entropia/0.1 (+https://github.com/ppiankov/entropia)"
+
 // NewValidator creates a new validator
 func NewValidator(timeout time.Duration, maxWorkers int, authConfig *model.AuthorityConfig, httpProxy, httpsProxy, noProxy string) *Validator {
 	if maxWorkers <= 0 {
@@ -45,8 +69,57 @@ func NewValidator(timeout time.Duration, maxWorkers int, authConfig *model.Autho
 				return nil
 			},
 		},
-		maxWorkers: maxWorkers,
-		authority:  NewAuthorityClassifier(authConfig),
+		maxWorkers:   maxWorkers,
+		userAgent:    defaultUserAgent,
+		authority:    NewAuthorityClassifier(authConfig),
+		maxBodyBytes: defaultMaxBodyBytes,
+		probes:       make(map[string]*hostProbe),
+	}
+}
+
+// SetUserAgent sets the User-Agent sent with evidence requests; it should
+// match the one robots.txt rules are checked for
+func (v *Validator) SetUserAgent(userAgent string) {
+	if userAgent != "" {
+		v.userAgent = userAgent
+	}
+}
+
+// SetRobotsChecker skips evidence that robots.txt disallows, reporting it
+// as ValidationDisallowed instead of requesting it
+func (v *Validator) SetRobotsChecker(robots *util.RobotsChecker) {
+	v.robots = robots
+}
+
+// SetIdentifierResolvers validates evidence that cites a DOI, arXiv ID or
+// ISBN at its resolver rather than at the evidence URL: DOIs through the
+// resolver's handle API, arXiv IDs and ISBNs by requesting their page
+func (v *Validator) SetIdentifierResolvers(resolvers model.IdentifierConfig) {
+	v.resolvers = &resolvers
+}
+
+// SetContentDates switches validation to GET and reads up to maxBodyBytes of
+// each HTML evidence page for a publication date (article:published_time,
+// citation_date, JSON-LD datePublished, <time>) and bibliographic fields
+// (citation_* meta, JSON-LD)
+func (v *Validator) SetContentDates(maxBodyBytes int64) {
+	v.contentDates = true
+	v.setMaxBodyBytes(maxBodyBytes)
+}
+
+// SetSoft404Detection switches validation to GET and flags accessible pages
+// whose title or text is a "not found" or domain-parking template. With probe
+// set, pages matching a random path on the same host are flagged as well.
+// Redirects from a deep link to a homepage are flagged regardless.
+func (v *Validator) SetSoft404Detection(maxBodyBytes int64, probe bool) {
+	v.soft404 = true
+	v.soft404Probe = probe
+	v.setMaxBodyBytes(maxBodyBytes)
+}
+
+func (v *Validator) setMaxBodyBytes(maxBodyBytes int64) {
+	if maxBodyBytes > 0 {
+		v.maxBodyBytes = maxBodyBytes
 	}
 }
 
@@ -72,6 +145,7 @@ func (v *Validator) Validate(ctx context.Context, evidence []model.Evidence) ([]
 			case <-ctx.Done():
 				results[idx] = model.ValidationResult{
 					URL:          e.URL,
+					Status:       model.ValidationInaccessible,
 					IsAccessible: false,
 					Error:        "context cancelled",
 				}
@@ -83,7 +157,15 @@ func (v *Validator) Validate(ctx context.Context, evidence []model.Evidence) ([]
 			defer func() { <-semaphore }()
 
 			// Validate the evidence with retry
-			results[idx] = v.validateSingleWithRetry(ctx, e)
+			result := v.validateSingleWithRetry(ctx, e)
+
+			// The cited work's publication date beats any date of the page hosting it
+			if e.Citation != nil && e.Citation.PublishedAt != nil && !e.Citation.PublishedAt.After(time.Now()) {
+				published := *e.Citation.PublishedAt
+				result.PublishedAt = &published
+				setAge(&result, published, model.DateSourceCitedWork)
+			}
+			results[idx] = result
 		}(i, ev)
 	}
 
@@ -101,20 +183,63 @@ func (v *Validator) validateSingle(ctx context.Context, evidence model.Evidence)
 		Authority:    v.authority.Classify(evidence.URL),
 	}
 
-	// Create HEAD request
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, evidence.URL, nil)
-	if err != nil {
-		result.Error = fmt.Sprintf("create request: %v", err)
-		result.IsDead = true
+	// Works cited without a link (print books and journals) have nothing to
+	// request; a published work is rated as a secondary source
+	if evidence.Offline {
+		result.Status = model.ValidationOffline
+		result.Authority = model.TierSecondary
 		return result
 	}
 
-	req.Header.Set("User-Agent", "Entropia/0.1 (+https://github.com/ppiankov/entropia)")
+	// Links to local files (relative links in docs read from disk) are
+	// checked on disk, never requested
+	if strings.HasPrefix(evidence.URL, "file://") {
+		return validateFile(result)
+	}
 
-	// Execute request
-	resp, err := v.httpClient.Do(req)
+	// Cited identifiers are scholarly records or published books, wherever
+	// they are resolved, and are checked at their resolver
+	target := evidence.URL
+	if id := evidence.Identifier; id != nil {
+		if tier := identifierTier(id.Type); tier < result.Authority {
+			result.Authority = tier
+		}
+		if v.resolvers != nil {
+			if id.Type == model.IdentifierDOI && v.resolvers.DOIResolver != "" {
+				return v.validateDOI(ctx, result, id.Value)
+			}
+			if resolved := v.resolvers.URL(*id); resolved != "" {
+				target = resolved
+			}
+		}
+	}
+
+	// Respect robots.txt: disallowed links are not requested and not counted as dead
+	if v.robots != nil {
+		if allowed, _, err := v.robots.CanFetch(ctx, target); err == nil && !allowed {
+			result.Status = model.ValidationDisallowed
+			result.Error = util.ErrRobotsDisallowed.Error()
+			return result
+		}
+	}
+
+	// HEAD is cheapest; GET when we need the body or the server rejects HEAD
+	readBody := v.contentDates || v.soft404
+	method := http.MethodHead
+	if readBody {
+		method = http.MethodGet
+	}
+	resp, err := v.request(ctx, method, target)
+	if method == http.MethodHead && shouldFallbackToGet(ctx, resp, err) {
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		method = http.MethodGet
+		resp, err = v.request(ctx, method, target)
+	}
 	if err != nil {
-		result.Error = fmt.Sprintf("request failed: %v", err)
+		result.Error = err.Error()
+		result.Status = model.ValidationDead
 		result.IsDead = true
 		return result
 	}
@@ -124,13 +249,17 @@ func (v *Validator) validateSingle(ctx context.Context, evidence model.Evidence)
 
 	// Check if accessible
 	if resp.StatusCode >= 200 && resp.StatusCode < 400 {
+		result.Status = model.ValidationAccessible
 		result.IsAccessible = true
 	} else if resp.StatusCode == 404 || resp.StatusCode == 410 {
+		result.Status = model.ValidationDead
 		result.IsDead = true
+	} else {
+		result.Status = model.ValidationInaccessible
 	}
 
 	// Check for redirects
-	if resp.Request.URL.String() != evidence.URL {
+	if resp.Request.URL.String() != target {
 		result.RedirectURL = resp.Request.URL.String()
 	}
 
@@ -138,24 +267,126 @@ func (v *Validator) validateSingle(ctx context.Context, evidence model.Evidence)
 	if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
 		if t, err := time.Parse(time.RFC1123, lastModified); err == nil {
 			result.LastModified = &t
+			setAge(&result, t, model.DateSourceLastModified)
+		}
+	}
 
-			// Calculate age in days
-			ageDays := int(time.Since(t).Hours() / 24)
-			result.Age = &ageDays
+	// A deep link that lands on a homepage or parking service is a soft 404
+	if result.IsAccessible {
+		result.Soft404 = classifyRedirect(target, resp.Request.URL.String())
+	}
 
-			// Determine staleness
-			if ageDays > 365 {
-				result.IsStale = true
-			}
-			if ageDays > 365*3 {
-				result.IsVeryStale = true
-			}
+	if !readBody || method != http.MethodGet || !result.IsAccessible || !isHTML(resp.Header.Get("Content-Type")) {
+		return result
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, v.maxBodyBytes))
+	if err != nil && len(body) == 0 {
+		return result
+	}
+
+	// Prefer a publication date stated in the page over the header
+	if v.contentDates {
+		if t, source, ok := extractPublishedDate(bytes.NewReader(body)); ok && !t.After(time.Now()) {
+			result.PublishedAt = &t
+			setAge(&result, t, source)
+		}
+		result.Citation = extractCitation(body)
+	}
+
+	if v.soft404 && result.Soft404 == "" {
+		page := summarizePage(body)
+		result.Soft404 = classifyPage(page)
+		if result.Soft404 == "" && v.soft404Probe && v.matchesProbe(ctx, target, resp.Request.URL.String(), page) {
+			result.Soft404 = model.Soft404ProbeMatch
 		}
 	}
 
 	return result
 }
 
+// validateFile marks a file:// link accessible when the file (or
+// directory) exists and dead when it does not. File times are not used for
+// freshness: a checkout sets them.
+func validateFile(result model.ValidationResult) model.ValidationResult {
+	parsed, err := url.Parse(result.URL)
+	if err != nil {
+		result.Status = model.ValidationInaccessible
+		result.Error = err.Error()
+		return result
+	}
+	_, err = os.Stat(filepath.FromSlash(parsed.Path))
+	switch {
+	case err == nil:
+		result.Status = model.ValidationAccessible
+		result.IsAccessible = true
+	case errors.Is(err, fs.ErrNotExist):
+		result.Status = model.ValidationDead
+		result.IsDead = true
+		result.Error = "file not found"
+	default:
+		result.Status = model.ValidationInaccessible
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// request issues a single request for an evidence URL
+func (v *Validator) request(ctx context.Context, method, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", v.userAgent)
+	if method == http.MethodGet {
+		req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8")
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// shouldFallbackToGet reports whether a HEAD outcome means the server may
+// not support HEAD: a non-timeout request failure, or a status that servers
+// commonly return for unsupported methods. 404/410 and retryable statuses
+// (429, 5xx other than 501) are trusted as-is.
+func shouldFallbackToGet(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !isRetryableNetworkError(err.Error())
+	}
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// setAge records a dated source and its staleness
+func setAge(result *model.ValidationResult, t time.Time, source model.DateSource) {
+	ageDays := int(time.Since(t).Hours() / 24)
+	result.Age = &ageDays
+	result.DateSource = source
+
+	// Determine staleness
+	result.IsStale = ageDays > 365
+	result.IsVeryStale = ageDays > 365*3
+}
+
+// isHTML reports whether a Content-Type is HTML (missing counts as HTML)
+func isHTML(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	ct := strings.ToLower(contentType)
+	return strings.Contains(ct, "text/html") || strings.Contains(ct, "application/xhtml")
+}
+
 // validateSingleWithRetry retries transient failures with exponential backoff
 func (v *Validator) validateSingleWithRetry(ctx context.Context, evidence model.Evidence) model.ValidationResult {
 	var result model.ValidationResult
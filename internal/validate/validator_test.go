@@ -4,11 +4,14 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ppiankov/entropia/internal/model"
+	"github.com/ppiankov/entropia/internal/util"
 )
 
 func init() {
@@ -349,6 +352,23 @@ func TestNewValidator_CustomWorkers(t *testing.T) {
 	}
 }
 
+func TestValidator_SetUserAgent(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	validator := NewValidator(5*time.Second, 20, nil, "", "", "")
+	validator.SetUserAgent("MyOrg-Audit/1.0")
+	validator.validateSingle(context.Background(), model.Evidence{URL: server.URL, Kind: model.EvidenceKindExternalLink})
+
+	if got != "MyOrg-Audit/1.0" {
+		t.Errorf("Expected the configured User-Agent, got %q", got)
+	}
+}
+
 func TestValidateSingleWithRetry_TransientThenSuccess(t *testing.T) {
 	var attempts atomic.Int32
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -496,3 +516,334 @@ func TestValidateBatch_ConvenienceFunction(t *testing.T) {
 		t.Error("Expected link to be accessible")
 	}
 }
+
+func TestValidator_RobotsDisallowed(t *testing.T) {
+	var pageHits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /paywall/\n"))
+			return
+		}
+		pageHits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	validator := NewValidator(5*time.Second, 20, nil, "", "", "")
+	validator.SetRobotsChecker(util.NewRobotsChecker("Entropia/0.1", 5*time.Second))
+
+	results, err := validator.Validate(context.Background(), []model.Evidence{
+		{URL: server.URL + "/paywall/article"},
+		{URL: server.URL + "/open/article"},
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	disallowed := results[0]
+	if disallowed.Status != model.ValidationDisallowed {
+		t.Errorf("Expected disallowed status, got %q", disallowed.Status)
+	}
+	if disallowed.IsDead || disallowed.IsAccessible {
+		t.Error("Expected disallowed evidence to be neither dead nor accessible")
+	}
+
+	if results[1].Status != model.ValidationAccessible {
+		t.Errorf("Expected accessible status for allowed evidence, got %q", results[1].Status)
+	}
+	if pageHits.Load() != 1 {
+		t.Errorf("Expected only the allowed page to be requested, got %d hits", pageHits.Load())
+	}
+}
+
+func TestValidator_HeadRejectedFallsBackToGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2023 15:04:05 GMT")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	validator := NewValidator(5*time.Second, 20, nil, "", "", "")
+	result := validator.validateSingle(context.Background(), model.Evidence{URL: server.URL})
+
+	if !result.IsAccessible || result.StatusCode != http.StatusOK {
+		t.Fatalf("Expected GET fallback to succeed, got status %d (%s)", result.StatusCode, result.Status)
+	}
+	if result.DateSource != model.DateSourceLastModified || result.Age == nil {
+		t.Errorf("Expected age from Last-Modified, got source %q", result.DateSource)
+	}
+}
+
+func TestValidator_ContentDates(t *testing.T) {
+	pages := map[string]string{
+		"/article": `<html><head>
+			<meta property="article:published_time" content="2019-05-04T10:00:00Z">
+			<script type="application/ld+json">{"@type":"NewsArticle","datePublished":"2020-01-01"}</script>
+			</head><body><time datetime="2021-02-03">Feb 3</time></body></html>`,
+		"/paper": `<html><head><meta name="citation_date" content="2015/07/01"></head></html>`,
+		"/graph": `<html><head><script type="application/ld+json">
+			{"@context":"https://schema.org","@graph":[{"@type":"WebSite"},{"@type":"Article","datePublished":"2018-03-09T08:00:00+01:00"}]}
+			</script></head></html>`,
+		"/blog":    `<html><body><article><time datetime="2017-11-30">30 Nov</time></article></body></html>`,
+		"/undated": `<html><body><p>No dates here</p></body></html>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET with content dates, got %s", r.Method)
+		}
+		w.Header().Set("Last-Modified", time.Now().Format(time.RFC1123))
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	validator := NewValidator(5*time.Second, 20, nil, "", "", "")
+	validator.SetContentDates(64_000)
+
+	tests := []struct {
+		path   string
+		source model.DateSource
+		date   string
+	}{
+		{"/article", model.DateSourceArticlePublished, "2019-05-04"},
+		{"/paper", model.DateSourceCitationDate, "2015-07-01"},
+		{"/graph", model.DateSourceJSONLD, "2018-03-09"},
+		{"/blog", model.DateSourceTimeElement, "2017-11-30"},
+		{"/undated", model.DateSourceLastModified, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result := validator.validateSingle(context.Background(), model.Evidence{URL: server.URL + tt.path})
+
+			if result.DateSource != tt.source {
+				t.Fatalf("Expected date source %q, got %q", tt.source, result.DateSource)
+			}
+			if tt.date == "" {
+				if result.PublishedAt != nil {
+					t.Errorf("Expected no published date, got %v", result.PublishedAt)
+				}
+				return
+			}
+			if result.PublishedAt == nil || result.PublishedAt.Format("2006-01-02") != tt.date {
+				t.Fatalf("Expected published date %s, got %v", tt.date, result.PublishedAt)
+			}
+			if result.Age == nil || !result.IsStale {
+				t.Errorf("Expected age from page content to mark source stale, got age %v", result.Age)
+			}
+		})
+	}
+}
+
+func TestValidator_Soft404(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`<html><head><title>Example News</title></head><body>Welcome home</body></html>`))
+			return
+		}
+		// Unknown paths get a generic template with 200
+		_, _ = w.Write([]byte(`<html><head><title>Example News</title></head><body><p>Sorry, we couldn't locate that.</p></body></html>`))
+	})
+	mux.HandleFunc("/articles/real", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head><title>Bridge opens after 40 years | Example News</title></head><body><p>The bridge opened today.</p></body></html>`))
+	})
+	mux.HandleFunc("/articles/missing", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head><title>Page Not Found | Example News</title></head><body><p>The page you requested could not be found.</p></body></html>`))
+	})
+	mux.HandleFunc("/articles/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/parked", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head><title>example.net</title></head><body><h1>This domain is for sale!</h1><p>Related searches</p></body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	validator := NewValidator(5*time.Second, 20, nil, "", "", "")
+	validator.SetSoft404Detection(64_000, true)
+
+	tests := []struct {
+		path string
+		want model.Soft404Kind
+	}{
+		{"/articles/real", ""},
+		{"/articles/missing", model.Soft404NotFoundPage},
+		{"/articles/moved", model.Soft404RedirectToRoot},
+		{"/parked", model.Soft404ParkedDomain},
+		{"/articles/deleted", model.Soft404ProbeMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result := validator.validateSingle(context.Background(), model.Evidence{URL: server.URL + tt.path})
+
+			if !result.IsAccessible {
+				t.Fatalf("Expected HTTP-level success, got %d", result.StatusCode)
+			}
+			if result.Soft404 != tt.want {
+				t.Errorf("Expected soft 404 %q, got %q", tt.want, result.Soft404)
+			}
+		})
+	}
+}
+
+func TestValidator_RedirectToRootWithoutBodyChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	validator := NewValidator(5*time.Second, 20, nil, "", "", "")
+	result := validator.validateSingle(context.Background(), model.Evidence{URL: server.URL + "/2019/report.html"})
+
+	if result.Soft404 != model.Soft404RedirectToRoot {
+		t.Errorf("Expected redirect-to-root with HEAD only, got %q", result.Soft404)
+	}
+}
+
+func TestValidator_FileLinks(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "install.md")
+	if err := os.WriteFile(existing, []byte("# Install\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	validator := NewValidator(5*time.Second, 20, nil, "", "", "")
+	results, err := validator.Validate(context.Background(), []model.Evidence{
+		{URL: "file://" + filepath.ToSlash(existing) + "#setup"},
+		{URL: "file://" + filepath.ToSlash(filepath.Join(dir, "missing.md"))},
+		{URL: "file://" + filepath.ToSlash(dir)},
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	want := []model.ValidationStatus{model.ValidationAccessible, model.ValidationDead, model.ValidationAccessible}
+	for i, result := range results {
+		if result.Status != want[i] || result.StatusCode != 0 {
+			t.Errorf("%s: status %s (code %d), want %s", result.URL, result.Status, result.StatusCode, want[i])
+		}
+	}
+	if !results[1].IsDead {
+		t.Error("Expected a missing file to be dead")
+	}
+}
+
+func TestValidator_Identifiers(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/handles/10.1000/abc.1":
+			_, _ = w.Write([]byte(`{"responseCode":1,"handle":"10.1000/abc.1","values":[{"index":1,"type":"URL","data":{"format":"string","value":"https://publisher.example.com/abc"}}]}`))
+		case "/api/handles/10.1000/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"responseCode":100,"handle":"10.1000/missing"}`))
+		case "/abs/2101.00001":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	validator := NewValidator(5*time.Second, 1, nil, "", "", "")
+	validator.SetIdentifierResolvers(model.IdentifierConfig{
+		Enabled:       true,
+		DOIResolver:   server.URL,
+		ArXivResolver: server.URL + "/abs",
+	})
+	results, err := validator.Validate(context.Background(), []model.Evidence{
+		{URL: "https://doi.org/10.1000/abc.1", Identifier: &model.Identifier{Type: model.IdentifierDOI, Value: "10.1000/abc.1"}},
+		{URL: "https://doi.org/10.1000/missing", Identifier: &model.Identifier{Type: model.IdentifierDOI, Value: "10.1000/missing"}},
+		{URL: "https://arxiv.org/pdf/2101.00001", Identifier: &model.Identifier{Type: model.IdentifierArXiv, Value: "2101.00001"}},
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	if results[0].Status != model.ValidationAccessible || results[0].RedirectURL != "https://publisher.example.com/abc" {
+		t.Errorf("Expected registered DOI accessible with its landing page, got %+v", results[0])
+	}
+	if results[1].Status != model.ValidationDead || !results[1].IsDead {
+		t.Errorf("Expected unregistered DOI dead, got %+v", results[1])
+	}
+	if results[2].Status != model.ValidationAccessible || results[2].URL != "https://arxiv.org/pdf/2101.00001" {
+		t.Errorf("Expected arXiv ID checked at its abstract page, got %+v", results[2])
+	}
+	for _, result := range results {
+		if result.Authority != model.TierPrimary {
+			t.Errorf("%s: authority %s, want primary", result.URL, result.Authority)
+		}
+	}
+	if len(requested) != 3 {
+		t.Errorf("Expected only resolver requests, got %v", requested)
+	}
+}
+
+func TestValidator_Citations(t *testing.T) {
+	pages := map[string]string{
+		"/paper": `<html><head>
+			<meta name="citation_title" content="A Study of Decay">
+			<meta name="citation_author" content="Smith, Jane">
+			<meta name="citation_author" content="Doe, John">
+			<meta name="citation_journal_title" content="Journal of Links">
+			<meta name="citation_publication_date" content="2012/03/04">
+			<script type="application/ld+json">{"@type":"ScholarlyArticle","publisher":{"@type":"Organization","name":"Example Press"}}</script>
+			</head></html>`,
+		"/news": `<html><head><script type="application/ld+json">
+			{"@graph":[{"@type":"WebSite","name":"News"},{"@type":"NewsArticle","headline":"Bridge reopens","author":[{"name":"A. Reporter"}],"publisher":"Daily News","datePublished":"2016-08-01"}]}
+			</script></head></html>`,
+		"/plain": `<html><body>Landing page</body></html>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", time.Now().Format(time.RFC1123))
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	validator := NewValidator(5*time.Second, 20, nil, "", "", "")
+	validator.SetContentDates(64_000)
+
+	published := time.Date(2010, 6, 1, 0, 0, 0, 0, time.UTC)
+	results, err := validator.Validate(context.Background(), []model.Evidence{
+		{URL: server.URL + "/paper"},
+		{URL: server.URL + "/news"},
+		{URL: server.URL + "/plain", Citation: &model.Citation{PublishedAt: &published}},
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	paper := results[0].Citation
+	if paper == nil || paper.Title != "A Study of Decay" || len(paper.Authors) != 2 || paper.Publisher != "Journal of Links" ||
+		paper.PublishedAt == nil || paper.PublishedAt.Format("2006-01-02") != "2012-03-04" {
+		t.Fatalf("Expected citation_* fields, got %+v", paper)
+	}
+	if len(paper.Sources) != 2 || paper.Sources[0] != model.CitationSourceMeta {
+		t.Errorf("Expected meta fields completed by JSON-LD, got sources %v", paper.Sources)
+	}
+
+	news := results[1].Citation
+	if news == nil || news.Title != "Bridge reopens" || news.Publisher != "Daily News" || len(news.Authors) != 1 {
+		t.Errorf("Expected JSON-LD fields, got %+v", news)
+	}
+	if results[2].Citation != nil {
+		t.Errorf("Expected no citation fields on a plain page, got %+v", results[2].Citation)
+	}
+
+	// The cited work's date wins over the landing page's Last-Modified
+	if results[2].DateSource != model.DateSourceCitedWork || results[2].PublishedAt == nil || !results[2].PublishedAt.Equal(published) || !results[2].IsVeryStale {
+		t.Errorf("Expected age from the cited work's publication date, got %+v", results[2])
+	}
+}
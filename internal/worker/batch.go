@@ -77,6 +77,12 @@ func NewBatchProcessor(scanner Scanner, concurrency int, rps float64, burst int)
 		limiter = NewLimiter(rps, burst)
 	}
 
+	return NewBatchProcessorWithLimiter(scanner, concurrency, limiter)
+}
+
+// NewBatchProcessorWithLimiter creates a batch processor that shares an
+// existing limiter (nil disables rate limiting)
+func NewBatchProcessorWithLimiter(scanner Scanner, concurrency int, limiter *Limiter) *BatchProcessor {
 	return &BatchProcessor{
 		scanner:     scanner,
 		concurrency: concurrency,
@@ -84,33 +90,61 @@ func NewBatchProcessor(scanner Scanner, concurrency int, rps float64, burst int)
 	}
 }
 
+// Limiter returns the processor's per-domain rate limiter (may be nil)
+func (b *BatchProcessor) Limiter() *Limiter {
+	return b.limiter
+}
+
+// ProcessURL scans a single URL under the processor's rate limiter
+func (b *BatchProcessor) ProcessURL(ctx context.Context, url string) *ScanResult {
+	job := &ScanJob{
+		URL:     url,
+		Scanner: b.scanner,
+		Limiter: b.limiter,
+	}
+	return job.Execute(ctx).(*ScanResult)
+}
+
 // ProcessURLs processes multiple URLs concurrently
 func (b *BatchProcessor) ProcessURLs(ctx context.Context, urls []string) []*ScanResult {
+	return b.ProcessURLsFunc(ctx, urls, nil)
+}
+
+// ProcessURLsFunc processes multiple URLs concurrently, calling onResult
+// (if non-nil) as each scan finishes. Results are returned in completion
+// order. Scans in flight when ctx is cancelled still produce a result;
+// URLs not started before then produce none.
+func (b *BatchProcessor) ProcessURLsFunc(ctx context.Context, urls []string, onResult func(*ScanResult)) []*ScanResult {
 	if len(urls) == 0 {
 		return []*ScanResult{}
 	}
 
 	// Create worker pool
-	pool := NewPool(b.concurrency)
+	pool := NewPoolWithContext(ctx, b.concurrency)
 	pool.Start()
 
-	// Submit jobs
-	for _, url := range urls {
-		job := &ScanJob{
-			URL:     url,
-			Scanner: b.scanner,
-			Limiter: b.limiter,
+	// Submit jobs while results are consumed below, so large batches
+	// never fill both the job and result buffers
+	go func() {
+		for _, url := range urls {
+			job := &ScanJob{
+				URL:     url,
+				Scanner: b.scanner,
+				Limiter: b.limiter,
+			}
+			pool.Submit(job)
 		}
-		pool.Submit(job)
-	}
-
-	// Wait for all jobs to complete
-	results := pool.Wait()
-
-	// Convert to ScanResults
-	scanResults := make([]*ScanResult, len(results))
-	for i, result := range results {
-		scanResults[i] = result.(*ScanResult)
+		pool.Close()
+	}()
+
+	// Collect results as they arrive
+	scanResults := make([]*ScanResult, 0, len(urls))
+	for result := range pool.Results() {
+		scanResult := result.(*ScanResult)
+		if onResult != nil {
+			onResult(scanResult)
+		}
+		scanResults = append(scanResults, scanResult)
 	}
 
 	return scanResults
@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -238,3 +239,105 @@ http://example.com`
 		t.Errorf("expected 1 URL after deduplication, got %d", len(urls))
 	}
 }
+
+func TestBatchProcessor_ProcessURLs_LargeBatch(t *testing.T) {
+	scanner := &MockScanner{}
+	processor := NewBatchProcessor(scanner, 2, 0, 0)
+
+	// Far more URLs than the pool's job and result buffers combined
+	var urls []string
+	for i := 0; i < 50; i++ {
+		urls = append(urls, fmt.Sprintf("http://example%d.com", i))
+	}
+
+	done := make(chan []*ScanResult, 1)
+	go func() {
+		done <- processor.ProcessURLs(context.Background(), urls)
+	}()
+
+	select {
+	case results := <-done:
+		if len(results) != len(urls) {
+			t.Errorf("expected %d results, got %d", len(urls), len(results))
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("ProcessURLs deadlocked on a large batch")
+	}
+}
+
+func TestBatchProcessor_ProcessURLsFunc_Streams(t *testing.T) {
+	scanner := &MockScanner{}
+	processor := NewBatchProcessor(scanner, 3, 0, 0)
+
+	urls := []string{"http://a.com", "http://b.com", "http://c.com", "http://d.com"}
+	seen := make(map[string]bool)
+
+	results := processor.ProcessURLsFunc(context.Background(), urls, func(r *ScanResult) {
+		seen[r.URL] = true
+	})
+
+	if len(results) != len(urls) {
+		t.Fatalf("expected %d results, got %d", len(urls), len(results))
+	}
+	for _, url := range urls {
+		if !seen[url] {
+			t.Errorf("callback not invoked for %s", url)
+		}
+	}
+}
+
+func TestBatchProcessor_SharedLimiter(t *testing.T) {
+	limiter := NewLimiter(10, 1)
+	first := NewBatchProcessorWithLimiter(&MockScanner{}, 1, limiter)
+	second := NewBatchProcessorWithLimiter(&MockScanner{}, 1, limiter)
+
+	if first.Limiter() != second.Limiter() {
+		t.Fatal("expected processors to share one limiter")
+	}
+
+	// Burst of 1 is consumed by the first processor
+	if res := first.ProcessURL(context.Background(), "http://example.com/a"); res.Error != nil {
+		t.Fatalf("unexpected error: %v", res.Error)
+	}
+	if limiter.Allow("http://example.com/b") {
+		t.Error("expected the shared per-domain budget to be exhausted")
+	}
+}
+
+// cancelScanner cancels the batch from inside the first scan, which still
+// completes, and fails any scan started after the cancellation
+type cancelScanner struct {
+	cancel context.CancelFunc
+}
+
+func (s *cancelScanner) ScanURL(ctx context.Context, url string) (*pipeline.ScanResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.cancel()
+	return &pipeline.ScanResult{Report: &model.Report{SourceURL: url}}, nil
+}
+
+func TestBatchProcessor_ProcessURLsFunc_KeepsResultsFinishedAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	processor := NewBatchProcessor(&cancelScanner{cancel: cancel}, 1, 0, 0)
+
+	urls := []string{"http://a.com", "http://b.com", "http://c.com"}
+	results := processor.ProcessURLsFunc(ctx, urls, nil)
+
+	completed := 0
+	for _, r := range results {
+		if r.Error == nil {
+			completed++
+			if r.Report == nil {
+				t.Errorf("expected a report for %s", r.URL)
+			}
+		} else if !errors.Is(r.Error, context.Canceled) {
+			t.Errorf("unexpected error for %s: %v", r.URL, r.Error)
+		}
+	}
+	if completed != 1 {
+		t.Errorf("expected the scan finished during cancellation to be delivered, got %d completed of %d results", completed, len(results))
+	}
+}
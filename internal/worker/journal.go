@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// JournalEntry records one finished URL of a batch
+type JournalEntry struct {
+	URL        string    `json:"url"`
+	Report     string    `json:"report,omitempty"` // JSON report file, relative to the output directory
+	Error      string    `json:"error,omitempty"`  // Scan or render error (entry is not complete)
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// Complete reports whether the URL was scanned and its report written
+func (e JournalEntry) Complete() bool {
+	return e.Error == "" && e.Report != ""
+}
+
+// Journal is an append-only JSON Lines checkpoint of finished batch URLs.
+// Each entry is synced to disk as soon as it is recorded, so a crash or
+// interrupt loses at most the scans still in flight.
+type Journal struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]JournalEntry
+}
+
+// OpenJournal opens the journal at path. With resume, existing entries are
+// loaded and new ones appended; otherwise the journal starts empty.
+func OpenJournal(path string, resume bool) (*Journal, error) {
+	j := &Journal{entries: make(map[string]JournalEntry)}
+
+	partial := false
+	if resume {
+		var err error
+		if partial, err = j.load(path); err != nil {
+			return nil, err
+		}
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if !resume {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open journal: %w", err)
+	}
+	j.file = file
+
+	if partial {
+		if _, err := file.Write([]byte("\n")); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("write journal: %w", err)
+		}
+	}
+
+	return j, nil
+}
+
+// load reads existing entries; the last entry for a URL wins. A truncated
+// final line (from a crash mid-write) is ignored and reported via partial
+// so the next append starts on a fresh line.
+func (j *Journal) load(path string) (partial bool, err error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read journal: %w", err)
+	}
+
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var entry JournalEntry
+		if err := json.Unmarshal(line, &entry); err != nil || entry.URL == "" {
+			continue
+		}
+		j.entries[entry.URL] = entry
+	}
+
+	return len(data) > 0 && data[len(data)-1] != '\n', nil
+}
+
+// Completed returns the entry for url if it finished successfully
+func (j *Journal) Completed(url string) (JournalEntry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, ok := j.entries[url]
+	if !ok || !entry.Complete() {
+		return JournalEntry{}, false
+	}
+	return entry, true
+}
+
+// Record appends an entry and syncs it to disk
+func (j *Journal) Record(entry JournalEntry) error {
+	if entry.FinishedAt.IsZero() {
+		entry.FinishedAt = time.Now().UTC()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode journal entry: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("sync journal: %w", err)
+	}
+	j.entries[entry.URL] = entry
+
+	return nil
+}
+
+// Close closes the journal file
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournal_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch-journal.jsonl")
+
+	journal, err := OpenJournal(path, false)
+	if err != nil {
+		t.Fatalf("OpenJournal failed: %v", err)
+	}
+	entries := []JournalEntry{
+		{URL: "https://example.com/a", Report: "a.json"},
+		{URL: "https://example.com/b", Error: "timeout"},
+		{URL: "https://example.com/c", Error: "timeout"},
+		{URL: "https://example.com/c", Report: "c.json"}, // Retried later
+	}
+	for _, entry := range entries {
+		if err := journal.Record(entry); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+	if err := journal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash mid-write
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"url":"https://example.com/d","rep`)
+	_ = f.Close()
+
+	resumed, err := OpenJournal(path, true)
+	if err != nil {
+		t.Fatalf("OpenJournal(resume) failed: %v", err)
+	}
+
+	if entry, ok := resumed.Completed("https://example.com/a"); !ok || entry.Report != "a.json" {
+		t.Errorf("Expected a to be complete, got %+v %v", entry, ok)
+	}
+	if _, ok := resumed.Completed("https://example.com/b"); ok {
+		t.Error("Failed URL should not count as complete")
+	}
+	if _, ok := resumed.Completed("https://example.com/c"); !ok {
+		t.Error("Last entry should win for retried URL")
+	}
+	if _, ok := resumed.Completed("https://example.com/d"); ok {
+		t.Error("Truncated entry should be ignored")
+	}
+
+	// Appending after a truncated line must not corrupt the new entry
+	if err := resumed.Record(JournalEntry{URL: "https://example.com/e", Report: "e.json"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := resumed.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := OpenJournal(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reloaded.Completed("https://example.com/e"); !ok {
+		t.Error("Entry appended after a truncated line should load")
+	}
+	_ = reloaded.Close()
+
+	fresh, err := OpenJournal(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = fresh.Close() }()
+	if _, ok := fresh.Completed("https://example.com/a"); ok {
+		t.Error("Journal opened without resume should start empty")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ppiankov/entropia/internal/util"
 	"golang.org/x/time/rate"
 )
 
@@ -15,6 +16,7 @@ type Limiter struct {
 	mu           sync.RWMutex
 	defaultRate  rate.Limit
 	defaultBurst int
+	robots       *util.RobotsChecker // Optional source of per-host Crawl-delay
 }
 
 // NewLimiter creates a new rate limiter
@@ -30,6 +32,13 @@ func NewLimiter(requestsPerSecond float64, burst int) *Limiter {
 	}
 }
 
+// SetRobotsChecker makes the limiter honor robots.txt Crawl-delay: the first
+// request to a host looks up its delay and slows that host's rate to at
+// most one request per delay
+func (l *Limiter) SetRobotsChecker(robots *util.RobotsChecker) {
+	l.robots = robots
+}
+
 // Wait waits for rate limit clearance for the given URL
 func (l *Limiter) Wait(ctx context.Context, rawURL string) error {
 	domain, err := extractDomain(rawURL)
@@ -37,6 +46,12 @@ func (l *Limiter) Wait(ctx context.Context, rawURL string) error {
 		return err
 	}
 
+	if l.robots != nil && !l.hasLimiter(domain) {
+		if delay, err := l.robots.GetCrawlDelay(ctx, rawURL); err == nil && delay > 0 {
+			l.applyCrawlDelay(domain, delay)
+		}
+	}
+
 	limiter := l.getLimiter(domain)
 	return limiter.Wait(ctx)
 }
@@ -77,6 +92,30 @@ func (l *Limiter) getLimiter(domain string) *rate.Limiter {
 	return limiter
 }
 
+// hasLimiter reports whether a domain already has a limiter
+func (l *Limiter) hasLimiter(domain string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, exists := l.limiters[domain]
+	return exists
+}
+
+// applyCrawlDelay creates a domain limiter no faster than one request per delay
+func (l *Limiter) applyCrawlDelay(domain string, delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.limiters[domain]; exists {
+		return
+	}
+
+	limit := l.defaultRate
+	if crawlLimit := rate.Every(delay); crawlLimit < limit {
+		limit = crawlLimit
+	}
+	l.limiters[domain] = rate.NewLimiter(limit, 1)
+}
+
 // SetDomainRate sets a custom rate limit for a specific domain
 func (l *Limiter) SetDomainRate(domain string, requestsPerSecond float64, burst int) {
 	l.mu.Lock()
@@ -2,8 +2,12 @@ package worker
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/ppiankov/entropia/internal/util"
 )
 
 func TestLimiter_New(t *testing.T) {
@@ -111,3 +115,31 @@ func TestExtractDomain(t *testing.T) {
 		t.Errorf("expected error for invalid URL")
 	}
 }
+
+func TestLimiter_CrawlDelay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			_, _ = w.Write([]byte("User-agent: *\nCrawl-delay: 2\n"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := NewLimiter(100, 5)
+	limiter.SetRobotsChecker(util.NewRobotsChecker("Entropia/0.1", 5*time.Second))
+
+	if err := limiter.Wait(context.Background(), server.URL+"/page"); err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+
+	// Crawl-delay of 2s overrides the 100 rps / burst 5 default for this host
+	if limiter.Allow(server.URL + "/other") {
+		t.Error("expected second request within crawl delay to be refused")
+	}
+
+	// Other hosts keep the default rate
+	if !limiter.Allow("http://example.com/page") {
+		t.Error("expected other hosts to keep the default rate")
+	}
+}
@@ -24,15 +24,21 @@ type Pool struct {
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 	closeOnce  sync.Once
+	jobsOnce   sync.Once
 }
 
 // NewPool creates a new worker pool with the specified number of workers
 func NewPool(workers int) *Pool {
+	return NewPoolWithContext(context.Background(), workers)
+}
+
+// NewPoolWithContext creates a worker pool whose jobs are cancelled with ctx
+func NewPoolWithContext(ctx context.Context, workers int) *Pool {
 	if workers <= 0 {
 		workers = 1
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
 
 	return &Pool{
 		workers:    workers,
@@ -63,12 +69,9 @@ func (p *Pool) worker(id int) {
 			if !ok {
 				return
 			}
-			result := job.Execute(p.ctx)
-			select {
-			case p.results <- result:
-			case <-p.ctx.Done():
-				return
-			}
+			// Always deliver a finished result, even after cancellation,
+			// so completed work is never lost
+			p.results <- job.Execute(p.ctx)
 		}
 	}
 }
@@ -82,16 +85,27 @@ func (p *Pool) Submit(job Job) {
 	}
 }
 
+// Results returns the channel results are delivered on.
+// It is closed once Close or Wait has drained all workers.
+func (p *Pool) Results() <-chan Result {
+	return p.results
+}
+
+// Close signals that no more jobs will be submitted, waits for the
+// workers to finish and closes the results channel. Results must be
+// consumed concurrently, otherwise workers block on delivery.
+func (p *Pool) Close() {
+	p.jobsOnce.Do(func() {
+		close(p.jobQueue)
+	})
+	p.wg.Wait()
+	p.closeResults()
+}
+
 // Wait waits for all jobs to complete and returns the results
 func (p *Pool) Wait() []Result {
-	// Close job queue to signal workers to exit when done
-	close(p.jobQueue)
-
-	// Use a goroutine to wait for workers and close results
-	go func() {
-		p.wg.Wait()
-		p.closeResults()
-	}()
+	// Close job queue and wait for workers in the background
+	go p.Close()
 
 	// Collect all results
 	var results []Result
@@ -102,11 +116,24 @@ func (p *Pool) Wait() []Result {
 	return results
 }
 
-// Shutdown shuts down the worker pool immediately
+// Shutdown shuts down the worker pool immediately, discarding results
+// that have not been consumed
 func (p *Pool) Shutdown() {
 	p.cancelFunc()
-	p.wg.Wait()
-	p.closeResults()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			p.closeResults()
+			return
+		case <-p.results:
+		}
+	}
 }
 
 func (p *Pool) closeResults() {